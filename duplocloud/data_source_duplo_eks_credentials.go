@@ -55,46 +55,9 @@ func dataSourceEksCredentialsRead(d *schema.ResourceData, m interface{}) error {
 	// Get the data from Duplo.
 	planID := d.Get("plan_id").(string)
 	c := m.(*duplosdk.Client)
-	infra, err := c.InfrastructureGetConfig(planID)
+	k8sConfig, err := planK8sCredentials(c, planID)
 	if err != nil {
-		return fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
-	}
-	k8sConfig := &duplosdk.DuploEksCredentials{}
-	// Now we know infra is not nil, proceed with checks
-	if infra != nil && planID != "default" {
-		if !infra.EnableK8Cluster && infra.Cloud != 2 {
-			return fmt.Errorf("no kubernetes cluster for this plan %s", planID)
-		} else if infra.Cloud == 2 && (infra.AksConfig == nil || !infra.AksConfig.CreateAndManage) {
-			return fmt.Errorf("no kubernetes cluster for plan %s", planID)
-		}
-
-		k8sConfig, err = c.GetPlanK8sJitAccess(planID)
-		if err != nil && !err.PossibleMissingAPI() {
-			return fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
-		}
-
-		// If it failed, try the fallback method.
-		if k8sConfig == nil {
-			k8sConfig, err = c.GetK8sCredentials(planID)
-			if err != nil {
-				return fmt.Errorf("failed to read EKS credentials: %s", err)
-			}
-		}
-	} else {
-		// First, try the newer method of obtaining a JIT access token.
-
-		k8sConfig, err = c.GetPlanK8sJitAccess(planID)
-		if err != nil && !err.PossibleMissingAPI() {
-			return fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
-		}
-
-		// If it failed, try the fallback method.
-		if k8sConfig == nil {
-			k8sConfig, err = c.GetK8sCredentials(planID)
-			if err != nil {
-				return fmt.Errorf("failed to read EKS credentials: %s", err)
-			}
-		}
+		return err
 	}
 	d.SetId(planID)
 
@@ -114,3 +77,35 @@ func dataSourceEksCredentialsRead(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[TRACE] dataSourceEksCredentialsRead ******** end")
 	return nil
 }
+
+// planK8sCredentials retrieves the credentials of a plan's kubernetes cluster.
+func planK8sCredentials(c *duplosdk.Client, planID string) (*duplosdk.DuploEksCredentials, error) {
+	infra, err := c.InfrastructureGetConfig(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
+	}
+
+	// Check that the plan has a cluster, unless it is the default plan.
+	if infra != nil && planID != "default" {
+		if !infra.EnableK8Cluster && infra.Cloud != 2 {
+			return nil, fmt.Errorf("no kubernetes cluster for this plan %s", planID)
+		} else if infra.Cloud == 2 && (infra.AksConfig == nil || !infra.AksConfig.CreateAndManage) {
+			return nil, fmt.Errorf("no kubernetes cluster for plan %s", planID)
+		}
+	}
+
+	// First, try the newer method of obtaining a JIT access token.
+	k8sConfig, err := c.GetPlanK8sJitAccess(planID)
+	if err != nil && !err.PossibleMissingAPI() {
+		return nil, fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
+	}
+
+	// If it failed, try the fallback method.
+	if k8sConfig == nil {
+		k8sConfig, err = c.GetK8sCredentials(planID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read EKS credentials: %s", err)
+		}
+	}
+	return k8sConfig, nil
+}
@@ -2,7 +2,6 @@ package duplocloud
 
 import (
 	"encoding/base64"
-	"fmt"
 	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
 	"log"
 
@@ -55,48 +54,9 @@ func dataSourceGKECredentialsRead(d *schema.ResourceData, m interface{}) error {
 	// Get the data from Duplo.
 	planID := d.Get("plan_id").(string)
 	c := m.(*duplosdk.Client)
-	infra, err := c.InfrastructureGetConfig(planID)
+	k8sConfig, err := planK8sCredentials(c, planID)
 	if err != nil {
-		return fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
-	}
-	k8sConfig := &duplosdk.DuploEksCredentials{}
-
-	// Now we know infra is not nil, proceed with checks
-	if infra != nil && planID != "default" {
-		if !infra.EnableK8Cluster && infra.Cloud != 2 {
-			return fmt.Errorf("no kubernetes cluster for this plan %s", planID)
-		} else if infra.Cloud == 2 {
-			// Check for AksConfig only if Cloud is 2 (relevant scenario)
-			if infra.AksConfig == nil || !infra.AksConfig.CreateAndManage {
-				return fmt.Errorf("no kubernetes cluster for plan %s", planID)
-			}
-		}
-		// First, try the newer method of obtaining a JIT access token.
-		k8sConfig, err = c.GetPlanK8sJitAccess(planID)
-		if err != nil && !err.PossibleMissingAPI() {
-			return fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
-		}
-
-		// If it failed, try the fallback method.
-		if k8sConfig == nil {
-			k8sConfig, err = c.GetK8sCredentials(planID)
-			if err != nil {
-				return fmt.Errorf("failed to read EKS credentials: %s", err)
-			}
-		}
-	} else {
-		k8sConfig, err = c.GetPlanK8sJitAccess(planID)
-		if err != nil && !err.PossibleMissingAPI() {
-			return fmt.Errorf("failed to get plan %s kubernetes JIT access: %s", planID, err)
-		}
-
-		// If it failed, try the fallback method.
-		if k8sConfig == nil {
-			k8sConfig, err = c.GetK8sCredentials(planID)
-			if err != nil {
-				return fmt.Errorf("failed to read EKS credentials: %s", err)
-			}
-		}
+		return err
 	}
 	d.SetId(planID)
 
@@ -49,17 +49,38 @@ func dataSourceK8SecretRead(ctx context.Context, d *schema.ResourceData, m inter
 	log.Printf("[TRACE] dataSourceK8SecretRead(%s, %s): start", tenantID, name)
 
 	c := m.(*duplosdk.Client)
-	usrResp, err := c.UserInfo()
+	readOnly, err := k8sSecretReadOnly(c, tenantID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if usrResp == nil {
-		return diag.Errorf("user not found")
+	rp, err := c.K8SecretGet(tenantID, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rp == nil || rp.SecretName == "" {
+		return diag.Errorf("tenant k8 secret '%s' not found", name)
+	}
+	// Convert the results into TF state.
+	flattenK8sSecret(d, rp, readOnly)
+	d.SetId(fmt.Sprintf("%s/%s", tenantID, name))
 
+	log.Printf("[TRACE] dataSourceK8SecretRead(%s, %s): end", tenantID, name)
+
+	return nil
+}
+
+// k8sSecretReadOnly returns true if the current user may not see the values of a tenant's kubernetes secrets.
+func k8sSecretReadOnly(c *duplosdk.Client, tenantID string) (bool, error) {
+	usrResp, err := c.UserInfo()
+	if err != nil {
+		return false, err
+	}
+	if usrResp == nil {
+		return false, fmt.Errorf("user not found")
 	}
 	tennantAccess, err := c.TenantAccessGet(usrResp.Username)
 	if err != nil {
-		return diag.FromErr(err)
+		return false, err
 	}
 	if len(tennantAccess) > 0 && !usrResp.IsReadOnly {
 		for _, tenantAccessInfo := range tennantAccess {
@@ -73,23 +94,10 @@ func dataSourceK8SecretRead(ctx context.Context, d *schema.ResourceData, m inter
 	}
 	access, err := c.SystemSettingGet("AllowReadonlyK8sSecrets")
 	if err != nil {
-		return diag.FromErr(err)
+		return false, err
 	}
 	if access != nil && strings.ToLower(access.Value) == "true" {
 		usrResp.IsReadOnly = false
 	}
-	rp, err := c.K8SecretGet(tenantID, name)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if rp == nil || rp.SecretName == "" {
-		return diag.Errorf("tenant k8 secret '%s' not found", name)
-	}
-	// Convert the results into TF state.
-	flattenK8sSecret(d, rp, usrResp.IsReadOnly)
-	d.SetId(fmt.Sprintf("%s/%s", tenantID, name))
-
-	log.Printf("[TRACE] dataSourceK8SecretRead(%s, %s): end", tenantID, name)
-
-	return nil
+	return usrResp.IsReadOnly, nil
 }
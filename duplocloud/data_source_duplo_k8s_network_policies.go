@@ -0,0 +1,94 @@
+package duplocloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceK8sNetworkPolicies() *schema.Resource {
+	return &schema.Resource{
+		Description: "`duplocloud_k8s_network_policies` lists all Kubernetes network policies in a Duplo tenant.",
+
+		ReadContext: dataSourceK8sNetworkPoliciesRead,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant to list the network policies of.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"network_policies": {
+				Description: "The network policies in the tenant.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the network policy.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"labels": {
+							Description: "The labels of the network policy.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"annotations": {
+							Description: "The annotations of the network policy.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"spec": {
+							Description: "Spec of the network policy.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: networkPolicySpecFields(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceK8sNetworkPoliciesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tenantID := d.Get("tenant_id").(string)
+
+	log.Printf("[TRACE] dataSourceK8sNetworkPoliciesRead(%s): start", tenantID)
+
+	// List from Duplo.
+	c := m.(*duplosdk.Client)
+	rp, err := c.K8sNetworkPolicyGetList(tenantID)
+	if err != nil {
+		return diag.Errorf("Failed to list network policies. API error: %s", err)
+	}
+
+	// Convert the results into TF state.
+	list := make([]map[string]interface{}, 0, len(*rp))
+	for _, np := range *rp {
+		list = append(list, map[string]interface{}{
+			"name":        np.Metadata.Name,
+			"labels":      np.Metadata.Labels,
+			"annotations": np.Metadata.Annotations,
+			"spec":        flattenNetworkPolicySpec(np.Spec),
+		})
+	}
+
+	if err := d.Set("network_policies", list); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(tenantID)
+
+	log.Printf("[TRACE] dataSourceK8sNetworkPoliciesRead(%s): end", tenantID)
+	return nil
+}
@@ -59,12 +59,34 @@ func dataSourceTenantEksCredentialsRead(d *schema.ResourceData, m interface{}) e
 	// Get the data from Duplo.
 	tenantID := d.Get("tenant_id").(string)
 	c := m.(*duplosdk.Client)
+	k8sConfig, caCertificateData, err := tenantK8sCredentials(c, tenantID)
+	if err != nil {
+		return err
+	}
+	d.SetId(tenantID)
+
+	// Set the Terraform resource data
+	d.Set("tenant_id", tenantID)
+	d.Set("name", k8sConfig.Name)
+	d.Set("endpoint", k8sConfig.APIServer)
+	d.Set("region", k8sConfig.AwsRegion)
+	d.Set("version", k8sConfig.K8sVersion)
+	d.Set("token", k8sConfig.Token)
+	d.Set("ca_certificate_data", caCertificateData)
+	d.Set("namespace", k8sConfig.DefaultNamespace)
+
+	log.Printf("[TRACE] dataSourceTenantEksCredentialsRead ******** end")
+	return nil
+}
+
+// tenantK8sCredentials retrieves a tenant's kubernetes credentials, and their decoded CA certificate.
+func tenantK8sCredentials(c *duplosdk.Client, tenantID string) (*duplosdk.DuploTenantK8sCredentials, string, error) {
 	caCertificateData := ""
 
 	// First, try the newer method of obtaining a JIT access token.
 	k8sConfig, err := c.GetTenantK8sJitAccess(tenantID)
 	if err != nil && !err.PossibleMissingAPI() {
-		return fmt.Errorf("failed to get tenant %s kubernetes JIT access: %s", tenantID, err)
+		return nil, "", fmt.Errorf("failed to get tenant %s kubernetes JIT access: %s", tenantID, err)
 	}
 	if k8sConfig != nil {
 		bytes, err := base64.StdEncoding.DecodeString(k8sConfig.CertificateAuthorityDataBase64)
@@ -76,29 +98,16 @@ func dataSourceTenantEksCredentialsRead(d *schema.ResourceData, m interface{}) e
 	} else {
 		k8sConfig, err = c.GetTenantK8sCredentials(tenantID)
 		if err != nil {
-			return fmt.Errorf("failed to read tenant %s kubernetes config: %s", tenantID, err)
+			return nil, "", fmt.Errorf("failed to read tenant %s kubernetes config: %s", tenantID, err)
 		}
 		k8sSecret, err := c.GetTenantEksSecret(tenantID)
 		if err != nil {
-			return fmt.Errorf("failed to read tenant %s EKS service account token: %s", tenantID, err)
+			return nil, "", fmt.Errorf("failed to read tenant %s EKS service account token: %s", tenantID, err)
 		}
 
 		k8sConfig.Token = k8sSecret.Data["token"]
 		k8sConfig.DefaultNamespace = k8sSecret.Data["namespace"]
 		caCertificateData = k8sSecret.Data["ca.crt"]
 	}
-	d.SetId(tenantID)
-
-	// Set the Terraform resource data
-	d.Set("tenant_id", tenantID)
-	d.Set("name", k8sConfig.Name)
-	d.Set("endpoint", k8sConfig.APIServer)
-	d.Set("region", k8sConfig.AwsRegion)
-	d.Set("version", k8sConfig.K8sVersion)
-	d.Set("token", k8sConfig.Token)
-	d.Set("ca_certificate_data", caCertificateData)
-	d.Set("namespace", k8sConfig.DefaultNamespace)
-
-	log.Printf("[TRACE] dataSourceTenantEksCredentialsRead ******** end")
-	return nil
+	return k8sConfig, caCertificateData, nil
 }
@@ -0,0 +1,419 @@
+package duplocloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// tenantInventoryItem is a normalized description of an object found in a tenant.
+type tenantInventoryItem struct {
+	Type          string // normalized type of the object, such as "s3_bucket"
+	Name          string // name of the object, as reported by Duplo
+	ID            string // Duplo identifier of the object within the tenant
+	CloudID       string // cloud ARN, or cloud resource ID / self link
+	TerraformType string // Terraform resource type that manages the object, if any
+}
+
+// tenantInventorySource lists one kind of object in a tenant.
+type tenantInventorySource struct {
+	name   string // name of the SDK method, for diagnostics
+	clouds []int  // clouds whose tenants support the source, or nil for all of them
+	list   func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError)
+}
+
+// Normalized types of the generic AWS cloud resources, by Duplo resource type.
+var awsCloudResourceInventoryTypes = map[int]struct{ typ, terraformType string }{
+	duplosdk.ResourceTypeS3Bucket:          {"s3_bucket", "duplocloud_s3_bucket"},
+	duplosdk.ResourceTypeDynamoDBTable:     {"dynamodb_table", "duplocloud_aws_dynamodb_table_v2"},
+	duplosdk.ResourceTypeSQSQueue:          {"sqs_queue", "duplocloud_aws_sqs_queue"},
+	duplosdk.ResourceTypeSNSTopic:          {"sns_topic", "duplocloud_aws_sns_topic"},
+	duplosdk.ResourceTypeLambdaFunction:    {"lambda_function", "duplocloud_aws_lambda_function"},
+	duplosdk.ResourceTypeApiGatewayRestAPI: {"api_gateway_rest_api", ""},
+	duplosdk.ResourceTypeKafkaCluster:      {"kafka_cluster", "duplocloud_aws_kafka_cluster"},
+	duplosdk.ResourceTypeApplicationLB:     {"load_balancer", "duplocloud_aws_load_balancer"},
+	duplosdk.ResourceTypeElasticSearch:     {"elasticsearch", "duplocloud_aws_elasticsearch"},
+}
+
+var tenantInventorySources = []tenantInventorySource{
+	// Objects managed by Duplo in every cloud.
+	{name: "ReplicationControllerList", list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.ReplicationControllerList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, rc := range *list {
+			items = append(items, tenantInventoryItem{Type: "duplo_service", Name: rc.Name, ID: rc.Name, TerraformType: "duplocloud_duplo_service"})
+		}
+		return items, nil
+	}},
+	{name: "K8SecretGetList", list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.K8SecretGetList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, secret := range *list {
+			items = append(items, tenantInventoryItem{Type: "k8s_secret", Name: secret.SecretName, ID: secret.SecretName, TerraformType: "duplocloud_k8_secret"})
+		}
+		return items, nil
+	}},
+
+	// AWS objects.
+	{name: "TenantListAwsCloudResources", clouds: []int{AWS_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.TenantListAwsCloudResources(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, res := range *list {
+			item := tenantInventoryItem{Type: fmt.Sprintf("aws_cloud_resource_%d", res.Type), Name: res.Name, ID: res.Name, CloudID: res.Arn}
+			if t, ok := awsCloudResourceInventoryTypes[res.Type]; ok {
+				item.Type, item.TerraformType = t.typ, t.terraformType
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}},
+	{name: "NativeHostGetList", clouds: []int{AWS_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.NativeHostGetList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, host := range *list {
+			items = append(items, tenantInventoryItem{Type: "host", Name: host.FriendlyName, ID: host.InstanceID, CloudID: host.InstanceID, TerraformType: "duplocloud_aws_host"})
+		}
+		return items, nil
+	}},
+	{name: "EcsServiceList", clouds: []int{AWS_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.EcsServiceList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, svc := range *list {
+			items = append(items, tenantInventoryItem{Type: "ecs_service", Name: svc.Name, ID: svc.Name, TerraformType: "duplocloud_ecs_service"})
+		}
+		return items, nil
+	}},
+	{name: "RdsInstanceList", clouds: []int{AWS_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.RdsInstanceList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, rds := range *list {
+			items = append(items, tenantInventoryItem{Type: "rds_instance", Name: rds.Identifier, ID: rds.Identifier, CloudID: rds.Arn, TerraformType: "duplocloud_rds_instance"})
+		}
+		return items, nil
+	}},
+
+	// Azure objects.
+	{name: "AzureVirtualMachineList", clouds: []int{AZURE_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.AzureVirtualMachineList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, vm := range *list {
+			items = append(items, tenantInventoryItem{Type: "azure_virtual_machine", Name: vm.Name, ID: vm.Name, CloudID: vm.ID, TerraformType: "duplocloud_azure_virtual_machine"})
+		}
+		return items, nil
+	}},
+	{name: "StorageAccountList", clouds: []int{AZURE_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.StorageAccountList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, sa := range *list {
+			items = append(items, tenantInventoryItem{Type: "azure_storage_account", Name: sa.Name, ID: sa.Name, CloudID: sa.ID, TerraformType: "duplocloud_azure_storage_account"})
+		}
+		return items, nil
+	}},
+	{name: "TenantKeyVaultList", clouds: []int{AZURE_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.TenantKeyVaultList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, kv := range *list {
+			items = append(items, tenantInventoryItem{Type: "azure_key_vault", Name: kv.Name, ID: kv.Name, CloudID: kv.ID, TerraformType: "duplocloud_azure_tenant_key_vault"})
+		}
+		return items, nil
+	}},
+	{name: "RedisCacheList", clouds: []int{AZURE_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.RedisCacheList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, redis := range *list {
+			items = append(items, tenantInventoryItem{Type: "azure_redis_cache", Name: redis.Name, ID: redis.Name, CloudID: redis.ID, TerraformType: "duplocloud_azure_redis_cache"})
+		}
+		return items, nil
+	}},
+	{name: "MsSqlServerList", clouds: []int{AZURE_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.MsSqlServerList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, server := range *list {
+			items = append(items, tenantInventoryItem{Type: "azure_mssql_server", Name: server.Name, ID: server.Name, CloudID: server.ID, TerraformType: "duplocloud_azure_mssql_server"})
+		}
+		return items, nil
+	}},
+	{name: "MySqlServerList", clouds: []int{AZURE_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.MySqlServerList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, server := range *list {
+			items = append(items, tenantInventoryItem{Type: "azure_mysql_server", Name: server.Name, ID: server.Name, CloudID: server.ID})
+		}
+		return items, nil
+	}},
+	{name: "PostgresqlServerList", clouds: []int{AZURE_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.PostgresqlServerList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, server := range *list {
+			items = append(items, tenantInventoryItem{Type: "azure_postgresql_server", Name: server.Name, ID: server.Name, CloudID: server.ID, TerraformType: "duplocloud_azure_postgresql_database"})
+		}
+		return items, nil
+	}},
+
+	// GCP objects.
+	{name: "GcpStorageBucketGetList", clouds: []int{GCP_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.GcpStorageBucketGetList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, bucket := range *list {
+			items = append(items, tenantInventoryItem{Type: "gcp_storage_bucket", Name: bucket.Name, ID: bucket.Name, CloudID: bucket.SelfLink, TerraformType: "duplocloud_gcp_storage_bucket_v2"})
+		}
+		return items, nil
+	}},
+	{name: "GcpPubsubTopicGetList", clouds: []int{GCP_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.GcpPubsubTopicGetList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, topic := range *list {
+			items = append(items, tenantInventoryItem{Type: "gcp_pubsub_topic", Name: topic.Name, ID: topic.Name, CloudID: topic.SelfLink, TerraformType: "duplocloud_gcp_pubsub_topic"})
+		}
+		return items, nil
+	}},
+	{name: "GcpCloudFunctionGetList", clouds: []int{GCP_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.GcpCloudFunctionGetList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, fn := range *list {
+			items = append(items, tenantInventoryItem{Type: "gcp_cloud_function", Name: fn.Name, ID: fn.Name, CloudID: fn.SelfLink, TerraformType: "duplocloud_gcp_cloud_function"})
+		}
+		return items, nil
+	}},
+	{name: "GcpSchedulerJobGetList", clouds: []int{GCP_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.GcpSchedulerJobGetList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, job := range *list {
+			items = append(items, tenantInventoryItem{Type: "gcp_scheduler_job", Name: job.Name, ID: job.Name, CloudID: job.SelfLink, TerraformType: "duplocloud_gcp_scheduler_job"})
+		}
+		return items, nil
+	}},
+	{name: "GCPSqlDBInstanceList", clouds: []int{GCP_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.GCPSqlDBInstanceList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, db := range *list {
+			items = append(items, tenantInventoryItem{Type: "gcp_sql_database_instance", Name: db.Name, ID: db.Name, CloudID: db.SelfLink, TerraformType: "duplocloud_gcp_sql_database_instance"})
+		}
+		return items, nil
+	}},
+	{name: "RedisInstanceList", clouds: []int{GCP_CLOUD}, list: func(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, duplosdk.ClientError) {
+		list, err := c.RedisInstanceList(tenantID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		items := make([]tenantInventoryItem, 0, len(*list))
+		for _, redis := range *list {
+			items = append(items, tenantInventoryItem{Type: "gcp_redis_instance", Name: redis.Name, ID: redis.Name, CloudID: redis.SelfLink, TerraformType: "duplocloud_gcp_redis_instance"})
+		}
+		return items, nil
+	}},
+}
+
+func dataSourceTenantInventory() *schema.Resource {
+	return &schema.Resource{
+		Description: "`duplocloud_tenant_inventory` lists the objects found in a Duplo tenant, across services, hosts, " +
+			"Kubernetes secrets and cloud resources.  It can be compared with the Terraform state to find objects that were " +
+			"created outside of Terraform.",
+
+		ReadContext: dataSourceTenantInventoryRead,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant to list the objects of.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"types": {
+				Description: "Only list objects of these normalized types, such as `s3_bucket` or `duplo_service`.  All types are listed when not set.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"resources": {
+				Description: "The objects found in the tenant, sorted by type and name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "The normalized type of the object, such as `s3_bucket`, `duplo_service` or `gcp_pubsub_topic`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the object, as reported by Duplo.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"id": {
+							Description: "The identifier of the object within the tenant.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cloud_id": {
+							Description: "The cloud ARN, resource ID or self link of the object, when it has one.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"terraform_type": {
+							Description: "The type of the Terraform resource that manages this kind of object, if there is one.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTenantInventoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tenantID := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] dataSourceTenantInventoryRead(%s): start", tenantID)
+
+	var types map[string]bool
+	if v, ok := d.GetOk("types"); ok {
+		types = map[string]bool{}
+		for _, t := range v.(*schema.Set).List() {
+			types[t.(string)] = true
+		}
+	}
+
+	c := m.(*duplosdk.Client)
+	items, diags := tenantInventory(c, tenantID)
+	if diags.HasError() {
+		return diags
+	}
+
+	resources := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if types != nil && !types[item.Type] {
+			continue
+		}
+		resources = append(resources, map[string]interface{}{
+			"type":           item.Type,
+			"name":           item.Name,
+			"id":             item.ID,
+			"cloud_id":       item.CloudID,
+			"terraform_type": item.TerraformType,
+		})
+	}
+
+	d.SetId(tenantID)
+	if err := d.Set("resources", resources); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	log.Printf("[TRACE] dataSourceTenantInventoryRead(%s): end", tenantID)
+	return diags
+}
+
+// tenantInventory lists the objects found in a tenant, sorted by type and name.
+//
+// Lists that the Duplo portal does not support are skipped with a warning, so that one missing
+// API does not hide the rest of the inventory.
+func tenantInventory(c *duplosdk.Client, tenantID string) ([]tenantInventoryItem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tenant, err := c.TenantGetV2(tenantID)
+	if err != nil {
+		return nil, diag.Errorf("Unable to retrieve tenant '%s': %s", tenantID, err)
+	}
+	if tenant == nil {
+		return nil, diag.Errorf("Tenant '%s' not found", tenantID)
+	}
+	infra, err := c.InfrastructureGetConfig(tenant.PlanID)
+	if err != nil {
+		return nil, diag.Errorf("Unable to retrieve duplo infrastructure '%s': %s", tenant.PlanID, err)
+	}
+	if infra == nil {
+		return nil, diag.Errorf("Duplo infrastructure '%s' not found", tenant.PlanID)
+	}
+
+	items := []tenantInventoryItem{}
+	for _, source := range tenantInventorySources {
+		if source.clouds != nil && !slices.Contains(source.clouds, infra.Cloud) {
+			continue
+		}
+
+		list, err := source.list(c, tenantID)
+		if err != nil {
+			if errors.Is(err, duplosdk.ErrAPIUnsupported) || errors.Is(err, duplosdk.ErrNotFound) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Unable to list objects with %s: skipping them.", source.name),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+			return nil, append(diags, clientErrorDiagnostics(fmt.Sprintf("Unable to list objects with %s in tenant '%s'", source.name, tenantID), err, nil)...)
+		}
+		items = append(items, list...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items, diags
+}
@@ -0,0 +1,122 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+	"github.com/duplocloud/terraform-provider-duplocloud/internal/duplosdktest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTenantInventoryRead(t *testing.T, c *duplosdk.Client) ([]interface{}, diag.Diagnostics) {
+	d := schema.TestResourceDataRaw(t, dataSourceTenantInventory().Schema, map[string]interface{}{"tenant_id": Tenant_testacc1a})
+	diags := dataSourceTenantInventory().ReadContext(context.Background(), d, c)
+	return d.Get("resources").([]interface{}), diags
+}
+
+func testTenantInventoryWarnings(diags diag.Diagnostics) []string {
+	warnings := []string{}
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			warnings = append(warnings, d.Summary)
+		}
+	}
+	return warnings
+}
+
+func TestEmulator_TenantInventory(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	require.Nil(t, c.ReplicationControllerCreate(Tenant_testacc1a, &duplosdk.DuploReplicationControllerCreateRequest{
+		Name:     "web",
+		Image:    "nginx:latest",
+		Replicas: 1,
+	}))
+	require.Nil(t, c.K8SecretCreate(Tenant_testacc1a, &duplosdk.DuploK8sSecret{
+		SecretName: "creds",
+		SecretType: "Opaque",
+		SecretData: map[string]interface{}{"password": "hunter2"},
+	}))
+	require.Nil(t, c.TenantCreateS3Bucket(Tenant_testacc1a, duplosdk.DuploS3BucketRequest{Name: "duploservices-testacc1a-logs"}))
+
+	resources, diags := testTenantInventoryRead(t, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, testTenantInventoryWarnings(diags))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"type": "duplo_service", "name": "web", "id": "web", "cloud_id": "", "terraform_type": "duplocloud_duplo_service",
+		},
+		map[string]interface{}{
+			"type": "host", "name": "duploservices-testacc1a-host1", "id": "i-a4a64e4407f5ae678", "cloud_id": "i-a4a64e4407f5ae678",
+			"terraform_type": "duplocloud_aws_host",
+		},
+		map[string]interface{}{
+			"type": "k8s_secret", "name": "creds", "id": "creds", "cloud_id": "", "terraform_type": "duplocloud_k8_secret",
+		},
+		map[string]interface{}{
+			"type": "s3_bucket", "name": "duploservices-testacc1a-logs", "id": "duploservices-testacc1a-logs",
+			"cloud_id": "arn:aws:s3:::duploservices-testacc1a-logs", "terraform_type": "duplocloud_s3_bucket",
+		},
+	}, resources)
+}
+
+func TestEmulator_TenantInventoryUnsupportedSource(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	// A portal without the ECS API only loses the ECS services from the inventory.
+	duplosdktest.InjectFaults(duplosdktest.EmuFault{
+		Method: "GET",
+		Route:  "/subscriptions/:tenantId/GetEcsServices",
+		Status: 400,
+		Body:   `{"Message":"No action was found on the controller 'Subscriptions' that matches the request."}`,
+	})
+	resources, diags := testTenantInventoryRead(t, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"Unable to list objects with EcsServiceList: skipping them."}, testTenantInventoryWarnings(diags))
+	require.Len(t, resources, 1)
+	assert.Equal(t, "host", resources[0].(map[string]interface{})["type"])
+
+	// Any other failure fails the whole inventory, rather than silently hiding objects.
+	duplosdktest.ResetEmulator()
+	duplosdktest.InjectFaults(duplosdktest.EmuFailTimes("GET", "/subscriptions/:tenantId/GetEcsServices", 0, 403))
+	_, diags = testTenantInventoryRead(t, c)
+	assert.True(t, diags.HasError())
+}
+
+func TestEmulator_TenantInventoryCloud(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	require.Nil(t, c.ReplicationControllerCreate(Tenant_testacc1a, &duplosdk.DuploReplicationControllerCreateRequest{
+		Name:     "web",
+		Image:    "nginx:latest",
+		Replicas: 1,
+	}))
+
+	// In an Azure tenant, the AWS hosts are not listed, and the Azure APIs that the emulator
+	// does not serve are reported as missing.
+	infra := duplosdk.DuploInfrastructureConfig{}
+	duplosdktest.PatchFixture("infra/testacc1", &infra, func() { infra.Cloud = AZURE_CLOUD })
+	defer duplosdktest.PatchFixture("infra/testacc1", &infra, func() { infra.Cloud = AWS_CLOUD })
+
+	resources, diags := testTenantInventoryRead(t, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"type": "duplo_service", "name": "web", "id": "web", "cloud_id": "", "terraform_type": "duplocloud_duplo_service",
+		},
+	}, resources)
+	assert.Equal(t, []string{
+		"Unable to list objects with AzureVirtualMachineList: skipping them.",
+		"Unable to list objects with StorageAccountList: skipping them.",
+		"Unable to list objects with TenantKeyVaultList: skipping them.",
+		"Unable to list objects with RedisCacheList: skipping them.",
+		"Unable to list objects with MsSqlServerList: skipping them.",
+		"Unable to list objects with MySqlServerList: skipping them.",
+		"Unable to list objects with PostgresqlServerList: skipping them.",
+	}, testTenantInventoryWarnings(diags))
+}
@@ -0,0 +1,81 @@
+package duplocloud
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deletionProtectionSchema returns the schema of the per-resource deletion_protection argument,
+// for resources that are only protected by the provider.
+func deletionProtectionSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Whether or not to refuse to delete the %s.  "+
+			"To delete it, set this to `false` and apply the change first.", kind),
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+}
+
+// checkDeletionProtection returns an error if a resource must not be deleted, because its deletion_protection
+// argument is set, or because the provider's deletion_protection policy covers it.
+func checkDeletionProtection(d *schema.ResourceData, m interface{}, resourceType, name string) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Deletion protection is enabled for %s '%s'", resourceType, name),
+			Detail:   "Will NOT delete it.  To delete it, set `deletion_protection = false`, apply the change, and try again.",
+		}}
+	}
+
+	if c, ok := m.(*duplosdk.Client); ok && c.DeletionProtection.Protects(resourceType, name) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Deletion protection is enabled for %s '%s'", resourceType, name),
+			Detail:   "Will NOT delete it, because it matches the provider's `deletion_protection` rules.  To delete it, remove it from those rules first.",
+		}}
+	}
+	return nil
+}
+
+// expandDeletionProtectionPolicy converts the provider's deletion_protection blocks to a policy,
+// or returns nil if there are none.
+func expandDeletionProtectionPolicy(blocks []interface{}) *duplosdk.DeletionProtectionPolicy {
+	if len(blocks) == 0 {
+		return nil
+	}
+	policy := duplosdk.DeletionProtectionPolicy{}
+	for _, raw := range blocks {
+		rule := duplosdk.DeletionProtectionRule{}
+		if block, ok := raw.(map[string]interface{}); ok {
+			rule.ResourceType, _ = block["resource_type"].(string)
+			if names, ok := block["names"].([]interface{}); ok {
+				for _, name := range names {
+					if s, ok := name.(string); ok {
+						rule.Names = append(rule.Names, s)
+					}
+				}
+			}
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+	return &policy
+}
+
+func validateGlob(v interface{}, p cty.Path) diag.Diagnostics {
+	if _, err := path.Match(v.(string), ""); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid glob '%s'", v.(string)),
+			Detail:        err.Error(),
+			AttributePath: p,
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package duplocloud
+
+import (
+	"testing"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletionProtectionPolicy(t *testing.T) {
+	policy := expandDeletionProtectionPolicy([]interface{}{
+		map[string]interface{}{"resource_type": "duplocloud_infrastructure", "names": []interface{}{}},
+		map[string]interface{}{"resource_type": "duplocloud_*", "names": []interface{}{"prod*", "shared"}},
+	})
+
+	assert.True(t, policy.Protects("duplocloud_infrastructure", "dev"))
+	assert.True(t, policy.Protects("duplocloud_tenant", "prod01"))
+	assert.True(t, policy.Protects("duplocloud_rds_instance", "shared"))
+	assert.False(t, policy.Protects("duplocloud_tenant", "dev"))
+	assert.False(t, policy.Protects("duplocloud_rds_instance", "shared2"))
+
+	assert.Nil(t, expandDeletionProtectionPolicy(nil))
+	assert.False(t, (*duplosdk.DeletionProtectionPolicy)(nil).Protects("duplocloud_tenant", "prod"))
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{"deletion_protection": deletionProtectionSchema("tenant")}
+	c := &duplosdk.Client{DeletionProtection: &duplosdk.DeletionProtectionPolicy{
+		Rules: []duplosdk.DeletionProtectionRule{{ResourceType: "duplocloud_tenant", Names: []string{"prod*"}}},
+	}}
+
+	// Protected by the resource itself.
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{"deletion_protection": true})
+	diags := checkDeletionProtection(d, &duplosdk.Client{}, "duplocloud_tenant", "dev")
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "deletion_protection = false")
+
+	// Protected by the provider.
+	d = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	diags = checkDeletionProtection(d, c, "duplocloud_tenant", "prod01")
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "provider's `deletion_protection` rules")
+
+	// Not protected.
+	assert.Nil(t, checkDeletionProtection(d, c, "duplocloud_tenant", "dev"))
+	assert.Nil(t, checkDeletionProtection(d, c, "duplocloud_infrastructure", "prod"))
+}
@@ -0,0 +1,53 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+)
+
+type ephemeralAdminAwsCredentials struct {
+	ephemeralBase
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralAdminAwsCredentials{}
+
+func newEphemeralAdminAwsCredentials() ephemeral.EphemeralResource {
+	return &ephemeralAdminAwsCredentials{}
+}
+
+func (e *ephemeralAdminAwsCredentials) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_aws_credentials"
+}
+
+func (e *ephemeralAdminAwsCredentials) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "`duplocloud_admin_aws_credentials` opens just-in-time administrator AWS credentials, without storing them in the state.",
+		Attributes:          ephemeralAwsCredentialsSchema(),
+	}
+}
+
+func (e *ephemeralAdminAwsCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralAwsCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	c, diags := e.client(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("[TRACE] ephemeralAdminAwsCredentialsOpen: start")
+
+	creds, err := c.AdminGetAwsCredentials()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read AWS credentials", fmt.Sprintf("failed to read admin AWS credentials: %s", err))
+		return
+	}
+	data = flattenEphemeralAwsCredentials(creds.ConsoleURL, creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken, creds.Region)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	log.Printf("[TRACE] ephemeralAdminAwsCredentialsOpen: end")
+}
@@ -0,0 +1,112 @@
+package duplocloud
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ephemeralPlanK8sCredentials opens the credentials of a plan's kubernetes cluster.  It backs both the EKS
+// and GKE credentials, like their data sources.
+type ephemeralPlanK8sCredentials struct {
+	ephemeralBase
+	typeName string
+	cluster  string
+}
+
+type ephemeralPlanK8sCredentialsModel struct {
+	PlanID            types.String `tfsdk:"plan_id"`
+	Name              types.String `tfsdk:"name"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+	Token             types.String `tfsdk:"token"`
+	Region            types.String `tfsdk:"region"`
+	Version           types.String `tfsdk:"version"`
+	CaCertificateData types.String `tfsdk:"ca_certificate_data"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralPlanK8sCredentials{}
+
+func newEphemeralEksCredentials() ephemeral.EphemeralResource {
+	return &ephemeralPlanK8sCredentials{typeName: "_eks_credentials", cluster: "EKS"}
+}
+
+func newEphemeralGkeCredentials() ephemeral.EphemeralResource {
+	return &ephemeralPlanK8sCredentials{typeName: "_gke_credentials", cluster: "GKE"}
+}
+
+func (e *ephemeralPlanK8sCredentials) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + e.typeName
+}
+
+func (e *ephemeralPlanK8sCredentials) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "`duplocloud" + e.typeName + "` opens just-in-time credentials for the " + e.cluster + " cluster of a Duplo plan, without storing them in the state.",
+		Attributes: map[string]schema.Attribute{
+			"plan_id": schema.StringAttribute{
+				MarkdownDescription: "The name of the plan, which is also the name of its infrastructure.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the cluster.",
+				Computed:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The URL of the cluster's API server.",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "A bearer token that authenticates to the cluster.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The cloud region of the cluster.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The kubernetes version of the cluster.",
+				Computed:            true,
+			},
+			"ca_certificate_data": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded certificate of the cluster's certificate authority.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *ephemeralPlanK8sCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralPlanK8sCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	c, diags := e.client(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planID := data.PlanID.ValueString()
+	log.Printf("[TRACE] ephemeralPlanK8sCredentialsOpen(%s): start", planID)
+
+	k8sConfig, err := planK8sCredentials(c, planID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read "+e.cluster+" credentials", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(k8sConfig.Name)
+	data.Endpoint = types.StringValue(k8sConfig.APIServer)
+	data.Token = types.StringValue(k8sConfig.Token)
+	data.Region = types.StringValue(k8sConfig.AwsRegion)
+	data.Version = types.StringValue(k8sConfig.K8sVersion)
+	data.CaCertificateData = types.StringNull()
+	if bytes, err := base64.StdEncoding.DecodeString(k8sConfig.CertificateAuthorityDataBase64); err == nil {
+		data.CaCertificateData = types.StringValue(string(bytes))
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	log.Printf("[TRACE] ephemeralPlanK8sCredentialsOpen(%s): end", planID)
+}
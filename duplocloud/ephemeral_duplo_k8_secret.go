@@ -0,0 +1,125 @@
+package duplocloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ephemeralK8Secret struct {
+	ephemeralBase
+}
+
+type ephemeralK8SecretModel struct {
+	TenantID          types.String `tfsdk:"tenant_id"`
+	SecretName        types.String `tfsdk:"secret_name"`
+	SecretType        types.String `tfsdk:"secret_type"`
+	SecretVersion     types.String `tfsdk:"secret_version"`
+	SecretData        types.String `tfsdk:"secret_data"`
+	SecretAnnotations types.Map    `tfsdk:"secret_annotations"`
+	SecretLabels      types.Map    `tfsdk:"secret_labels"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralK8Secret{}
+
+func newEphemeralK8Secret() ephemeral.EphemeralResource {
+	return &ephemeralK8Secret{}
+}
+
+func (e *ephemeralK8Secret) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8_secret"
+}
+
+func (e *ephemeralK8Secret) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "`duplocloud_k8_secret` opens a kubernetes secret in a Duplo tenant, without storing its data in the state.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: "The GUID of the tenant that the secret is in.",
+				Required:            true,
+			},
+			"secret_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the secret.",
+				Required:            true,
+			},
+			"secret_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the secret.  Usually `\"Opaque\"`.",
+				Computed:            true,
+			},
+			"secret_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the secret.",
+				Computed:            true,
+			},
+			"secret_data": schema.StringAttribute{
+				MarkdownDescription: "A JSON encoded string of the secret's data.  The values are masked when the current user has read-only access to the tenant's secrets.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"secret_annotations": schema.MapAttribute{
+				MarkdownDescription: "Annotations of the secret.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"secret_labels": schema.MapAttribute{
+				MarkdownDescription: "Labels of the secret.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *ephemeralK8Secret) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralK8SecretModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	c, diags := e.client(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenantID, name := data.TenantID.ValueString(), data.SecretName.ValueString()
+	log.Printf("[TRACE] ephemeralK8SecretOpen(%s, %s): start", tenantID, name)
+
+	readOnly, err := k8sSecretReadOnly(c, tenantID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read kubernetes secret", err.Error())
+		return
+	}
+	rp, err := c.K8SecretGet(tenantID, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read kubernetes secret", err.Error())
+		return
+	}
+	if rp == nil || rp.SecretName == "" {
+		resp.Diagnostics.AddError("Unable to read kubernetes secret", fmt.Sprintf("tenant k8 secret '%s' not found", name))
+		return
+	}
+
+	if readOnly {
+		for key := range rp.SecretData {
+			rp.SecretData[key] = "**********"
+		}
+	}
+	encoded, jerr := json.Marshal(rp.SecretData)
+	if jerr != nil {
+		resp.Diagnostics.AddError("Unable to encode kubernetes secret", jerr.Error())
+		return
+	}
+
+	data.SecretType = types.StringValue(rp.SecretType)
+	data.SecretVersion = types.StringValue(rp.SecretVersion)
+	data.SecretData = types.StringValue(string(encoded))
+	data.SecretAnnotations, diags = types.MapValueFrom(ctx, types.StringType, rp.SecretAnnotations)
+	resp.Diagnostics.Append(diags...)
+	data.SecretLabels, diags = types.MapValueFrom(ctx, types.StringType, rp.SecretLabels)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	log.Printf("[TRACE] ephemeralK8SecretOpen(%s, %s): end", tenantID, name)
+}
@@ -0,0 +1,114 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ephemeralTenantAwsCredentials struct {
+	ephemeralBase
+}
+
+type ephemeralTenantAwsCredentialsModel struct {
+	TenantID types.String `tfsdk:"tenant_id"`
+	ephemeralAwsCredentialsModel
+}
+
+// ephemeralAwsCredentialsModel holds the credentials shared by the tenant and admin AWS credentials.
+type ephemeralAwsCredentialsModel struct {
+	ConsoleURL      types.String `tfsdk:"console_url"`
+	AccessKeyID     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	SessionToken    types.String `tfsdk:"session_token"`
+	Region          types.String `tfsdk:"region"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralTenantAwsCredentials{}
+
+func newEphemeralTenantAwsCredentials() ephemeral.EphemeralResource {
+	return &ephemeralTenantAwsCredentials{}
+}
+
+func (e *ephemeralTenantAwsCredentials) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_aws_credentials"
+}
+
+func (e *ephemeralTenantAwsCredentials) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	attributes := ephemeralAwsCredentialsSchema()
+	attributes["tenant_id"] = schema.StringAttribute{
+		MarkdownDescription: "The GUID of the tenant.",
+		Required:            true,
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "`duplocloud_tenant_aws_credentials` opens just-in-time AWS credentials for a Duplo tenant, without storing them in the state.",
+		Attributes:          attributes,
+	}
+}
+
+func (e *ephemeralTenantAwsCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralTenantAwsCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	c, diags := e.client(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenantID := data.TenantID.ValueString()
+	log.Printf("[TRACE] ephemeralTenantAwsCredentialsOpen(%s): start", tenantID)
+
+	creds, err := c.TenantGetAwsCredentials(tenantID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read AWS credentials", fmt.Sprintf("failed to read AWS credentials from tenant '%s': %s", tenantID, err))
+		return
+	}
+	data.ephemeralAwsCredentialsModel = flattenEphemeralAwsCredentials(creds.ConsoleURL, creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken, creds.Region)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	log.Printf("[TRACE] ephemeralTenantAwsCredentialsOpen(%s): end", tenantID)
+}
+
+// ephemeralAwsCredentialsSchema returns the attributes shared by the tenant and admin AWS credentials.
+func ephemeralAwsCredentialsSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"console_url": schema.StringAttribute{
+			MarkdownDescription: "A URL that signs in to the AWS console with these credentials.",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"access_key_id": schema.StringAttribute{
+			MarkdownDescription: "The AWS access key ID.",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"secret_access_key": schema.StringAttribute{
+			MarkdownDescription: "The AWS secret access key.",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"session_token": schema.StringAttribute{
+			MarkdownDescription: "The AWS session token.",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"region": schema.StringAttribute{
+			MarkdownDescription: "The AWS region.",
+			Computed:            true,
+		},
+	}
+}
+
+func flattenEphemeralAwsCredentials(consoleURL, accessKeyID, secretAccessKey, sessionToken, region string) ephemeralAwsCredentialsModel {
+	return ephemeralAwsCredentialsModel{
+		ConsoleURL:      types.StringValue(consoleURL),
+		AccessKeyID:     types.StringValue(accessKeyID),
+		SecretAccessKey: types.StringValue(secretAccessKey),
+		SessionToken:    types.StringValue(sessionToken),
+		Region:          types.StringValue(region),
+	}
+}
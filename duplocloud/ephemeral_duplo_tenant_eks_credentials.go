@@ -0,0 +1,106 @@
+package duplocloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ephemeralTenantEksCredentials struct {
+	ephemeralBase
+}
+
+type ephemeralTenantEksCredentialsModel struct {
+	TenantID          types.String `tfsdk:"tenant_id"`
+	Name              types.String `tfsdk:"name"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+	CaCertificateData types.String `tfsdk:"ca_certificate_data"`
+	Token             types.String `tfsdk:"token"`
+	Region            types.String `tfsdk:"region"`
+	Version           types.String `tfsdk:"version"`
+	Namespace         types.String `tfsdk:"namespace"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralTenantEksCredentials{}
+
+func newEphemeralTenantEksCredentials() ephemeral.EphemeralResource {
+	return &ephemeralTenantEksCredentials{}
+}
+
+func (e *ephemeralTenantEksCredentials) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_eks_credentials"
+}
+
+func (e *ephemeralTenantEksCredentials) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "`duplocloud_tenant_eks_credentials` opens just-in-time kubernetes credentials for a Duplo tenant, without storing them in the state.",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: "The GUID of the tenant.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the cluster.",
+				Computed:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The URL of the cluster's API server.",
+				Computed:            true,
+			},
+			"ca_certificate_data": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded certificate of the cluster's certificate authority.",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "A bearer token that authenticates to the cluster, with the tenant's permissions.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The cloud region of the cluster.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The kubernetes version of the cluster.",
+				Computed:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The kubernetes namespace of the tenant.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *ephemeralTenantEksCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralTenantEksCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	c, diags := e.client(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenantID := data.TenantID.ValueString()
+	log.Printf("[TRACE] ephemeralTenantEksCredentialsOpen(%s): start", tenantID)
+
+	k8sConfig, caCertificateData, err := tenantK8sCredentials(c, tenantID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read kubernetes credentials", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(k8sConfig.Name)
+	data.Endpoint = types.StringValue(k8sConfig.APIServer)
+	data.CaCertificateData = types.StringValue(caCertificateData)
+	data.Token = types.StringValue(k8sConfig.Token)
+	data.Region = types.StringValue(k8sConfig.AwsRegion)
+	data.Version = types.StringValue(k8sConfig.K8sVersion)
+	data.Namespace = types.StringValue(k8sConfig.DefaultNamespace)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	log.Printf("[TRACE] ephemeralTenantEksCredentialsOpen(%s): end", tenantID)
+}
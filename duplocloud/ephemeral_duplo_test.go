@@ -0,0 +1,145 @@
+package duplocloud
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+	"github.com/duplocloud/terraform-provider-duplocloud/internal/duplosdktest"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEphemeralOpen opens an ephemeral resource against the emulator, with string arguments.
+func testEphemeralOpen(t *testing.T, e ephemeral.EphemeralResource, args map[string]string) *ephemeral.OpenResponse {
+	ctx := context.Background()
+
+	sdk := Provider()
+	diags := sdk.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"duplo_host":  testAccEmulator.URL,
+		"duplo_token": "FAKE",
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	configureResp := &ephemeral.ConfigureResponse{}
+	e.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: NewFrameworkProvider(sdk)}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError(), "%v", configureResp.Diagnostics)
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, typ := range objectType.AttributeTypes {
+		if arg, ok := args[name]; ok {
+			values[name] = tftypes.NewValue(typ, arg)
+		} else {
+			values[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+
+	resp := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	e.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}, resp)
+	return resp
+}
+
+func TestEphemeralTenantAwsCredentials(t *testing.T) {
+	duplosdktest.ResetEmulator()
+	resp := testEphemeralOpen(t, newEphemeralTenantAwsCredentials(), map[string]string{"tenant_id": Tenant_testacc1a})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var creds ephemeralTenantAwsCredentialsModel
+	require.False(t, resp.Result.Get(context.Background(), &creds).HasError())
+	assert.Equal(t, Tenant_testacc1a, creds.TenantID.ValueString())
+	assert.Equal(t, "ASIATENANTEXAMPLE", creds.AccessKeyID.ValueString())
+	assert.Equal(t, "tenant-session-token", creds.SessionToken.ValueString())
+	assert.Equal(t, "us-west-2", creds.Region.ValueString())
+}
+
+func TestEphemeralAdminAwsCredentials(t *testing.T) {
+	duplosdktest.ResetEmulator()
+	resp := testEphemeralOpen(t, newEphemeralAdminAwsCredentials(), nil)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var creds ephemeralAwsCredentialsModel
+	require.False(t, resp.Result.Get(context.Background(), &creds).HasError())
+	assert.Equal(t, "ASIAADMINEXAMPLE", creds.AccessKeyID.ValueString())
+	assert.Equal(t, "admin-secret-access-key", creds.SecretAccessKey.ValueString())
+}
+
+func TestEphemeralTenantEksCredentials(t *testing.T) {
+	duplosdktest.ResetEmulator()
+	resp := testEphemeralOpen(t, newEphemeralTenantEksCredentials(), map[string]string{"tenant_id": Tenant_testacc1a})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var creds ephemeralTenantEksCredentialsModel
+	require.False(t, resp.Result.Get(context.Background(), &creds).HasError())
+	assert.Equal(t, "https://testacc1.eks.amazonaws.com", creds.Endpoint.ValueString())
+	assert.Equal(t, "tenant-k8s-token", creds.Token.ValueString())
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----", creds.CaCertificateData.ValueString())
+	assert.Equal(t, "duploservices-testacc1a", creds.Namespace.ValueString())
+}
+
+func TestEphemeralK8Secret(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	require.Nil(t, c.K8SecretCreate(Tenant_testacc1a, &duplosdk.DuploK8sSecret{
+		SecretName:   "creds",
+		SecretType:   "Opaque",
+		SecretData:   map[string]interface{}{"user": "admin"},
+		SecretLabels: map[string]string{"team": "web"},
+	}))
+
+	resp := testEphemeralOpen(t, newEphemeralK8Secret(), map[string]string{"tenant_id": Tenant_testacc1a, "secret_name": "creds"})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var secret ephemeralK8SecretModel
+	require.False(t, resp.Result.Get(context.Background(), &secret).HasError())
+	assert.Equal(t, "Opaque", secret.SecretType.ValueString())
+	data := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(secret.SecretData.ValueString()), &data))
+	assert.Equal(t, "admin", data["user"])
+	assert.Equal(t, `"web"`, secret.SecretLabels.Elements()["team"].String())
+
+	resp = testEphemeralOpen(t, newEphemeralK8Secret(), map[string]string{"tenant_id": Tenant_testacc1a, "secret_name": "missing"})
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+func TestEphemeralPlanK8sCredentials(t *testing.T) {
+	for _, e := range []ephemeral.EphemeralResource{newEphemeralEksCredentials(), newEphemeralGkeCredentials()} {
+		duplosdktest.ResetEmulator()
+
+		// The default plan is not checked for a cluster.
+		resp := testEphemeralOpen(t, e, map[string]string{"plan_id": "default"})
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var creds ephemeralPlanK8sCredentialsModel
+		require.False(t, resp.Result.Get(context.Background(), &creds).HasError())
+		assert.Equal(t, "default", creds.PlanID.ValueString())
+		assert.Equal(t, "duploinfra-default", creds.Name.ValueString())
+		assert.Equal(t, "https://default.eks.amazonaws.com", creds.Endpoint.ValueString())
+		assert.Equal(t, "plan-k8s-token", creds.Token.ValueString())
+		assert.Equal(t, "us-west-2", creds.Region.ValueString())
+		assert.Equal(t, "1.30", creds.Version.ValueString())
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", creds.CaCertificateData.ValueString())
+	}
+}
+
+func TestEphemeralPlanK8sCredentialsNoCluster(t *testing.T) {
+	for _, e := range []ephemeral.EphemeralResource{newEphemeralEksCredentials(), newEphemeralGkeCredentials()} {
+		duplosdktest.ResetEmulator()
+		infra := duplosdk.DuploInfrastructureConfig{}
+		duplosdktest.PatchFixture("infra/testacc1", &infra, func() { infra.EnableK8Cluster = false })
+
+		resp := testEphemeralOpen(t, e, map[string]string{"plan_id": "testacc1"})
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "no kubernetes cluster for this plan testacc1")
+		duplosdktest.PatchFixture("infra/testacc1", &infra, func() { infra.EnableK8Cluster = true })
+	}
+}
@@ -0,0 +1,96 @@
+package duplocloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type functionParseID struct{}
+
+type functionParseIDResult struct {
+	TenantID string   `tfsdk:"tenant_id"`
+	Kind     string   `tfsdk:"kind"`
+	Name     string   `tfsdk:"name"`
+	Parts    []string `tfsdk:"parts"`
+}
+
+var _ function.Function = &functionParseID{}
+
+func newFunctionParseID() function.Function {
+	return &functionParseID{}
+}
+
+func (f *functionParseID) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+func (f *functionParseID) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the ID of a Duplo resource.",
+		MarkdownDescription: "Parses the ID of a Duplo resource into an object with the following attributes:\n\n" +
+			"- `tenant_id` - The GUID of the tenant, or an empty string if the ID does not start with one.\n" +
+			"- `kind` - The kind of resource in `v2/subscriptions/<tenant_id>/<kind>/<name>` IDs, or an empty string.\n" +
+			"- `name` - The rest of the ID.\n" +
+			"- `parts` - All the parts of the ID.\n\n" +
+			"Slashes that are encoded as `_SLASH_` in a part of the ID are decoded.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The ID of the resource.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"tenant_id": types.StringType,
+				"kind":      types.StringType,
+				"name":      types.StringType,
+				"parts":     types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (f *functionParseID) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+	if id == "" {
+		resp.Error = function.NewArgumentFuncError(0, "the ID must not be empty")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, parseDuploID(id))
+}
+
+// parseDuploID splits a resource ID into its parts, and finds the tenant, kind and name in them.
+func parseDuploID(id string) functionParseIDResult {
+	parts := strings.Split(id, "/")
+	for i := range parts {
+		parts[i] = duplosdk.DecodeSlashInIdPart(parts[i])
+	}
+	result := functionParseIDResult{Parts: parts}
+
+	switch {
+	case len(parts) >= 5 && strings.HasPrefix(parts[0], "v") && parts[1] == "subscriptions":
+		result.TenantID, result.Kind, result.Name = parts[2], parts[3], strings.Join(parts[4:], "/")
+	case len(parts) >= 2 && isUUID(parts[0]):
+		result.TenantID, result.Name = parts[0], strings.Join(parts[1:], "/")
+	default:
+		result.Name = strings.Join(parts, "/")
+	}
+	return result
+}
+
+func isUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil && len(s) == 36
+}
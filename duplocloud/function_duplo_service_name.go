@@ -0,0 +1,104 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// duploServicesPrefix is the default prefix of the names that Duplo gives to the resources of a tenant.
+//
+// Portals can use another prefix, with their ResourceNamePrefix system setting.  Provider functions have
+// no access to the Duplo API, so the functions that build or parse names must be given that prefix.
+const duploServicesPrefix = "duploservices"
+
+// functionNameOptions parses the optional argument of the functions that build or parse names: an object
+// with the cloud account ID and the resource name prefix, both of which can be left out.
+func functionNameOptions(options []map[string]string) (accountID, prefix string, err *function.FuncError) {
+	if len(options) > 1 {
+		return "", "", function.NewArgumentFuncError(3, "only one options object can be given")
+	}
+	prefix = duploServicesPrefix
+	for _, opts := range options {
+		for key, value := range opts {
+			switch key {
+			case "account_id":
+				accountID = value
+			case "prefix":
+				if value != "" {
+					prefix = value
+				}
+			default:
+				return "", "", function.NewArgumentFuncError(2, fmt.Sprintf("unsupported option '%s': only account_id and prefix can be given", key))
+			}
+		}
+	}
+	return accountID, prefix, nil
+}
+
+// functionNameOptionsParameter returns the definition of the optional argument parsed by functionNameOptions.
+func functionNameOptionsParameter(accountIDDescription string) function.Parameter {
+	return function.MapParameter{
+		Name:        "options",
+		ElementType: types.StringType,
+		MarkdownDescription: "An optional object with the following attributes, which can each be left out:" +
+			"\n  - `account_id`: " + accountIDDescription +
+			"\n  - `prefix`: the resource name prefix of the portal, which defaults to `duploservices`.",
+	}
+}
+
+type functionServiceName struct{}
+
+var _ function.Function = &functionServiceName{}
+
+func newFunctionServiceName() function.Function {
+	return &functionServiceName{}
+}
+
+func (f *functionServiceName) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "service_name"
+}
+
+func (f *functionServiceName) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the full name of a Duplo resource.",
+		MarkdownDescription: "Builds the full name that Duplo gives to a resource in a tenant: `duploservices-<tenant_name>-<name>`, " +
+			"followed by `-<account_id>` for the resources that Duplo suffixes with the cloud account ID, such as S3 buckets.  " +
+			"The function cannot read the portal's settings: on portals configured with another resource name prefix than `duploservices`, that prefix must be given.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "tenant_name",
+				MarkdownDescription: "The name of the tenant.",
+			},
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The short name of the resource.",
+			},
+		},
+		VariadicParameter: functionNameOptionsParameter("the cloud account ID to suffix the name with."),
+		Return:            function.StringReturn{},
+	}
+}
+
+func (f *functionServiceName) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tenantName, name string
+	var options []map[string]string
+	resp.Error = req.Arguments.Get(ctx, &tenantName, &name, &options)
+	if resp.Error != nil {
+		return
+	}
+	accountID, prefix, err := functionNameOptions(options)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	parts := []string{prefix, tenantName, name}
+	if accountID != "" {
+		parts = append(parts, accountID)
+	}
+	resp.Error = resp.Result.Set(ctx, strings.Join(parts, "-"))
+}
@@ -0,0 +1,128 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFunctionRun validates and runs a provider-defined function, with string arguments and string map variadic arguments.
+func testFunctionRun(t *testing.T, f function.Function, args []string, variadic ...map[string]string) *function.RunResponse {
+	ctx := context.Background()
+
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+	validateResp := &function.DefinitionValidateResponse{}
+	definitionResp.Definition.ValidateImplementation(ctx, function.DefinitionValidateRequest{}, validateResp)
+	require.False(t, validateResp.Diagnostics.HasError(), "%v", validateResp.Diagnostics)
+
+	values := []attr.Value{}
+	for _, arg := range args {
+		values = append(values, types.StringValue(arg))
+	}
+	if definitionResp.Definition.VariadicParameter != nil {
+		elementTypes, elements := []attr.Type{}, []attr.Value{}
+		for _, arg := range variadic {
+			m := map[string]attr.Value{}
+			for k, v := range arg {
+				m[k] = types.StringValue(v)
+			}
+			elementTypes = append(elementTypes, types.MapType{ElemType: types.StringType})
+			elements = append(elements, types.MapValueMust(types.StringType, m))
+		}
+		values = append(values, types.TupleValueMust(elementTypes, elements))
+	}
+
+	resp := &function.RunResponse{Result: function.NewResultData(definitionResp.Definition.Return.GetType().ValueType(ctx))}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(values)}, resp)
+	return resp
+}
+
+func TestFunctionServiceName(t *testing.T) {
+	resp := testFunctionRun(t, newFunctionServiceName(), []string{"myapp", "web"})
+	require.Nil(t, resp.Error)
+	assert.Equal(t, types.StringValue("duploservices-myapp-web"), resp.Result.Value())
+
+	resp = testFunctionRun(t, newFunctionServiceName(), []string{"myapp", "logs"}, map[string]string{"account_id": "123456789012"})
+	require.Nil(t, resp.Error)
+	assert.Equal(t, types.StringValue("duploservices-myapp-logs-123456789012"), resp.Result.Value())
+
+	// Portals can use another resource name prefix.
+	resp = testFunctionRun(t, newFunctionServiceName(), []string{"myapp", "web"}, map[string]string{"prefix": "acme"})
+	require.Nil(t, resp.Error)
+	assert.Equal(t, types.StringValue("acme-myapp-web"), resp.Result.Value())
+
+	resp = testFunctionRun(t, newFunctionServiceName(), []string{"myapp", "logs"}, map[string]string{"account_id": "123456789012", "prefix": "acme"})
+	require.Nil(t, resp.Error)
+	assert.Equal(t, types.StringValue("acme-myapp-logs-123456789012"), resp.Result.Value())
+
+	resp = testFunctionRun(t, newFunctionServiceName(), []string{"myapp", "logs"}, map[string]string{"account": "123456789012"})
+	assert.NotNil(t, resp.Error)
+	resp = testFunctionRun(t, newFunctionServiceName(), []string{"myapp", "logs"}, map[string]string{"prefix": "acme"}, map[string]string{"account_id": "1"})
+	assert.NotNil(t, resp.Error)
+}
+
+func TestFunctionUnwrapName(t *testing.T) {
+	cases := []struct {
+		name, accountID, prefix, expected string
+	}{
+		{"duploservices-myapp-web", "", "", "web"},
+		{"duploservices-myapp-logs-123456789012", "123456789012", "", "logs"},
+		{"duploservices-myapp-logs", "123456789012", "", "logs"},
+		{"arn:aws:s3:::duploservices-myapp-logs-123456789012", "123456789012", "", "logs"},
+		{"arn:aws:sqs:us-west-2:123456789012:duploservices-myapp-jobs", "", "", "jobs"},
+		{"arn:aws:iam::123456789012:role/duploservices-myapp-worker", "", "", "worker"},
+		{"acme-myapp-web", "", "acme", "web"},
+		{"arn:aws:s3:::acme-myapp-logs-123456789012", "123456789012", "acme", "logs"},
+	}
+	for _, c := range cases {
+		options := map[string]string{}
+		if c.accountID != "" {
+			options["account_id"] = c.accountID
+		}
+		if c.prefix != "" {
+			options["prefix"] = c.prefix
+		}
+		resp := testFunctionRun(t, newFunctionUnwrapName(), []string{"myapp", c.name}, options)
+		require.Nil(t, resp.Error, c.name)
+		assert.Equal(t, types.StringValue(c.expected), resp.Result.Value(), c.name)
+	}
+
+	for _, name := range []string{"duploservices-other-web", "duploservices-myapp", "duploservices-myappweb"} {
+		resp := testFunctionRun(t, newFunctionUnwrapName(), []string{"myapp", name})
+		assert.NotNil(t, resp.Error, name)
+	}
+	resp := testFunctionRun(t, newFunctionUnwrapName(), []string{"myapp", "duploservices-myapp-123456789012"}, map[string]string{"account_id": "123456789012"})
+	assert.NotNil(t, resp.Error)
+	resp = testFunctionRun(t, newFunctionUnwrapName(), []string{"myapp", "duploservices-myapp-web"}, map[string]string{"prefix": "acme"})
+	assert.NotNil(t, resp.Error)
+}
+
+func TestFunctionParseID(t *testing.T) {
+	resp := testFunctionRun(t, newFunctionParseID(), []string{"v2/subscriptions/" + Tenant_testacc1a + "/K8SecretApiV2/creds"})
+	require.Nil(t, resp.Error)
+	result := resp.Result.Value().(types.Object).Attributes()
+	assert.Equal(t, types.StringValue(Tenant_testacc1a), result["tenant_id"])
+	assert.Equal(t, types.StringValue("K8SecretApiV2"), result["kind"])
+	assert.Equal(t, types.StringValue("creds"), result["name"])
+	assert.Len(t, result["parts"].(types.List).Elements(), 5)
+
+	assert.Equal(t, functionParseIDResult{
+		TenantID: Tenant_testacc1a,
+		Name:     "mydb/aurora5.7/query_cache_size",
+		Parts:    []string{Tenant_testacc1a, "mydb", "aurora5.7/query_cache_size"},
+	}, parseDuploID(Tenant_testacc1a+"/mydb/aurora5.7_SLASH_query_cache_size"))
+
+	assert.Equal(t, functionParseIDResult{
+		Name:  "default/myplan",
+		Parts: []string{"default", "myplan"},
+	}, parseDuploID("default/myplan"))
+
+	resp = testFunctionRun(t, newFunctionParseID(), []string{""})
+	assert.NotNil(t, resp.Error)
+}
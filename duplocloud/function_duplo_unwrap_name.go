@@ -0,0 +1,79 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type functionUnwrapName struct{}
+
+var _ function.Function = &functionUnwrapName{}
+
+func newFunctionUnwrapName() function.Function {
+	return &functionUnwrapName{}
+}
+
+func (f *functionUnwrapName) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "unwrap_name"
+}
+
+func (f *functionUnwrapName) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Extracts the short name of a Duplo resource from its full name or ARN.",
+		MarkdownDescription: "Extracts the short name of a Duplo resource from its full name or ARN, " +
+			"by removing the `duploservices-<tenant_name>-` prefix and the optional `-<account_id>` suffix.  " +
+			"Fails if the name does not start with the prefix.  " +
+			"The function cannot read the portal's settings: on portals configured with another resource name prefix than `duploservices`, that prefix must be given.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "tenant_name",
+				MarkdownDescription: "The name of the tenant.",
+			},
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The full name or ARN of the resource.",
+			},
+		},
+		VariadicParameter: functionNameOptionsParameter("the cloud account ID to remove from the end of the name, if present."),
+		Return:            function.StringReturn{},
+	}
+}
+
+func (f *functionUnwrapName) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tenantName, name string
+	var options []map[string]string
+	resp.Error = req.Arguments.Get(ctx, &tenantName, &name, &options)
+	if resp.Error != nil {
+		return
+	}
+	accountID, prefix, err := functionNameOptions(options)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	// Only keep the resource part of an ARN, which follows the last ':' or '/'.
+	if strings.HasPrefix(name, "arn:") {
+		name = name[strings.LastIndexAny(name, ":/")+1:]
+	}
+
+	// The short name must not be empty, with or without the account ID.
+	prefix += "-" + tenantName
+	if !strings.HasPrefix(name, prefix+"-") || (accountID != "" && name == prefix+"-"+accountID) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("'%s' is not the name of a resource in tenant '%s'", name, tenantName))
+		return
+	}
+
+	var shortName string
+	if accountID != "" {
+		shortName, _ = duplosdk.UnwrapName(prefix, accountID, name, true)
+	} else {
+		shortName, _ = duplosdk.UnprefixName(prefix, name)
+	}
+	resp.Error = resp.Result.Set(ctx, shortName)
+}
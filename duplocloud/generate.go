@@ -0,0 +1,402 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// generateImportID returns the ID of an inventory item, in the format that its resource's importer expects.
+type generateImportID func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError)
+
+// Import ID formats, by Terraform resource type.  Objects of other types are listed in a comment.
+var generateImportIDs = map[string]generateImportID{
+	"duplocloud_duplo_service": func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+		return fmt.Sprintf("v2/subscriptions/%s/ReplicationControllerApiV2/%s", g.tenantID, item.ID), nil
+	},
+	"duplocloud_k8_secret": func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+		return fmt.Sprintf("v2/subscriptions/%s/K8SecretApiV2/%s", g.tenantID, item.ID), nil
+	},
+	"duplocloud_aws_host": func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+		return fmt.Sprintf("v2/subscriptions/%s/NativeHostV2/%s", g.tenantID, item.ID), nil
+	},
+	"duplocloud_ecs_service": func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+		return fmt.Sprintf("v2/subscriptions/%s/EcsServiceApiV2/%s", g.tenantID, item.ID), nil
+	},
+	"duplocloud_rds_instance": func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+		return fmt.Sprintf("v2/subscriptions/%s/RDSDBInstance/%s", g.tenantID, strings.TrimPrefix(item.ID, "duplo")), nil
+	},
+	"duplocloud_s3_bucket": func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+		name, err := g.s3BucketShortName(item.ID)
+		return fmt.Sprintf("%s/%s", g.tenantID, name), err
+	},
+	"duplocloud_aws_sns_topic": func(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+		return fmt.Sprintf("%s/%s", g.tenantID, item.CloudID), nil
+	},
+	"duplocloud_aws_lambda_function":       generateTenantSlashID,
+	"duplocloud_aws_dynamodb_table_v2":     generateTenantSlashID,
+	"duplocloud_azure_storage_account":     generateTenantSlashID,
+	"duplocloud_azure_tenant_key_vault":    generateTenantSlashID,
+	"duplocloud_azure_redis_cache":         generateTenantSlashID,
+	"duplocloud_azure_mssql_server":        generateTenantSlashID,
+	"duplocloud_gcp_pubsub_topic":          generateTenantSlashID,
+	"duplocloud_gcp_cloud_function":        generateTenantSlashID,
+	"duplocloud_gcp_scheduler_job":         generateTenantSlashID,
+	"duplocloud_gcp_storage_bucket_v2":     generateTenantSlashID,
+	"duplocloud_gcp_redis_instance":        generateTenantSlashID,
+	"duplocloud_gcp_sql_database_instance": generateTenantSlashID,
+}
+
+func generateTenantSlashID(g *tenantGenerator, item tenantInventoryItem) (string, duplosdk.ClientError) {
+	return fmt.Sprintf("%s/%s", g.tenantID, item.ID), nil
+}
+
+// tenantGenerator writes the configuration of the objects found in a tenant.
+type tenantGenerator struct {
+	provider *schema.Provider
+	meta     interface{}
+	client   *duplosdk.Client
+	tenantID string
+	labels   map[string]bool
+}
+
+// GenerateTenantConfig writes Terraform 1.5 import blocks, and the matching resource bodies, for the objects
+// found in a tenant.  The provider must already be configured.
+//
+// Each object is read with its resource's importer and Read function, so that only objects that can really
+// be imported are written.  Objects without a matching resource are listed in a comment.
+func GenerateTenantConfig(ctx context.Context, p *schema.Provider, tenantName string, w io.Writer) diag.Diagnostics {
+	meta := withClientContext(ctx, p.Meta())
+	c, ok := meta.(*duplosdk.Client)
+	if !ok {
+		return diag.Errorf("The provider is not configured")
+	}
+
+	tenant, err := c.GetTenantByNameForUser(tenantName)
+	if err != nil {
+		return diag.Errorf("Unable to retrieve tenant '%s': %s", tenantName, err)
+	}
+	if tenant == nil {
+		return diag.Errorf("Tenant '%s' not found", tenantName)
+	}
+
+	items, diags := tenantInventory(c, tenant.TenantID)
+	if diags.HasError() {
+		return diags
+	}
+
+	g := &tenantGenerator{provider: p, meta: meta, client: c, tenantID: tenant.TenantID, labels: map[string]bool{}}
+	f := hclwrite.NewEmptyFile()
+	skipped := []string{}
+	for _, item := range items {
+		newIDFunc, ok := generateImportIDs[item.TerraformType]
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s %q", item.Type, item.Name))
+			continue
+		}
+		id, err := newIDFunc(g, item)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unable to generate the import ID of %s '%s': skipping it.", item.TerraformType, item.Name),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		diags = append(diags, g.writeResource(ctx, f.Body(), item, id)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	header := fmt.Sprintf("# Generated by `terraform-provider-duplocloud generate` for the %q tenant.\n"+
+		"# Review these resources, then run `terraform plan` to import them.\n", tenantName)
+	if len(skipped) > 0 {
+		header += "#\n# These objects have no importable resource, and were skipped:\n"
+		for _, s := range skipped {
+			header += "#   - " + s + "\n"
+		}
+	}
+	if _, err := io.WriteString(w, header+"\n"); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if _, err := w.Write(hclwrite.Format(f.Bytes())); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// writeResource reads an object through its resource, and writes its import block and resource body.
+func (g *tenantGenerator) writeResource(ctx context.Context, body *hclwrite.Body, item tenantInventoryItem, id string) diag.Diagnostics {
+	r, ok := g.provider.ResourcesMap[item.TerraformType]
+	if !ok {
+		return diag.Errorf("Unknown resource type: %s", item.TerraformType)
+	}
+	log.Printf("[TRACE] generate(%s): importing %s", item.TerraformType, id)
+
+	d := r.Data(nil)
+	d.SetId(id)
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imported, err := r.Importer.StateContext(ctx, d, g.meta)
+		if err != nil || len(imported) == 0 {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unable to import %s '%s': skipping it.", item.TerraformType, id),
+				Detail:   fmt.Sprint(err),
+			}}
+		}
+		d = imported[0]
+	}
+
+	var diags diag.Diagnostics
+	switch {
+	case r.ReadContext != nil:
+		diags = r.ReadContext(ctx, d, g.meta)
+	case r.ReadWithoutTimeout != nil:
+		diags = r.ReadWithoutTimeout(ctx, d, g.meta)
+	}
+	if diags.HasError() || d.Id() == "" {
+		return append(warningDiagnostics(diags), diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Unable to read %s '%s': skipping it.", item.TerraformType, id),
+		})
+	}
+
+	label := g.label(item.Name)
+	imp := body.AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: item.TerraformType}, hcl.TraverseAttr{Name: label}})
+	imp.SetAttributeValue("id", cty.StringVal(d.Id()))
+	body.AppendNewline()
+
+	res := body.AppendNewBlock("resource", []string{item.TerraformType, label}).Body()
+	ignored := writeResourceBody(res, r.Schema, func(name string) interface{} { return d.Get(name) })
+	if len(ignored) > 0 {
+		// The values of sensitive arguments are not written, so keep Terraform from removing them.
+		names := make([]hclwrite.Tokens, 0, len(ignored))
+		for _, name := range ignored {
+			names = append(names, hclwrite.TokensForIdentifier(name))
+		}
+		res.AppendNewline()
+		lifecycle := res.AppendNewBlock("lifecycle", nil).Body()
+		lifecycle.SetAttributeRaw("ignore_changes", hclwrite.TokensForTuple(names))
+	}
+	body.AppendNewline()
+	return diags
+}
+
+// s3BucketShortName returns the name of an S3 bucket without the prefix and suffix added by Duplo.
+func (g *tenantGenerator) s3BucketShortName(fullName string) (string, duplosdk.ClientError) {
+	features, _ := g.client.AdminGetSystemFeatures()
+	if features != nil && features.IsTagsBasedResourceMgmtEnabled {
+		return strings.TrimPrefix(fullName, features.S3BucketNamePrefix), nil
+	}
+	prefix, err := g.client.GetDuploServicesPrefix(g.tenantID, "")
+	if err != nil {
+		return "", err
+	}
+	accountID, err := g.client.TenantGetAwsAccountID(g.tenantID)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(fullName, prefix+"-"), "-"+accountID), nil
+}
+
+var generateLabelChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// label returns a unique resource label for an object name.
+func (g *tenantGenerator) label(name string) string {
+	base := strings.Trim(generateLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "r_" + base
+	}
+	label := base
+	for i := 2; g.labels[label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.labels[label] = true
+	return label
+}
+
+// writeResourceBody writes the arguments of a resource, or of a nested block, as HCL.
+//
+// Computed-only attributes are left out, as are arguments that are unset or have their default value.
+// Sensitive and deprecated arguments are left out too, so that secrets are never written to disk.
+//
+// The names of the sensitive arguments that have a value are returned, so that the caller can ignore
+// their changes.  Sensitive arguments that must be set by hand get a TODO comment instead.
+func writeResourceBody(body *hclwrite.Body, s map[string]*schema.Schema, get func(name string) interface{}) (ignored []string) {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Attributes first, then nested blocks, as terraform fmt would.
+	blocks := []string{}
+	for _, name := range names {
+		attr := s[name]
+		if (!attr.Required && !attr.Optional) || attr.Deprecated != "" {
+			continue
+		}
+		if attr.Sensitive {
+			switch {
+			case attr.WriteOnly:
+				// Write-only values are never in the state, but their version is written.
+				for _, version := range attr.RequiredWith {
+					if !isDefaultValue(s[version], get(version)) {
+						appendGenerateTODO(body, name)
+						break
+					}
+				}
+			case !isDefaultValue(attr, get(name)):
+				ignored = append(ignored, name)
+			case attr.Required:
+				appendGenerateTODO(body, name)
+			}
+			continue
+		}
+		if _, ok := attr.Elem.(*schema.Resource); ok && (attr.Type == schema.TypeList || attr.Type == schema.TypeSet) {
+			blocks = append(blocks, name)
+			continue
+		}
+		value := get(name)
+		if !attr.Required && isDefaultValue(attr, value) {
+			continue
+		}
+		if v, ok := generateValue(attr, value); ok {
+			body.SetAttributeValue(name, v)
+		}
+	}
+
+	for _, name := range blocks {
+		attr := s[name]
+		elem := attr.Elem.(*schema.Resource)
+		for _, raw := range generateListItems(get(name)) {
+			m, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			block := body.AppendNewBlock(name, nil).Body()
+			for _, name := range writeResourceBody(block, elem.Schema, func(name string) interface{} { return m[name] }) {
+				appendGenerateTODO(block, name)
+			}
+		}
+	}
+	return ignored
+}
+
+// appendGenerateTODO writes a comment asking to set a sensitive argument whose value was not written.
+func appendGenerateTODO(body *hclwrite.Body, name string) {
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte(fmt.Sprintf("# TODO: set %s\n", name))},
+	})
+}
+
+// isDefaultValue returns true if an argument's value is its zero value, or its default.
+func isDefaultValue(attr *schema.Schema, value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if attr.Default != nil && reflect.DeepEqual(attr.Default, value) {
+		return true
+	}
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// generateListItems returns the items of a list or set value.
+func generateListItems(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+	return nil
+}
+
+// generateValue converts an argument's value to HCL.
+func generateValue(attr *schema.Schema, value interface{}) (cty.Value, bool) {
+	switch attr.Type {
+	case schema.TypeString:
+		s, ok := value.(string)
+		return cty.StringVal(s), ok
+	case schema.TypeInt:
+		i, ok := value.(int)
+		return cty.NumberIntVal(int64(i)), ok
+	case schema.TypeFloat:
+		f, ok := value.(float64)
+		return cty.NumberFloatVal(f), ok
+	case schema.TypeBool:
+		b, ok := value.(bool)
+		return cty.BoolVal(b), ok
+	case schema.TypeMap:
+		m, ok := value.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			return cty.NilVal, false
+		}
+		elem := &schema.Schema{Type: schema.TypeString}
+		if s, ok := attr.Elem.(*schema.Schema); ok {
+			elem = s
+		}
+		values := map[string]cty.Value{}
+		for k, raw := range m {
+			if v, ok := generateValue(elem, raw); ok {
+				values[k] = v
+			}
+		}
+		return cty.ObjectVal(values), true
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := attr.Elem.(*schema.Schema)
+		if !ok {
+			return cty.NilVal, false
+		}
+		values := []cty.Value{}
+		for _, raw := range generateListItems(value) {
+			if v, ok := generateValue(elem, raw); ok {
+				values = append(values, v)
+			}
+		}
+		return cty.TupleVal(values), true
+	}
+	return cty.NilVal, false
+}
+
+// warningDiagnostics downgrades errors to warnings.
+func warningDiagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	warnings := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		d.Severity = diag.Warning
+		warnings = append(warnings, d)
+	}
+	return warnings
+}
@@ -0,0 +1,215 @@
+package duplocloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+func TestGenerateLabel(t *testing.T) {
+	g := &tenantGenerator{labels: map[string]bool{}}
+
+	assert.Equal(t, "my_app", g.label("My.App"))
+	assert.Equal(t, "my_app_2", g.label("my app"))
+	assert.Equal(t, "r_123", g.label("123"))
+	assert.Equal(t, "r_", g.label("..."))
+}
+
+func TestGenerateResourceBody(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"name":     {Type: schema.TypeString, Required: true},
+		"replicas": {Type: schema.TypeInt, Optional: true, Default: 1},
+		"enabled":  {Type: schema.TypeBool, Optional: true},
+		"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"status":   {Type: schema.TypeString, Computed: true},
+		"labels":   {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"setting": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"key":   {Type: schema.TypeString, Required: true},
+				"value": {Type: schema.TypeString, Optional: true},
+				"tags":  {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"token": {Type: schema.TypeString, Optional: true, Sensitive: true},
+			}},
+		},
+		"api_key":           {Type: schema.TypeString, Required: true, Sensitive: true},
+		"secret_wo":         {Type: schema.TypeString, Optional: true, Sensitive: true, WriteOnly: true, RequiredWith: []string{"secret_wo_version"}},
+		"secret_wo_version": {Type: schema.TypeInt, Optional: true, RequiredWith: []string{"secret_wo"}},
+		"unused_wo":         {Type: schema.TypeString, Optional: true, Sensitive: true, WriteOnly: true, RequiredWith: []string{"unused_wo_version"}},
+		"unused_wo_version": {Type: schema.TypeInt, Optional: true, RequiredWith: []string{"unused_wo"}},
+	}
+	values := map[string]interface{}{
+		"name":     "web",
+		"replicas": 1,
+		"enabled":  true,
+		"password": "hunter2",
+		"status":   "Running",
+		"labels":   map[string]interface{}{"app": "web"},
+		"setting": []interface{}{map[string]interface{}{
+			"key":   "a",
+			"value": "b",
+			"tags":  schema.NewSet(schema.HashString, []interface{}{}),
+			"token": "s3cr3t",
+		}},
+		"secret_wo_version": 1,
+	}
+
+	f := hclwrite.NewEmptyFile()
+	ignored := writeResourceBody(f.Body(), s, func(name string) interface{} { return values[name] })
+	out := string(hclwrite.Format(f.Bytes()))
+
+	assert.Equal(t, []string{"password"}, ignored)
+	assert.Contains(t, out, "# TODO: set api_key")
+	assert.Contains(t, out, "# TODO: set secret_wo\n")
+	assert.Contains(t, out, "secret_wo_version = 1")
+	assert.Contains(t, out, "# TODO: set token")
+	assert.NotContains(t, out, "unused_wo")
+	assert.NotContains(t, out, "s3cr3t")
+
+	assert.Contains(t, out, `name = "web"`)
+	assert.Contains(t, out, `enabled = true`)
+	assert.Contains(t, out, `app = "web"`)
+	assert.Contains(t, out, "setting {")
+	assert.Contains(t, out, `key   = "a"`)
+	assert.NotContains(t, out, "tags")
+	assert.NotContains(t, out, "replicas")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "Running")
+}
+
+func TestEmulator_GenerateTenantConfig(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	require.Nil(t, c.ReplicationControllerCreate(Tenant_testacc1a, &duplosdk.DuploReplicationControllerCreateRequest{
+		Name:     "web",
+		Image:    "nginx:latest",
+		Replicas: 2,
+	}))
+	require.Nil(t, c.K8SecretCreate(Tenant_testacc1a, &duplosdk.DuploK8sSecret{
+		SecretName: "creds",
+		SecretType: "Opaque",
+		SecretData: map[string]interface{}{"user": "admin"},
+	}))
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"duplo_host":  testAccEmulator.URL,
+		"duplo_token": "FAKE",
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	var out bytes.Buffer
+	diags = GenerateTenantConfig(context.Background(), p, "testacc1a", &out)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	config := out.String()
+	assert.Contains(t, config, "to = duplocloud_duplo_service.web")
+	assert.Contains(t, config, `id = "v2/subscriptions/`+Tenant_testacc1a+`/ReplicationControllerApiV2/web"`)
+	assert.Contains(t, config, `resource "duplocloud_duplo_service" "web"`)
+	assert.Contains(t, config, "to = duplocloud_k8_secret.creds")
+	assert.Contains(t, config, `id = "v2/subscriptions/`+Tenant_testacc1a+`/K8SecretApiV2/creds"`)
+	assert.NotContains(t, config, "admin")
+}
+
+// testGeneratedPlan plans a generated resource block against the state that its import block would produce,
+// honoring ignore_changes as Terraform does.
+func testGeneratedPlan(t *testing.T, p *schema.Provider, config []byte, resourceType, label string) *terraform.InstanceDiff {
+	ctx := context.Background()
+
+	f, diags := hclsyntax.ParseConfig(config, "generated.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), "%v", diags)
+
+	var id string
+	var block *hclsyntax.Block
+	for _, b := range f.Body.(*hclsyntax.Body).Blocks {
+		switch b.Type {
+		case "import":
+			to, _ := hcl.AbsTraversalForExpr(b.Body.Attributes["to"].Expr)
+			if len(to) == 2 && to.RootName() == resourceType && to[1].(hcl.TraverseAttr).Name == label {
+				v, _ := b.Body.Attributes["id"].Expr.Value(nil)
+				id = v.AsString()
+			}
+		case "resource":
+			if b.Labels[0] == resourceType && b.Labels[1] == label {
+				block = b
+			}
+		}
+	}
+	require.NotEmpty(t, id)
+	require.NotNil(t, block)
+
+	// Import the object.
+	r := p.ResourcesMap[resourceType]
+	d := r.Data(nil)
+	d.SetId(id)
+	imported, err := r.Importer.StateContext(ctx, d, p.Meta())
+	require.NoError(t, err)
+	d = imported[0]
+	require.False(t, r.ReadContext(ctx, d, p.Meta()).HasError())
+
+	// Plan the generated configuration.
+	raw := map[string]interface{}{}
+	for name, attr := range block.Body.Attributes {
+		v, diags := attr.Expr.Value(nil)
+		require.False(t, diags.HasErrors(), "%v", diags)
+		b, err := ctyjson.Marshal(v, v.Type())
+		require.NoError(t, err)
+		var value interface{}
+		require.NoError(t, json.Unmarshal(b, &value))
+		raw[name] = value
+	}
+	for _, lifecycle := range block.Body.Blocks {
+		if attr, ok := lifecycle.Body.Attributes["ignore_changes"]; ok && lifecycle.Type == "lifecycle" {
+			exprs, diags := hcl.ExprList(attr.Expr)
+			require.False(t, diags.HasErrors(), "%v", diags)
+			for _, expr := range exprs {
+				traversal, diags := hcl.AbsTraversalForExpr(expr)
+				require.False(t, diags.HasErrors(), "%v", diags)
+				raw[traversal.RootName()] = d.Get(traversal.RootName())
+			}
+		}
+	}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), p.Meta())
+	require.NoError(t, err)
+	return diff
+}
+
+func TestEmulator_GenerateTenantConfigPlan(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	require.Nil(t, c.K8SecretCreate(Tenant_testacc1a, &duplosdk.DuploK8sSecret{
+		SecretName: "creds",
+		SecretType: "Opaque",
+		SecretData: map[string]interface{}{"user": "admin"},
+	}))
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"duplo_host":  testAccEmulator.URL,
+		"duplo_token": "FAKE",
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	var out bytes.Buffer
+	diags = GenerateTenantConfig(context.Background(), p, "testacc1a", &out)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Contains(t, out.String(), "ignore_changes = [secret_data]")
+	assert.NotContains(t, out.String(), "admin")
+
+	// The secret's data is not in the configuration, but applying it must not remove the data.
+	diff := testGeneratedPlan(t, p, out.Bytes(), "duplocloud_k8_secret", "creds")
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+}
@@ -2,7 +2,6 @@ package duplocloud
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,8 +11,10 @@ import (
 
 	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -33,7 +34,7 @@ func init() {
 
 // Provider return a Terraform provider schema
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"duplo_host": {
 				Description: "This is the base URL to the Duplo REST API.  It must be provided, but it can also be sourced from the `duplo_host` environment variable.",
@@ -41,22 +42,81 @@ func Provider() *schema.Provider {
 				Optional:    true,
 			},
 			"duplo_token": {
-				Description: "This is a bearer token used to authenticate to the Duplo REST API.  It must be provided, but it can also be sourced from the `duplo_token` environment variable.",
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
+				Description:   "This is a bearer token used to authenticate to the Duplo REST API.  It must be provided, but it can also be sourced from the `duplo_token` environment variable.  Alternatively, use `duplo_token_file`, `exec` or `token_exchange`.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: conflictingAuthKeys("duplo_token"),
 			},
 			"ssl_no_verify": {
-				Description: "Disable SSL certificate verification.",
+				Description: "Disable SSL certificate verification.  When not set, the value from the selected profile is used.  Defaults to `false`.",
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     false,
 			},
 			"http_timeout": {
-				Description: "Timeout for HTTP requests in seconds.",
+				Description: "Timeout for HTTP requests in seconds.  When not set, the value from the selected profile is used.  Defaults to `30`.",
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Default:     30,
+			},
+			"max_retries": {
+				Description:  "Maximum number of times a Duplo API request is retried after a transient failure, such as throttling (`429` or `Rate exceeded`), a `502`/`503`/`504` response or a connection reset. Set to `0` to disable retries.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      duplosdk.DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_backoff": {
+				Description:  "Minimum time to wait before retrying a failed Duplo API request, in seconds. A `Retry-After` header sent by Duplo takes precedence when it asks for a longer wait, up to `retry_max_backoff`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(duplosdk.DefaultRetryMinBackoff / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_backoff": {
+				Description:  "Maximum time to wait between retries of a failed Duplo API request, in seconds.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(duplosdk.DefaultRetryMaxBackoff / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"requests_per_second": {
+				Description:  "Maximum number of Duplo API requests started per second, shared by all resources in a run. Set to `0` for no limit.",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Description:  "Maximum number of Duplo API requests in flight at once, shared by all resources in a run. Set to `0` for no limit.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"deletion_protection": {
+				Description: "Protects resources from deletion, regardless of their own `deletion_protection` argument.  " +
+					"A resource is protected when it matches any of these rules.  To delete a protected resource, remove it from the rules first.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Description:      "A glob on the type of the protected resources, such as `duplocloud_tenant` or `duplocloud_*`.  Defaults to all resource types that support deletion protection.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validateGlob,
+						},
+						"names": {
+							Description: "Globs on the names of the protected resources, such as `prod*`.  Defaults to all names.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validateGlob,
+							},
+						},
+					},
+				},
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -164,6 +224,16 @@ func Provider() *schema.Provider {
 			"duplocloud_k8s_job":                                       resourceKubernetesJobV1(),
 			"duplocloud_k8s_cron_job":                                  resourceKubernetesCronJobV1Beta1(),
 			"duplocloud_k8s_daemon_set":                                resourceKubernetesDaemonSetV1(),
+			"duplocloud_k8s_deployment":                                resourceKubernetesDeploymentV1(),
+			"duplocloud_k8s_stateful_set":                              resourceKubernetesStatefulSetV1(),
+			"duplocloud_k8s_service":                                   resourceKubernetesServiceV1(),
+			"duplocloud_k8s_horizontal_pod_autoscaler":                 resourceKubernetesHorizontalPodAutoscalerV2(),
+			"duplocloud_k8s_manifest":                                  resourceKubernetesManifest(),
+			"duplocloud_k8s_network_policy":                            resourceKubernetesNetworkPolicyV1(),
+			"duplocloud_k8s_pod_disruption_budget":                     resourceKubernetesPodDisruptionBudgetV1(),
+			"duplocloud_k8s_service_account":                           resourceKubernetesServiceAccountV1(),
+			"duplocloud_k8s_role":                                      resourceKubernetesRoleV1(),
+			"duplocloud_k8s_role_binding":                              resourceKubernetesRoleBindingV1(),
 			"duplocloud_k8_persistent_volume_claim":                    resourceK8PVC(),
 			"duplocloud_k8_storage_class":                              resourceK8StorageClass(),
 			"duplocloud_aws_batch_scheduling_policy":                   resourceAwsBatchSchedulingPolicy(),
@@ -250,6 +320,7 @@ func Provider() *schema.Provider {
 			"duplocloud_k8_config_map":              dataSourceK8ConfigMap(),
 			"duplocloud_k8_config_maps":             dataSourceK8ConfigMaps(),
 			"duplocloud_k8s_job":                    dataSourceK8sJob(),
+			"duplocloud_k8s_network_policies":       dataSourceK8sNetworkPolicies(),
 			"duplocloud_k8s_cron_job":               dataSourceK8sCronJob(),
 			"duplocloud_k8_secret":                  dataSourceK8Secret(),
 			"duplocloud_k8_secrets":                 dataSourceK8Secrets(),
@@ -274,6 +345,7 @@ func Provider() *schema.Provider {
 			"duplocloud_tenant_external_subnets":    dataSourceTenantExternalSubnets(),
 			"duplocloud_tenant_secret":              dataSourceTenantSecret(),
 			"duplocloud_tenant_secrets":             dataSourceTenantSecrets(),
+			"duplocloud_tenant_inventory":           dataSourceTenantInventory(),
 			"duplocloud_emr_cluster":                dataSourceEmrClusters(),
 			"duplocloud_plan_certificate":           dataSourcePlanCert(),
 			"duplocloud_plan_certificates":          dataSourcePlanCerts(),
@@ -307,51 +379,242 @@ func Provider() *schema.Provider {
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for k, v := range providerAuthSchema() {
+		p.Schema[k] = v
+	}
+	for k, v := range providerTransportSchema() {
+		p.Schema[k] = v
+	}
+	for k, v := range providerProfileSchema() {
+		p.Schema[k] = v
+	}
+
+	for _, r := range p.ResourcesMap {
+		bindClientContext(r)
+		registerSensitiveFields(r.Schema)
+	}
+	for _, r := range p.DataSourcesMap {
+		bindClientContext(r)
+		registerSensitiveFields(r.Schema)
+	}
+	registerSensitiveFields(p.Schema)
+
+	return p
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	token := d.Get("duplo_token").(string)
-	host := d.Get("duplo_host").(string)
-
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	if token == "" {
-		token = os.Getenv("duplo_token")
-		if token == "" {
-			token = os.Getenv("DUPLO_TOKEN")
 
-		}
+	profile, err := providerProfile(d)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Duplocloud Unable to load the selected profile.",
+			Detail:   err.Error(),
+		})
+		return nil, diags
 	}
-	if host == "" {
-		host = os.Getenv("duplo_host")
-		if host == "" {
-			host = os.Getenv("DUPLO_HOST")
-		}
+
+	// Provider arguments take precedence over environment variables, which take precedence over the profile.
+	host, hostSource := providerSetting(d, "duplo_host", []string{"duplo_host", "DUPLO_HOST"}, profile, profileHost(profile))
+	token, tokenSource := providerSetting(d, "duplo_token", []string{"duplo_token", "DUPLO_TOKEN"}, profile, "")
+	ts, tsSource := providerTokenSource(d, token, profile)
+	if ts != nil {
+		tokenSource = tsSource
+	} else if token == "" && profile != nil && profile.Token != "" {
+		token, tokenSource = profile.Token, profile.source()
+	}
+	if profile != nil && profile.explicit && hostSource != "" && tokenSource != "" && (hostSource == profile.source()) != (tokenSource == profile.source()) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Duplocloud Mismatched host and token sources.",
+			Detail: fmt.Sprintf("%s was selected, but duplo_host comes from %s and the Duplo token from %s.  "+
+				"Set both in the profile, or both in the provider configuration.", profile.source(), hostSource, tokenSource),
+		})
+		return nil, diags
+	}
+	if host != "" {
+		log.Printf("[INFO] duplocloud provider: duplo_host %s from %s", host, hostSource)
+	}
+	if tokenSource != "" {
+		log.Printf("[INFO] duplocloud provider: Duplo token from %s", tokenSource)
 	}
 
-	c, err := duplosdk.NewClient(host, token)
+	var c *duplosdk.Client
+	if ts != nil {
+		c, err = duplosdk.NewClientWithTokenSource(host, ts)
+	} else {
+		c, err = duplosdk.NewClient(host, token)
+	}
 
 	if err != nil {
+		detail := "Duplocloud Unable to create anonymous Duplocloud client - provide env for duplo_token (or duplo_token_file, exec, token_exchange), duplo_host"
+		if profile != nil {
+			detail += fmt.Sprintf(", or set them in %s", profile.source())
+		}
+		if host != "" {
+			detail += fmt.Sprintf(".  duplo_host was found in %s", hostSource)
+		}
+		if tokenSource != "" {
+			detail += fmt.Sprintf(".  The Duplo token was found in %s", tokenSource)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Duplocloud Unable to create Duplocloud client.",
-			Detail:   "Duplocloud Unable to create anonymous Duplocloud client - provide env for duplo_token, duplo_host",
+			Detail:   detail,
+		})
+		return nil, diags
+	}
+
+	if profile != nil && profile.AdminUser != "" {
+		log.Printf("[INFO] duplocloud provider: admin_user %s from %s", profile.AdminUser, profile.source())
+		c.UserAccount = profile.AdminUser
+	}
+
+	sslNoVerify, ok := d.GetOkExists("ssl_no_verify") //nolint:all
+	if !ok && profile != nil && profile.SslNoVerify != nil {
+		log.Printf("[INFO] duplocloud provider: ssl_no_verify from %s", profile.source())
+		sslNoVerify = *profile.SslNoVerify
+	}
+	transport, err := providerTransport(d, sslNoVerify.(bool))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Duplocloud Unable to configure the HTTP transport.",
+			Detail:   err.Error(),
 		})
 		return nil, diags
 	}
+	c.HTTPClient.Transport = transport
+	if tes, ok := c.TokenSource.(*duplosdk.TokenExchangeSource); ok {
+		tes.HTTPClient = &http.Client{Transport: transport, Timeout: 30 * time.Second}
+	}
 
-	if sslNoVerify, ok := d.GetOk("ssl_no_verify"); ok && sslNoVerify.(bool) {
-		log.Printf("[TRACE] ssl_no_verify provided in the provider configuration.")
-		c.HTTPClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// Record the Duplo API traffic to a cassette, or replay it from one, to turn real runs into tests.
+	if dir := os.Getenv("DUPLO_CASSETTE"); dir != "" {
+		mode := os.Getenv("DUPLO_CASSETTE_MODE")
+		if mode == "" {
+			mode = duplosdk.CassetteReplay
 		}
+		log.Printf("[WARN] duplocloud provider: using the %s cassette in %s", mode, dir)
+		if err := c.UseCassette(dir, mode); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Duplocloud Unable to use the DUPLO_CASSETTE.",
+				Detail:   err.Error(),
+			})
+			return nil, diags
+		}
+	}
+
+	httpTimeout, ok := d.GetOk("http_timeout")
+	if ok {
+		log.Printf("[TRACE] http_timeout provided in the provider configuration.")
+	} else if profile != nil && profile.HttpTimeout != nil {
+		log.Printf("[INFO] duplocloud provider: http_timeout from %s", profile.source())
+		httpTimeout = *profile.HttpTimeout
+	} else {
+		httpTimeout = 30
+	}
+	timeout := time.Duration(httpTimeout.(int)) * time.Second
+	log.Printf("[TRACE] http_timeout : %s", timeout)
+	c.HTTPClient.Timeout = timeout
+
+	c.RetryPolicy = duplosdk.RetryPolicy{
+		MaxRetries: d.Get("max_retries").(int),
+		MinBackoff: time.Duration(d.Get("retry_min_backoff").(int)) * time.Second,
+		MaxBackoff: time.Duration(d.Get("retry_max_backoff").(int)) * time.Second,
+	}
+	if c.RetryPolicy.MaxBackoff < c.RetryPolicy.MinBackoff {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Duplocloud retry_max_backoff is lower than retry_min_backoff.",
+			Detail:   "Duplocloud will wait retry_max_backoff between retries.",
+		})
 	}
+	log.Printf("[TRACE] retry policy in the provider configuration: %+v", c.RetryPolicy)
+
+	rps := d.Get("requests_per_second").(float64)
+	maxConcurrent := d.Get("max_concurrent_requests").(int)
+	c.RateLimiter = duplosdk.NewRateLimiter(rps, maxConcurrent)
+	log.Printf("[TRACE] rate limits in the provider configuration: requests_per_second: %v, max_concurrent_requests: %d", rps, maxConcurrent)
 
-	if httpTimeout, ok := d.GetOk("http_timeout"); ok {
-		timout := time.Duration(httpTimeout.(int)) * time.Second
-		log.Printf("[TRACE] http_timeout provided in the provider configuration. Timeout : %s", timout)
-		c.HTTPClient.Timeout = timout * time.Second
+	c.DeletionProtection = expandDeletionProtectionPolicy(d.Get("deletion_protection").([]interface{}))
+	if c.DeletionProtection != nil {
+		log.Printf("[TRACE] deletion protection in the provider configuration: %+v", *c.DeletionProtection)
 	}
 
 	return c, diags
 }
+
+// withClientContext binds the configured Duplo client to the operation's context, so that
+// cancellation and operation timeouts abort in-flight API calls and retry sleeps, and so that
+// Duplo API calls are logged to the operation's logger.
+func withClientContext(ctx context.Context, meta interface{}) interface{} {
+	if c, ok := meta.(*duplosdk.Client); ok {
+		ctx = tflog.NewSubsystem(ctx, duplosdk.LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_SDK", duplosdk.LogSubsystem))
+		return c.WithContext(ctx)
+	}
+	return meta
+}
+
+// registerSensitiveFields redacts the Duplo API fields that match sensitive attributes from the logs.
+func registerSensitiveFields(s map[string]*schema.Schema) {
+	duplosdk.RegisterSensitiveFields(sensitiveFieldNames(s)...)
+}
+
+// sensitiveFieldNames returns the names of the sensitive attributes of a schema that are unambiguous enough to be
+// redacted from every Duplo API payload.
+//
+// Single-word names, such as `value`, `data` or `content`, are skipped: the same fields hold tags, config maps and
+// most other payloads, so redacting them everywhere would make the logs useless.  The SDK redacts those fields
+// only where the payload's type makes them sensitive, such as SSM secure strings or kubernetes secrets.
+func sensitiveFieldNames(s map[string]*schema.Schema) []string {
+	var names []string
+	for name, attr := range s {
+		if attr.Sensitive && strings.Contains(strings.TrimSuffix(name, "_wo"), "_") {
+			names = append(names, name)
+		}
+		if elem, ok := attr.Elem.(*schema.Resource); ok {
+			names = append(names, sensitiveFieldNames(elem.Schema)...)
+		}
+	}
+	return names
+}
+
+// bindClientContext wraps the context-aware callbacks of a resource so that they receive a
+// Duplo client bound to the callback's context.
+func bindClientContext(r *schema.Resource) {
+	r.CreateContext = wrapCrud(r.CreateContext)
+	r.ReadContext = wrapCrud(r.ReadContext)
+	r.UpdateContext = wrapCrud(r.UpdateContext)
+	r.DeleteContext = wrapCrud(r.DeleteContext)
+	r.CreateWithoutTimeout = wrapCrud(r.CreateWithoutTimeout)
+	r.ReadWithoutTimeout = wrapCrud(r.ReadWithoutTimeout)
+	r.UpdateWithoutTimeout = wrapCrud(r.UpdateWithoutTimeout)
+	r.DeleteWithoutTimeout = wrapCrud(r.DeleteWithoutTimeout)
+
+	if f := r.CustomizeDiff; f != nil {
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return f(ctx, d, withClientContext(ctx, meta))
+		}
+	}
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		f := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			return f(ctx, d, withClientContext(ctx, meta))
+		}
+	}
+}
+
+func wrapCrud[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return f(ctx, d, withClientContext(ctx, meta))
+	}
+}
@@ -0,0 +1,148 @@
+package duplocloud
+
+import (
+	"os"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The provider arguments that select how to authenticate to Duplo.
+var providerAuthKeys = []string{"duplo_token", "duplo_token_file", "exec", "token_exchange"}
+
+func providerAuthSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"duplo_token_file": {
+			Description:   "Path to a file containing the bearer token used to authenticate to the Duplo REST API.  The file is read again whenever it changes, or when Duplo rejects the token.  It can also be sourced from the `DUPLO_TOKEN_FILE` environment variable.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: conflictingAuthKeys("duplo_token_file"),
+		},
+		"exec": {
+			Description:   "Runs a credential helper to obtain the bearer token, in the style of kubeconfig exec plugins.  The helper must print the token, or a JSON object with `token` and an optional RFC3339 `expiration`, to stdout.  It is run again when the token expires, or when Duplo rejects it.",
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: conflictingAuthKeys("exec"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"command": {
+						Description: "The credential helper to run.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"args": {
+						Description: "Arguments to pass to the credential helper.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"env": {
+						Description: "Additional environment variables to set for the credential helper.",
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"token_exchange": {
+			Description:   "Trades an OIDC JWT issued by a CI system for a Duplo bearer token, using an RFC 8693 token exchange endpoint.  A new token is obtained when the current one expires, or when Duplo rejects it.",
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: conflictingAuthKeys("token_exchange"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"endpoint": {
+						Description: "The URL of the token exchange endpoint.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"subject_token": {
+						Description: "The OIDC JWT to exchange.  It can also be sourced from the `DUPLO_OIDC_TOKEN` environment variable.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"subject_token_file": {
+						Description: "Path to a file containing the OIDC JWT to exchange.  The file is read again on every exchange.  It can also be sourced from the `DUPLO_OIDC_TOKEN_FILE` environment variable.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"audience": {
+						Description: "The audience to request for the Duplo token.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func conflictingAuthKeys(key string) []string {
+	keys := make([]string, 0, len(providerAuthKeys)-1)
+	for _, k := range providerAuthKeys {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// providerTokenSource builds a token source from the provider configuration, environment variables
+// or the selected profile, or returns nil if the provider should authenticate with a static token.
+// It also returns where the token source came from.
+//
+// The static token given by the provider configuration or environment variables takes precedence
+// over the profile.
+func providerTokenSource(d *schema.ResourceData, token string, profile *duploProfile) (duplosdk.TokenSource, string) {
+	if v, ok := d.GetOk("exec"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		m := v.([]interface{})[0].(map[string]interface{})
+		ts := &duplosdk.ExecTokenSource{
+			Command: m["command"].(string),
+			Env:     map[string]string{},
+		}
+		for _, arg := range m["args"].([]interface{}) {
+			ts.Args = append(ts.Args, arg.(string))
+		}
+		for k, v := range m["env"].(map[string]interface{}) {
+			ts.Env[k] = v.(string)
+		}
+		return ts, "the provider configuration"
+	}
+
+	if v, ok := d.GetOk("token_exchange"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		m := v.([]interface{})[0].(map[string]interface{})
+		ts := &duplosdk.TokenExchangeSource{
+			Endpoint:         m["endpoint"].(string),
+			SubjectToken:     m["subject_token"].(string),
+			SubjectTokenFile: m["subject_token_file"].(string),
+			Audience:         m["audience"].(string),
+		}
+		if ts.SubjectToken == "" && ts.SubjectTokenFile == "" {
+			ts.SubjectToken = os.Getenv("DUPLO_OIDC_TOKEN")
+			ts.SubjectTokenFile = os.Getenv("DUPLO_OIDC_TOKEN_FILE")
+		}
+		return ts, "the provider configuration"
+	}
+
+	if path := d.Get("duplo_token_file").(string); path != "" {
+		return &duplosdk.FileTokenSource{Path: path}, "the provider configuration"
+	}
+	if token != "" {
+		return nil, ""
+	}
+	if path := os.Getenv("DUPLO_TOKEN_FILE"); path != "" && (profile == nil || !profile.explicit) {
+		return &duplosdk.FileTokenSource{Path: path}, "the DUPLO_TOKEN_FILE environment variable"
+	}
+
+	if profile != nil {
+		if ts := profile.tokenSource(); ts != nil {
+			return ts, profile.source()
+		}
+	}
+	return nil, ""
+}
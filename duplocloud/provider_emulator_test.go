@@ -0,0 +1,195 @@
+package duplocloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+	"github.com/duplocloud/terraform-provider-duplocloud/internal/duplosdktest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccEmulatorClient(t *testing.T) *duplosdk.Client {
+	duplosdktest.ResetEmulator()
+	c, err := duplosdk.NewClient(testAccEmulator.URL, "FAKE")
+	require.NoError(t, err)
+	return c.WithoutCache()
+}
+
+func TestEmulator_ReplicationController(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	err := c.ReplicationControllerCreate(Tenant_testacc1a, &duplosdk.DuploReplicationControllerCreateRequest{
+		Name:     "web",
+		Image:    "nginx:latest",
+		Replicas: 2,
+	})
+	require.Nil(t, err)
+
+	rc, err := c.ReplicationControllerGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	require.NotNil(t, rc)
+	assert.Equal(t, 2, rc.Replicas)
+	assert.Equal(t, "nginx:latest", (*rc.Template.Containers)[0].Image)
+
+	err = c.ReplicationControllerUpdate(Tenant_testacc1a, &duplosdk.DuploReplicationControllerUpdateRequest{
+		Name:     "web",
+		Image:    "nginx:1.27",
+		Replicas: 3,
+	})
+	require.Nil(t, err)
+
+	list, err := c.ReplicationControllerList(Tenant_testacc1a)
+	require.Nil(t, err)
+	require.Len(t, *list, 1)
+	assert.Equal(t, 3, (*list)[0].Replicas)
+	assert.Equal(t, "nginx:1.27", (*(*list)[0].Template.Containers)[0].Image)
+
+	err = c.ReplicationControllerDelete(Tenant_testacc1a, &duplosdk.DuploReplicationControllerDeleteRequest{Name: "web"})
+	require.Nil(t, err)
+
+	rc, err = c.ReplicationControllerGet(Tenant_testacc1a, "web")
+	assert.Nil(t, err)
+	assert.Nil(t, rc)
+}
+
+func TestEmulator_K8sSecretAndConfigMap(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	err := c.K8SecretCreate(Tenant_testacc1a, &duplosdk.DuploK8sSecret{
+		SecretName: "creds",
+		SecretType: "Opaque",
+		SecretData: map[string]interface{}{"user": "admin"},
+	})
+	require.Nil(t, err)
+
+	secret, err := c.K8SecretGet(Tenant_testacc1a, "creds")
+	require.Nil(t, err)
+	assert.Equal(t, "admin", secret.SecretData["user"])
+
+	require.Nil(t, c.K8SecretDelete(Tenant_testacc1a, "creds"))
+	_, err = c.K8SecretGet(Tenant_testacc1a, "creds")
+	assert.True(t, errors.Is(err, duplosdk.ErrNotFound))
+
+	_, err = c.K8ConfigMapCreate(Tenant_testacc1a, &duplosdk.DuploK8sConfigMap{
+		Data:     map[string]interface{}{"key": "value"},
+		Metadata: map[string]interface{}{"name": "settings"},
+	})
+	require.Nil(t, err)
+
+	cm, err := c.K8ConfigMapGet(Tenant_testacc1a, "settings")
+	require.Nil(t, err)
+	require.NotNil(t, cm)
+	assert.Equal(t, "value", cm.Data["key"])
+
+	require.Nil(t, c.K8ConfigMapDelete(Tenant_testacc1a, "settings"))
+	_, err = c.K8ConfigMapGet(Tenant_testacc1a, "settings")
+	assert.True(t, errors.Is(err, duplosdk.ErrNotFound))
+}
+
+func TestEmulator_RdsInstance(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	rds, err := c.RdsInstanceCreate(Tenant_testacc1a, &duplosdk.DuploRdsInstance{Name: "db1", Engine: 0, SizeEx: "db.t3.micro"})
+	require.Nil(t, err)
+	assert.Equal(t, "duplodb1", rds.Identifier)
+
+	rds, err = c.RdsInstanceGetByName(Tenant_testacc1a, "db1")
+	require.Nil(t, err)
+	require.NotNil(t, rds)
+	assert.Equal(t, "available", rds.InstanceStatus)
+
+	list, err := c.RdsInstanceList(Tenant_testacc1a)
+	require.Nil(t, err)
+	assert.Len(t, *list, 1)
+
+	_, err = c.RdsInstanceDelete("v2/subscriptions/" + Tenant_testacc1a + "/RDSDBInstance/db1")
+	require.Nil(t, err)
+
+	list, err = c.RdsInstanceList(Tenant_testacc1a)
+	require.Nil(t, err)
+	assert.Empty(t, *list)
+}
+
+func TestEmulator_CloudResources(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	require.Nil(t, c.TenantCreateApplicationLB(Tenant_testacc1a, duplosdk.DuploAwsLBConfiguration{Name: "duplo3-testacc1a-lb", IsInternal: true}))
+	require.Nil(t, c.TenantCreateS3Bucket(Tenant_testacc1a, duplosdk.DuploS3BucketRequest{Name: "duploservices-testacc1a-bucket"}))
+
+	lb, err := c.TenantGetAwsCloudResource(Tenant_testacc1a, duplosdk.ResourceTypeApplicationLB, "duplo3-testacc1a-lb")
+	require.Nil(t, err)
+	require.NotNil(t, lb)
+	assert.True(t, lb.IsInternal)
+
+	bucket, err := c.TenantGetAwsCloudResource(Tenant_testacc1a, duplosdk.ResourceTypeS3Bucket, "duploservices-testacc1a-bucket")
+	require.Nil(t, err)
+	require.NotNil(t, bucket)
+	assert.Equal(t, "arn:aws:s3:::duploservices-testacc1a-bucket", bucket.Arn)
+
+	v3, err := c.TenantCreateV3S3Bucket(Tenant_testacc1a, duplosdk.DuploS3BucketSettingsRequest{Name: "duploservices-testacc1a-v3", EnableVersioning: true})
+	require.Nil(t, err)
+	require.NotNil(t, v3)
+	v3, err = c.TenantGetV3S3Bucket(Tenant_testacc1a, "duploservices-testacc1a-v3")
+	require.Nil(t, err)
+	require.NotNil(t, v3)
+	assert.True(t, v3.EnableVersioning)
+}
+
+func TestEmulator_TenantSecretAndEcsService(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	_, err := c.TenantCreateAwsSecret(Tenant_testacc1a, &duplosdk.DuploAwsSecretCreateRequest{Name: "api-key", SecretString: "s3cr3t"})
+	require.Nil(t, err)
+	secret, err := c.TenantGetAwsSecret(Tenant_testacc1a, "api-key")
+	require.Nil(t, err)
+	require.NotNil(t, secret)
+	value, err := c.TenantGetAwsSecretValue(Tenant_testacc1a, "api-key")
+	require.Nil(t, err)
+	assert.Equal(t, "s3cr3t", value.SecretString)
+
+	_, err = c.EcsServiceCreate(Tenant_testacc1a, &duplosdk.DuploEcsService{Name: "api", Replicas: 2})
+	require.Nil(t, err)
+	ecs, err := c.EcsServiceGetV2("v2/subscriptions/" + Tenant_testacc1a + "/EcsServiceApiV2/api")
+	require.Nil(t, err)
+	require.NotNil(t, ecs)
+	assert.Equal(t, 2, ecs.Replicas)
+}
+
+func TestEmulator_PlanSettings(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	settings, err := c.PlanGetSettings("testacc1")
+	require.Nil(t, err)
+	assert.False(t, settings.BlockBYOHosts)
+
+	_, err = c.PlanUpdateSettings("testacc1", &duplosdk.DuploPlanSettings{BlockBYOHosts: true})
+	require.Nil(t, err)
+
+	settings, err = c.PlanGetSettings("testacc1")
+	require.Nil(t, err)
+	assert.True(t, settings.BlockBYOHosts)
+}
+
+func TestEmulator_WaitForEventuallyConsistentCreate(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	duplosdktest.InjectFaults(duplosdktest.EmuEventuallyConsistent("POST", "/subscriptions/:tenantId/ReplicationControllerUpdate", 4))
+
+	err := c.ReplicationControllerCreate(Tenant_testacc1a, &duplosdk.DuploReplicationControllerCreateRequest{Name: "web", Image: "nginx:latest"})
+	require.Nil(t, err)
+
+	// Each get reads the service twice: by name, then from the list.  So it is only visible on the third get.
+	rc, err := c.ReplicationControllerGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	require.Nil(t, rc)
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	diags := waitForResourceToBePresentAfterCreate(context.Background(), d, "duplo service", "web", func() (interface{}, duplosdk.ClientError) {
+		return c.ReplicationControllerGet(Tenant_testacc1a, "web")
+	})
+	assert.False(t, diags.HasError())
+}
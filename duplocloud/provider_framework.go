@@ -0,0 +1,204 @@
+package duplocloud
+
+import (
+	"context"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	fwattr "github.com/hashicorp/terraform-plugin-framework/attr"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServerFactory returns a protocol 5 server that combines the SDKv2 provider with the plugin-framework
+// provider.  Both halves share the SDKv2 provider's configuration, and its Duplo client.
+func ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return newProviderServerFactory(ctx, Provider())
+}
+
+func newProviderServerFactory(ctx context.Context, sdk *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	mux, err := tf5muxserver.NewMuxServer(ctx,
+		sdk.GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider(sdk)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return mux.ProviderServer, nil
+}
+
+// frameworkProvider is the plugin-framework half of the provider.
+//
+// It declares the same configuration as the SDKv2 provider, as required by the mux server, but it does not
+// configure a client of its own: its resources, data sources and other objects use the SDKv2 provider's client.
+type frameworkProvider struct {
+	sdk *schema.Provider
+}
+
+var (
+	_ fwprovider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ fwprovider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+// NewFrameworkProvider returns the plugin-framework provider that is served alongside an SDKv2 provider.
+func NewFrameworkProvider(sdk *schema.Provider) fwprovider.Provider {
+	return &frameworkProvider{sdk: sdk}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "duplocloud"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	attributes, blocks := frameworkProviderSchema(p.sdk.Schema)
+	resp.Schema = fwschema.Schema{Attributes: attributes, Blocks: blocks}
+}
+
+// Configure hands the provider itself to the framework objects, since the mux server may configure the SDKv2
+// provider after this one.  The objects get the client with frameworkClient.
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	resp.DataSourceData = p
+	resp.EphemeralResourceData = p
+	resp.ResourceData = p
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() fwresource.Resource {
+	return []func() fwresource.Resource{}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() fwdatasource.DataSource {
+	return []func() fwdatasource.DataSource{}
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newEphemeralAdminAwsCredentials,
+		newEphemeralEksCredentials,
+		newEphemeralGkeCredentials,
+		newEphemeralK8Secret,
+		newEphemeralTenantAwsCredentials,
+		newEphemeralTenantEksCredentials,
+	}
+}
+
+// Functions returns the provider-defined functions, which do not use the Duplo client.
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newFunctionParseID,
+		newFunctionServiceName,
+		newFunctionUnwrapName,
+	}
+}
+
+// frameworkClient returns the Duplo client for a framework object, bound to the context of the current call.
+//
+// The client is nil, without errors, when the provider is not configured yet: the framework configures its
+// objects before the provider during validation.
+func frameworkClient(ctx context.Context, providerData interface{}) (*duplosdk.Client, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	if providerData == nil {
+		return nil, diags
+	}
+
+	p, ok := providerData.(*frameworkProvider)
+	if !ok {
+		diags.AddError("Unexpected provider data", "The framework object was configured by an unknown provider.")
+		return nil, diags
+	}
+	c, ok := withClientContext(ctx, p.sdk.Meta()).(*duplosdk.Client)
+	if !ok {
+		diags.AddError("Unconfigured provider", "The duplocloud provider has not been configured yet.")
+		return nil, diags
+	}
+	return c, diags
+}
+
+// ephemeralBase is embedded by the ephemeral resources, to keep the provider data they are configured with.
+type ephemeralBase struct {
+	providerData interface{}
+}
+
+func (e *ephemeralBase) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.providerData = req.ProviderData
+}
+
+// client returns the Duplo client, which must be configured by the time an ephemeral resource is opened.
+func (e *ephemeralBase) client(ctx context.Context) (*duplosdk.Client, fwdiag.Diagnostics) {
+	c, diags := frameworkClient(ctx, e.providerData)
+	if c == nil && !diags.HasError() {
+		diags.AddError("Unconfigured provider", "The duplocloud provider has not been configured yet.")
+	}
+	return c, diags
+}
+
+// frameworkProviderSchema converts the SDKv2 provider's configuration to framework attributes and blocks, so
+// that both halves of the mux server have identical provider schemas.
+func frameworkProviderSchema(s map[string]*schema.Schema) (map[string]fwschema.Attribute, map[string]fwschema.Block) {
+	attributes := map[string]fwschema.Attribute{}
+	blocks := map[string]fwschema.Block{}
+
+	for name, attr := range s {
+		description := attr.Description
+		if schema.SchemaDescriptionBuilder != nil {
+			description = schema.SchemaDescriptionBuilder(attr)
+		}
+
+		if elem, ok := attr.Elem.(*schema.Resource); ok {
+			nestedAttributes, nestedBlocks := frameworkProviderSchema(elem.Schema)
+			nested := fwschema.NestedBlockObject{Attributes: nestedAttributes, Blocks: nestedBlocks}
+			if attr.Type == schema.TypeSet {
+				blocks[name] = fwschema.SetNestedBlock{NestedObject: nested, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+			} else {
+				blocks[name] = fwschema.ListNestedBlock{NestedObject: nested, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+			}
+			continue
+		}
+
+		required, optional := attr.Required, !attr.Required
+		switch attr.Type {
+		case schema.TypeString:
+			attributes[name] = fwschema.StringAttribute{Required: required, Optional: optional, Sensitive: attr.Sensitive, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+		case schema.TypeInt:
+			attributes[name] = fwschema.Int64Attribute{Required: required, Optional: optional, Sensitive: attr.Sensitive, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+		case schema.TypeFloat:
+			attributes[name] = fwschema.Float64Attribute{Required: required, Optional: optional, Sensitive: attr.Sensitive, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+		case schema.TypeBool:
+			attributes[name] = fwschema.BoolAttribute{Required: required, Optional: optional, Sensitive: attr.Sensitive, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+		case schema.TypeList:
+			attributes[name] = fwschema.ListAttribute{ElementType: frameworkElementType(attr.Elem), Required: required, Optional: optional, Sensitive: attr.Sensitive, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+		case schema.TypeSet:
+			attributes[name] = fwschema.SetAttribute{ElementType: frameworkElementType(attr.Elem), Required: required, Optional: optional, Sensitive: attr.Sensitive, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+		case schema.TypeMap:
+			attributes[name] = fwschema.MapAttribute{ElementType: frameworkElementType(attr.Elem), Required: required, Optional: optional, Sensitive: attr.Sensitive, MarkdownDescription: description, DeprecationMessage: attr.Deprecated}
+		}
+	}
+
+	return attributes, blocks
+}
+
+// frameworkElementType returns the framework type of the elements of an SDKv2 list, set or map.
+func frameworkElementType(elem interface{}) fwattr.Type {
+	s, ok := elem.(*schema.Schema)
+	if !ok {
+		return types.StringType
+	}
+	switch s.Type {
+	case schema.TypeInt:
+		return types.Int64Type
+	case schema.TypeFloat:
+		return types.Float64Type
+	case schema.TypeBool:
+		return types.BoolType
+	}
+	return types.StringType
+}
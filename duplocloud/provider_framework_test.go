@@ -0,0 +1,35 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderServerFactory(t *testing.T) {
+	ctx := context.Background()
+	factory, err := ProviderServerFactory(ctx)
+	require.NoError(t, err)
+
+	// The mux server fails when the two providers do not declare identical configurations.
+	resp, err := factory().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		assert.NotEqual(t, tfprotov5.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+	assert.Contains(t, resp.ResourceSchemas, "duplocloud_tenant")
+	assert.Contains(t, resp.Functions, "service_name")
+
+	var host *tfprotov5.SchemaAttribute
+	for _, a := range resp.Provider.Block.Attributes {
+		if a.Name == "duplo_host" {
+			host = a
+		}
+	}
+	require.NotNil(t, host)
+	assert.Equal(t, tfprotov5.StringKindMarkdown, host.DescriptionKind)
+	assert.True(t, host.Optional)
+}
@@ -0,0 +1,193 @@
+package duplocloud
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultDuploConfigFile is the config file read when neither `config_file` nor DUPLO_CONFIG are set,
+// relative to the user's home directory.
+const defaultDuploConfigFile = ".duplo/config"
+
+// duploConfigFile is the content of a Duplo config file, such as:
+//
+//	current_profile: dev
+//	profiles:
+//	  dev:
+//	    host: https://dev.duplocloud.net
+//	    token_file: ~/.duplo/dev-token
+//	  prod:
+//	    host: https://prod.duplocloud.net
+//	    exec:
+//	      command: duploctl
+//	      args: ["jit", "token"]
+//	    http_timeout: 60
+type duploConfigFile struct {
+	CurrentProfile string                  `yaml:"current_profile"`
+	Profiles       map[string]duploProfile `yaml:"profiles"`
+}
+
+// duploProfile is a named set of provider settings, read from a Duplo config file.
+type duploProfile struct {
+	Host        string            `yaml:"host"`
+	Token       string            `yaml:"token"`
+	TokenFile   string            `yaml:"token_file"`
+	Exec        *duploProfileExec `yaml:"exec"`
+	SslNoVerify *bool             `yaml:"ssl_no_verify"`
+	HttpTimeout *int              `yaml:"http_timeout"`
+	AdminUser   string            `yaml:"admin_user"`
+
+	name string
+	path string
+
+	// explicit is set when the profile was selected by the provider configuration or DUPLO_PROFILE,
+	// rather than by the config file's current_profile.
+	explicit bool
+}
+
+type duploProfileExec struct {
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args"`
+	Env     map[string]string `yaml:"env"`
+}
+
+// source describes where the profile's settings come from, for logs and diagnostics.
+func (p *duploProfile) source() string {
+	return fmt.Sprintf("profile %q in %s", p.name, p.path)
+}
+
+// tokenSource builds a token source from the profile's token helper or token file, or returns nil.
+func (p *duploProfile) tokenSource() duplosdk.TokenSource {
+	if p.Exec != nil && p.Exec.Command != "" {
+		return &duplosdk.ExecTokenSource{Command: p.Exec.Command, Args: p.Exec.Args, Env: p.Exec.Env}
+	}
+	if p.TokenFile != "" {
+		return &duplosdk.FileTokenSource{Path: expandHomeDir(p.TokenFile)}
+	}
+	return nil
+}
+
+func providerProfileSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"profile": {
+			Description: "The name of the profile to use from the Duplo config file.  It can also be sourced from the `DUPLO_PROFILE` environment variable.  " +
+				"When neither are set, the config file's `current_profile` is used, if any.  " +
+				"Provider arguments and environment variables take precedence over the settings of the profile, " +
+				"except for an explicitly selected profile: its host and credentials are never mixed with the ones from environment variables.",
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"config_file": {
+			Description: "Path to the Duplo config file that holds named profiles.  It can also be sourced from the `DUPLO_CONFIG` environment variable.  Defaults to `~/.duplo/config`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	}
+}
+
+// providerProfile loads the selected profile from the Duplo config file.
+//
+// It returns nil when no profile was selected, or when the default config file does not exist.
+// It fails if a profile was explicitly selected but cannot be found.
+func providerProfile(d *schema.ResourceData) (*duploProfile, error) {
+	name, nameSource := d.Get("profile").(string), "the provider configuration"
+	if name == "" {
+		name, nameSource = os.Getenv("DUPLO_PROFILE"), "the DUPLO_PROFILE environment variable"
+	}
+
+	path, explicitPath := d.Get("config_file").(string), true
+	if path == "" {
+		path = os.Getenv("DUPLO_CONFIG")
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			if name != "" {
+				return nil, fmt.Errorf("cannot locate the Duplo config file for profile %q (from %s): %w", name, nameSource, err)
+			}
+			return nil, nil
+		}
+		path, explicitPath = filepath.Join(home, defaultDuploConfigFile), false
+	}
+	path = expandHomeDir(path)
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicitPath && name == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read the Duplo config file: %w", err)
+	}
+
+	config := duploConfigFile{}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return nil, fmt.Errorf("cannot parse the Duplo config file %s: %w", path, err)
+	}
+
+	explicit := name != ""
+	if name == "" {
+		name, nameSource = config.CurrentProfile, "current_profile in "+path
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q (from %s) is not defined in the Duplo config file %s", name, nameSource, path)
+	}
+	profile.name, profile.path, profile.explicit = name, path, explicit
+	log.Printf("[INFO] duplocloud provider: using %s (selected by %s)", profile.source(), nameSource)
+	return &profile, nil
+}
+
+// providerSetting resolves a string provider setting from, in order of precedence, the provider
+// configuration, the given environment variables and the selected profile.  It also returns where
+// the value came from.
+//
+// The environment variables are ignored when a profile was explicitly selected, so that the host of
+// one Duplo portal is never combined with the credentials of another.
+func providerSetting(d *schema.ResourceData, key string, envVars []string, profile *duploProfile, profileValue string) (string, string) {
+	if v := d.Get(key).(string); v != "" {
+		return v, "the provider configuration"
+	}
+	if profile != nil && profile.explicit {
+		envVars = nil
+	}
+	for _, env := range envVars {
+		if v := os.Getenv(env); v != "" {
+			return v, "the " + env + " environment variable"
+		}
+	}
+	if profile != nil && profileValue != "" {
+		return profileValue, profile.source()
+	}
+	return "", ""
+}
+
+// expandHomeDir expands a leading ~ in a path to the user's home directory.
+func expandHomeDir(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+func profileHost(profile *duploProfile) string {
+	if profile == nil {
+		return ""
+	}
+	return profile.Host
+}
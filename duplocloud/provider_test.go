@@ -2,7 +2,10 @@ package duplocloud
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/http/httptest"
 	"strings"
 
 	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
@@ -10,6 +13,7 @@ import (
 
 	"github.com/barkimedes/go-deepcopy"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,6 +21,13 @@ import (
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
+// testAccProtoV5ProviderFactories serve the test provider together with the plugin-framework provider, for
+// tests of framework resources.
+var testAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+
+// testAccEmulator is the fake Duplo API that the test provider talks to.
+var testAccEmulator *httptest.Server
+
 const (
 	TestAccProvider_PREAMBLE = `
 `
@@ -29,11 +40,21 @@ func init() {
 	}
 
 	testAccProvider.ConfigureContextFunc = testAccProvider_ConfigureContextFunc(testAccProvider)
+
+	testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"duplocloud": func() (tfprotov5.ProviderServer, error) {
+			factory, err := newProviderServerFactory(context.Background(), testAccProvider)
+			if err != nil {
+				return nil, err
+			}
+			return factory(), nil
+		},
+	}
 }
 
 func testAccProvider_ConfigureContextFunc(d *schema.Provider) schema.ConfigureContextFunc {
 	orig := d.ConfigureContextFunc
-	srv := duplosdktest.NewEmulator(duplosdktest.EmuConfig{
+	testAccEmulator = duplosdktest.NewEmulator(duplosdktest.EmuConfig{
 		Types: map[string]duplosdktest.EmuType{
 			"tenant": {
 				Factory: func() interface{} { return &duplosdk.DuploTenant{} },
@@ -86,6 +107,217 @@ func testAccProvider_ConfigureContextFunc(d *schema.Provider) schema.ConfigureCo
 					return
 				},
 			},
+			"plan/:planId/settings": {
+				Factory: func() interface{} { return &duplosdk.DuploPlanSettings{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploPlanSettings))
+					return
+				},
+			},
+			"tenant/:tenantId/replication_controller": {
+				Factory: func() interface{} { return &map[string]interface{}{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					rq := *in.(*map[string]interface{})
+					id, _ = rq["Name"].(string)
+					if rq["State"] == "delete" {
+						return id, nil
+					}
+
+					// Duplo moves the container settings of the request into the pod template.
+					rp := &duplosdk.DuploReplicationController{}
+					testAccEmuConvert(rq, rp)
+					template := &duplosdk.DuploPodTemplate{}
+					testAccEmuConvert(rq, template)
+					template.Name = id
+					template.Containers = &[]duplosdk.DuploPodContainer{{Name: id}}
+					if image, ok := rq["DockerImage"].(string); ok {
+						(*template.Containers)[0].Image = image
+					}
+					rp.Template = template
+					return id, rp
+				},
+			},
+			"tenant/:tenantId/lb_configuration": {
+				Factory: func() interface{} { return &map[string]interface{}{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					rq := *in.(*map[string]interface{})
+					id = fmt.Sprintf("%v-%v", rq["ReplicationControllerName"], rq["Port"])
+					if rq["State"] == "delete" {
+						return id, nil
+					}
+					rp := &duplosdk.DuploLbConfiguration{}
+					testAccEmuConvert(rq, rp)
+					return id, rp
+				},
+			},
+			"tenant/:tenantId/k8s_secret": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sSecret{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sSecret))
+					id = out.(*duplosdk.DuploK8sSecret).SecretName
+					return
+				},
+			},
+			"tenant/:tenantId/k8s_config_map": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sConfigMap{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sConfigMap))
+					id, _ = out.(*duplosdk.DuploK8sConfigMap).Metadata["name"].(string)
+					return
+				},
+			},
+			"tenant/:tenantId/k8s_service": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sService{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sService))
+					id = out.(*duplosdk.DuploK8sService).Metadata.Name
+					return
+				},
+			},
+			"tenant/:tenantId/k8s_horizontal_pod_autoscaler": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sHorizontalPodAutoscaler{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					// Copied through JSON, because deepcopy loses the unexported fields of resource quantities.
+					rp := &duplosdk.DuploK8sHorizontalPodAutoscaler{}
+					testAccEmuConvert(in, rp)
+					return rp.Metadata.Name, rp
+				},
+			},
+			"tenant/:tenantId/k8s_pod_disruption_budget": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sPodDisruptionBudget{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sPodDisruptionBudget))
+					id = out.(*duplosdk.DuploK8sPodDisruptionBudget).Metadata.Name
+					return
+				},
+			},
+			"tenant/:tenantId/k8s_service_account": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sServiceAccount{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sServiceAccount))
+					id = out.(*duplosdk.DuploK8sServiceAccount).Metadata.Name
+					return
+				},
+			},
+			"tenant/:tenantId/k8s_role": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sRole{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sRole))
+					id = out.(*duplosdk.DuploK8sRole).Metadata.Name
+					return
+				},
+			},
+			"tenant/:tenantId/k8s_role_binding": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sRoleBinding{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sRoleBinding))
+					id = out.(*duplosdk.DuploK8sRoleBinding).Metadata.Name
+					return
+				},
+			},
+			"tenant/:tenantId/k8s_network_policy": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sNetworkPolicy{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sNetworkPolicy))
+					id = out.(*duplosdk.DuploK8sNetworkPolicy).Metadata.Name
+					return
+				},
+			},
+			"k8s/namespaces/:namespace/:resource": {
+				Factory: func() interface{} { return &duplosdk.DuploK8sManifest{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploK8sManifest))
+					id = out.(*duplosdk.DuploK8sManifest).Name()
+					return
+				},
+			},
+			"tenant/:tenantId/aws_secret": {
+				Factory: func() interface{} { return &map[string]interface{}{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					rq := *in.(*map[string]interface{})
+
+					// Creates send the name, updates send the secret ID.  The emulator uses the same value for both.
+					if verb == "POST" {
+						id, _ = rq["Name"].(string)
+					} else {
+						id, _ = rq["SecretId"].(string)
+					}
+					out = map[string]interface{}{
+						"Name":         id,
+						"SecretId":     id,
+						"ARN":          "arn:aws:secretsmanager:us-west-2:12345678900:secret:" + id,
+						"SecretString": rq["SecretString"],
+					}
+					return
+				},
+			},
+			"tenant/:tenantId/rds_instance": {
+				Factory: func() interface{} { return &duplosdk.DuploRdsInstance{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploRdsInstance))
+					rds := out.(*duplosdk.DuploRdsInstance)
+					if verb == "POST" {
+						rds.Identifier = duplosdk.EnsureDuploPrefixInRdsIdentifier(rds.Name)
+						rds.Arn = "arn:aws:rds:us-west-2:12345678900:db:" + rds.Identifier
+						rds.Endpoint = rds.Identifier + ".emulator.rds.amazonaws.com:3306"
+					}
+					rds.InstanceStatus = "available"
+					id = rds.Identifier
+					return
+				},
+			},
+			"tenant/:tenantId/aws_cloud_resource/s3_bucket": {
+				Factory: func() interface{} { return &duplosdk.DuploS3BucketRequest{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					rq := in.(*duplosdk.DuploS3BucketRequest)
+					id = rq.Name
+					if rq.State == "delete" {
+						return id, nil
+					}
+					return id, &duplosdk.DuploAwsCloudResource{
+						Type: duplosdk.ResourceTypeS3Bucket,
+						Name: rq.Name,
+						Arn:  "arn:aws:s3:::" + rq.Name,
+					}
+				},
+			},
+			"tenant/:tenantId/aws_cloud_resource/application_lb": {
+				Factory: func() interface{} { return &duplosdk.DuploAwsLBConfiguration{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					rq := in.(*duplosdk.DuploAwsLBConfiguration)
+					id = rq.Name
+					if rq.State == "delete" {
+						return id, nil
+					}
+					return id, &duplosdk.DuploAwsCloudResource{
+						Type:             duplosdk.ResourceTypeApplicationLB,
+						Name:             rq.Name,
+						Arn:              "arn:aws:elasticloadbalancing:us-west-2:12345678900:loadbalancer/app/" + rq.Name,
+						MetaData:         rq.Name + ".us-west-2.elb.amazonaws.com",
+						IsInternal:       rq.IsInternal,
+						EnableAccessLogs: rq.EnableAccessLogs,
+					}
+				},
+			},
+			"tenant/:tenantId/s3_bucket": {
+				Factory: func() interface{} { return &duplosdk.DuploS3BucketSettingsRequest{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					rq := in.(*duplosdk.DuploS3BucketSettingsRequest)
+					rp := &duplosdk.DuploS3Bucket{}
+					testAccEmuConvert(rq, rp)
+					rp.Arn = "arn:aws:s3:::" + rp.Name
+					rp.DomainName = rp.Name + ".s3.amazonaws.com"
+					return rp.Name, rp
+				},
+			},
+			"tenant/:tenantId/ecs_service": {
+				Factory: func() interface{} { return &duplosdk.DuploEcsService{} },
+				Responder: func(verb string, in interface{}) (id string, out interface{}) {
+					out = deepcopy.MustAnything(in.(*duplosdk.DuploEcsService))
+					id = out.(*duplosdk.DuploEcsService).Name
+					return
+				},
+			},
 			"/v3/admin/infrastructure/:infraName": {
 				Factory: func() interface{} { return &duplosdk.DuploInfrastructureConfig{} },
 				Responder: func(verb string, in interface{}) (id string, out interface{}) {
@@ -102,13 +334,24 @@ func testAccProvider_ConfigureContextFunc(d *schema.Provider) schema.ConfigureCo
 		c, diags := orig(ctx, d)
 
 		if client, ok := c.(*duplosdk.Client); ok {
-			client.HostURL = srv.URL
+			client.HostURL = testAccEmulator.URL
 		}
 
 		return c, diags
 	}
 }
 
+// testAccEmuConvert copies the fields of one emulated object to another, by their JSON names.
+func testAccEmuConvert(from, to interface{}) {
+	bytes, err := json.Marshal(from)
+	if err == nil {
+		err = json.Unmarshal(bytes, to)
+	}
+	if err != nil {
+		log.Panicf("testAccEmuConvert: %T to %T: %s", from, to, err)
+	}
+}
+
 func testAccProvider_GenConfig(body string) string {
 	return TestAccProvider_PREAMBLE + body
 }
@@ -0,0 +1,157 @@
+package duplocloud
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/http/httpproxy"
+)
+
+func providerTransportSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"ca_cert_file": {
+			Description:   "Path to a PEM-encoded CA bundle used to verify the Duplo API's certificate, in addition to the system's trusted CAs.  It can also be sourced from the `DUPLO_CA_CERT_FILE` environment variable.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"ca_cert_pem"},
+		},
+		"ca_cert_pem": {
+			Description:   "PEM-encoded CA bundle used to verify the Duplo API's certificate, in addition to the system's trusted CAs.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"ca_cert_file"},
+		},
+		"client_cert_file": {
+			Description:   "Path to a PEM-encoded client certificate, presented to the Duplo API for mutual TLS.  Requires `client_key_file`.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			RequiredWith:  []string{"client_key_file"},
+			ConflictsWith: []string{"client_cert_pem"},
+		},
+		"client_key_file": {
+			Description:  "Path to the PEM-encoded private key of `client_cert_file`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"client_cert_file"},
+		},
+		"client_cert_pem": {
+			Description:   "PEM-encoded client certificate, presented to the Duplo API for mutual TLS.  Requires `client_key_pem`.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			RequiredWith:  []string{"client_key_pem"},
+			ConflictsWith: []string{"client_cert_file"},
+		},
+		"client_key_pem": {
+			Description:  "PEM-encoded private key of `client_cert_pem`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			RequiredWith: []string{"client_cert_pem"},
+		},
+		"proxy_url": {
+			Description: "URL of the HTTP proxy used to reach the Duplo API.  When not set, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are honored.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"no_proxy": {
+			Description: "Hosts that are reached without a proxy, as a list of host names, domain suffixes, IP addresses or CIDR ranges.  It applies to `proxy_url` as well as to the proxy environment variables, and takes precedence over the `NO_PROXY` environment variable.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+// providerTransport builds the HTTP transport used to reach the Duplo API.
+//
+// It is a clone of http.DefaultTransport, so that connection pooling, timeouts and HTTP/2 support
+// are kept, with the TLS and proxy settings of the provider configuration applied on top.
+func providerTransport(d *schema.ResourceData, sslNoVerify bool) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if sslNoVerify {
+		log.Printf("[TRACE] ssl_no_verify provided in the provider configuration.")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	// Trust the extra CAs, on top of the system's.
+	caPEM := []byte(d.Get("ca_cert_pem").(string))
+	caFile := d.Get("ca_cert_file").(string)
+	if caFile == "" && len(caPEM) == 0 {
+		caFile = os.Getenv("DUPLO_CA_CERT_FILE")
+	}
+	if caFile != "" {
+		bytes, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("ca_cert_file: %w", err)
+		}
+		caPEM = bytes
+	}
+	if len(caPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("ca_cert_file/ca_cert_pem: no PEM-encoded certificate was found")
+		}
+		log.Printf("[TRACE] custom CA bundle provided in the provider configuration.")
+		tlsConfig.RootCAs = pool
+	}
+
+	// Present a client certificate for mutual TLS.
+	var cert tls.Certificate
+	var err error
+	if certFile := d.Get("client_cert_file").(string); certFile != "" {
+		cert, err = tls.LoadX509KeyPair(certFile, d.Get("client_key_file").(string))
+	} else if certPEM := d.Get("client_cert_pem").(string); certPEM != "" {
+		cert, err = tls.X509KeyPair([]byte(certPEM), []byte(d.Get("client_key_pem").(string)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("client certificate: %w", err)
+	}
+	if len(cert.Certificate) > 0 {
+		log.Printf("[TRACE] client certificate provided in the provider configuration.")
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	// Use an explicit proxy, or keep honoring the proxy environment variables.
+	proxyURL := d.Get("proxy_url").(string)
+	hosts := []string{}
+	for _, host := range d.Get("no_proxy").([]interface{}) {
+		hosts = append(hosts, host.(string))
+	}
+	if proxyURL != "" || len(hosts) > 0 {
+		config := httpproxy.FromEnvironment()
+		if proxyURL != "" {
+			parsed, err := url.Parse(proxyURL)
+			if err != nil {
+				return nil, fmt.Errorf("proxy_url: %w", err)
+			}
+			config.HTTPProxy, config.HTTPSProxy = proxyURL, proxyURL
+
+			// Never log the proxy credentials.
+			parsed.User = nil
+			log.Printf("[TRACE] proxy_url provided in the provider configuration: %s", parsed.String())
+		}
+		if len(hosts) > 0 {
+			config.NoProxy = strings.Join(hosts, ",")
+			log.Printf("[TRACE] no_proxy provided in the provider configuration: %s", config.NoProxy)
+		}
+		proxyFunc := config.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	return transport, nil
+}
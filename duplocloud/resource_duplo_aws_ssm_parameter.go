@@ -14,7 +14,7 @@ import (
 )
 
 func awsSsmParameterSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	s := map[string]*schema.Schema{
 		"tenant_id": {
 			Description:  "The GUID of the tenant that the SSM parameter will be created in.",
 			Type:         schema.TypeString,
@@ -71,6 +71,9 @@ func awsSsmParameterSchema() map[string]*schema.Schema {
 			Computed: true,
 		},
 	}
+	addWriteOnlyArgument(s, "value")
+	s["value_wo"].Description += "  Only valid for `SecureString` parameters."
+	return s
 }
 
 // Resource for managing an AWS SSM parameter
@@ -90,7 +93,8 @@ func resourceAwsSsmParameter() *schema.Resource {
 			Update: schema.DefaultTimeout(2 * time.Minute),
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
-		Schema: awsSsmParameterSchema(),
+		Schema:        awsSsmParameterSchema(),
+		CustomizeDiff: validateAwsSsmParameterWriteOnly,
 	}
 }
 
@@ -127,7 +131,9 @@ func resourceAwsSsmParameterRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("tenant_id", tenantID)
 	d.Set("name", name)
 	d.Set("type", ssmParam.Type)
-	d.Set("value", ssmParam.Value)
+	if !writeOnlyInUse(d, "value") {
+		d.Set("value", ssmParam.Value)
+	}
 	d.Set("key_id", ssmParam.KeyId)
 	d.Set("description", ssmParam.Description)
 	d.Set("allowed_pattern", ssmParam.AllowedPattern)
@@ -146,11 +152,16 @@ func resourceAwsSsmParameterCreate(ctx context.Context, d *schema.ResourceData,
 	name := d.Get("name").(string)
 	log.Printf("[TRACE] resourceAwsSsmParameterCreate(%s, %s): start", tenantID, name)
 
+	value, err := writeOnlyValue(d, "value")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Create the request object.
 	rq := duplosdk.DuploSsmParameterRequest{
 		Name:           name,
 		Type:           d.Get("type").(string),
-		Value:          d.Get("value").(string),
+		Value:          value,
 		Description:    d.Get("description").(string),
 		KeyId:          d.Get("key_id").(string),
 		AllowedPattern: d.Get("allowed_pattern").(string),
@@ -187,11 +198,16 @@ func resourceAwsSsmParameterUpdate(ctx context.Context, d *schema.ResourceData,
 	name := d.Get("name").(string)
 	log.Printf("[TRACE] resourceAwsSsmParameterUpdate(%s, %s): start", tenantID, name)
 
+	value, err := writeOnlyValue(d, "value")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Create the request object.
 	rq := duplosdk.DuploSsmParameterRequest{
 		Name:        name,
 		Type:        d.Get("type").(string),
-		Value:       d.Get("value").(string),
+		Value:       value,
 		Description: d.Get("description").(string),
 	}
 
@@ -242,6 +258,15 @@ func resourceAwsSsmParameterDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
+// validateAwsSsmParameterWriteOnly only allows write-only values for SecureString parameters, since the others
+// are not secret.
+func validateAwsSsmParameterWriteOnly(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if _, ok := diff.GetOk("value_wo_version"); ok && diff.Get("type").(string) != "SecureString" {
+		return fmt.Errorf("value_wo can only be used with SecureString parameters, use value instead")
+	}
+	return nil
+}
+
 func parseAwsSsmParameterIdParts(id string) (tenantID, name string, err error) {
 	idParts := strings.SplitN(id, "/", 2)
 	if len(idParts) == 2 {
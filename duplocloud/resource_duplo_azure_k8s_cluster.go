@@ -229,7 +229,7 @@ func resourceAzureK8sClusterRead(ctx context.Context, d *schema.ResourceData, m
 	log.Printf("[TRACE] resourceAzureK8sClusterRead(%s): start", name)
 
 	// Get the object from Duplo, detecting a missing object
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	config, err := c.InfrastructureGetConfig(name)
 	if err != nil {
 		if err.Status() == 404 {
@@ -254,7 +254,7 @@ func resourceAzureK8sClusterCreate(ctx context.Context, d *schema.ResourceData,
 
 	infraName := d.Get("infra_name").(string)
 	log.Printf("[TRACE] resourceAzureK8sClusterCreate(%s): start", infraName)
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	rq := expandAzureK8sCluster(d)
 	err = c.AzureK8sClusterCreate(infraName, rq)
 	if err != nil {
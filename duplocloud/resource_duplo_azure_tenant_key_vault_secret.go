@@ -16,7 +16,7 @@ import (
 )
 
 func duploAzureTenantKeyVaultSecretSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	s := map[string]*schema.Schema{
 		"tenant_id": {
 			Description:  "The GUID of the DuploCloud tenant that the key vault secret will be created in.",
 			Type:         schema.TypeString,
@@ -69,6 +69,8 @@ func duploAzureTenantKeyVaultSecretSchema() map[string]*schema.Schema {
 			Computed:    true,
 		},
 	}
+	addWriteOnlyArgument(s, "value")
+	return s
 }
 
 func resourceAzureTenantKeyVaultSecret() *schema.Resource {
@@ -127,7 +129,10 @@ func resourceAzureTenantKeyVaultSecretCreate(ctx context.Context, d *schema.Reso
 	name := d.Get("name").(string)
 	log.Printf("[TRACE] resourceAzureTenantKeyVaultSecretCreate(%s, %s, %s): start", tenantID, vaultName, name)
 	c := m.(*duplosdk.Client)
-	rq := expandAzureTenantKeyVaultSecret(d)
+	rq, err := expandAzureTenantKeyVaultSecret(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = c.TenantKeyVaultSecretCreate(tenantID, rq)
 	if err != nil {
 		return diag.Errorf("Error creating tenant %s azure key vault secret '%s', '%s': %s", tenantID, vaultName, name, err)
@@ -154,13 +159,16 @@ func resourceAzureTenantKeyVaultSecretUpdate(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	rq := expandAzureTenantKeyVaultSecret(d)
+	rq, err := expandAzureTenantKeyVaultSecret(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	rq.SecretName = name
 	c := m.(*duplosdk.Client)
 
 	cerr := c.TenantKeyVaultSecretUpdate(tenantID, rq)
 	if cerr != nil {
-		diag.FromErr(err)
+		return diag.FromErr(cerr)
 	}
 
 	return resourceAzureTenantKeyVaultSecretRead(ctx, d, m)
@@ -195,16 +203,20 @@ func resourceAzureTenantKeyVaultSecretDelete(ctx context.Context, d *schema.Reso
 	return nil
 }
 
-func expandAzureTenantKeyVaultSecret(d *schema.ResourceData) *duplosdk.DuploAzureTenantKeyVaultSecretRequest {
+func expandAzureTenantKeyVaultSecret(d *schema.ResourceData) (*duplosdk.DuploAzureTenantKeyVaultSecretRequest, error) {
+	value, err := writeOnlyValue(d, "value")
+	if err != nil {
+		return nil, err
+	}
 	request := duplosdk.DuploAzureTenantKeyVaultSecretRequest{
 		VaultName:   d.Get("vault_name").(string),
 		SecretName:  d.Get("name").(string),
-		SecretValue: d.Get("value").(string),
+		SecretValue: value,
 	}
 	if v, ok := d.GetOk("content_type"); ok && v != nil && v.(string) != "" {
 		request.ContentType = v.(string)
 	}
-	return &request
+	return &request, nil
 }
 
 func parseAzureTenantKeyVaultSecretIdParts(id string) (tenantID, vaultName, name string, err error) {
@@ -221,7 +233,9 @@ func flattenAzureTenantKeyVaultSecret(tenantID string, d *schema.ResourceData, d
 	d.Set("tenant_id", tenantID)
 	d.Set("name", duplo.SecretIdentifier.Name)
 	d.Set("vault_name", getVaultName(duplo.SecretIdentifier.Vault))
-	d.Set("value", duplo.Value)
+	if !writeOnlyInUse(d, "value") {
+		d.Set("value", duplo.Value)
+	}
 	if len(duplo.ContentType) > 0 {
 		d.Set("content_type", duplo.ContentType)
 	}
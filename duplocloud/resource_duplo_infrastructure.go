@@ -317,6 +317,7 @@ func resourceInfrastructure() *schema.Resource {
 				Computed:    true,
 				Elem:        infrastructureVnetSecurityGroupsSchema(),
 			},
+			"deletion_protection": deletionProtectionSchema("infrastructure"),
 			"wait_until_deleted": {
 				Description:      "Whether or not to wait until Duplo has destroyed the infrastructure.",
 				Type:             schema.TypeBool,
@@ -353,7 +354,7 @@ func resourceInfrastructureRead(ctx context.Context, d *schema.ResourceData, m i
 	log.Printf("[TRACE] resourceInfrastructureRead(%s): start", name)
 
 	// Get the object from Duplo, detecting a missing object
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	missing, err := infrastructureRead(ctx, c, d, name)
 	if err != nil && err.Status() != 404 {
 		return diag.Errorf("Duplocloud resource '%s'\n%s", id, err)
@@ -384,7 +385,7 @@ func resourceInfrastructureCreate(ctx context.Context, d *schema.ResourceData, m
 	// Post the object to Duplo.
 	id := fmt.Sprintf("v2/admin/InfrastructureV2/%s", rq.Name)
 
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	err = c.InfrastructureCreate(rq)
 	if err != nil {
 		return diag.Errorf("Duplocloud resource '%s'\n%s", id, err)
@@ -419,7 +420,7 @@ func resourceInfrastructureUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	log.Printf("[TRACE] resourceInfrastructureUpdate(%s): start", infraName)
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 
 	// Apply any ECS changes.
 	if d.HasChanges("enable_ecs_cluster", "enable_container_insights") {
@@ -488,7 +489,12 @@ func resourceInfrastructureDelete(ctx context.Context, d *schema.ResourceData, m
 
 	log.Printf("[TRACE] resourceInfrastructureDelete(%s): start", infraName)
 
-	c := m.(*duplosdk.Client)
+	diags = checkDeletionProtection(d, m, "duplocloud_infrastructure", infraName)
+	if diags != nil {
+		return diags
+	}
+
+	c := m.(*duplosdk.Client).WithoutCache()
 	err := c.InfrastructureDelete(infraName)
 	if err != nil {
 		if err.Status() == 404 {
@@ -175,7 +175,7 @@ func resourceInfrastructureOnpremRead(ctx context.Context, d *schema.ResourceDat
 	log.Printf("[TRACE] resourceInfrastructureOnpremRead(%s): start", name)
 
 	// Get the object from Duplo, detecting a missing object
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	config, err := c.InfrastructureGetConfig(name)
 	if err != nil {
 		if err.Status() == 404 {
@@ -203,7 +203,7 @@ func resourceInfrastructureOnpremCreate(ctx context.Context, d *schema.ResourceD
 	log.Printf("[TRACE] resourceInfrastructureOnpremCreate(%s): start", rq.Name)
 
 	// Post the object to Duplo.
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	err = c.InfrastructureCreate(rq)
 	if err != nil {
 		return diag.FromErr(err)
@@ -240,7 +240,7 @@ func resourceInfrastructureOnpremDelete(ctx context.Context, d *schema.ResourceD
 	infraName := idParts[4]
 	log.Printf("[TRACE] resourceInfrastructureOnpremDelete(%s): start", infraName)
 
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	err := c.InfrastructureDelete(infraName)
 	if err != nil {
 		if err.Status() == 404 {
@@ -74,6 +74,9 @@ func k8sSecretSchema() map[string]*schema.Schema {
 
 // SCHEMA for resource crud
 func resourceK8Secret() *schema.Resource {
+	s := k8sSecretSchema()
+	addWriteOnlyArgument(s, "secret_data")
+
 	return &schema.Resource{
 		Description: "`duplocloud_k8_secret` manages a kubernetes secret in a Duplo tenant.",
 
@@ -89,7 +92,7 @@ func resourceK8Secret() *schema.Resource {
 			Update: schema.DefaultTimeout(15 * time.Minute),
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
-		Schema: k8sSecretSchema(),
+		Schema: s,
 	}
 }
 
@@ -115,6 +118,9 @@ func resourceK8SecretRead(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	flattenK8sSecret(d, rp, false)
+	if writeOnlyInUse(d, "secret_data") {
+		d.Set("secret_data", nil)
+	}
 	log.Printf("[TRACE] resourceK8SecretRead(%s, %s): end", tenantId, name)
 	return nil
 }
@@ -283,14 +289,21 @@ func expandK8sSecret(d *schema.ResourceData) (*duplosdk.DuploK8sSecret, error) {
 	}
 
 	// The data must be decoded as JSON.
-	data := d.Get("secret_data").(string)
+	data, err := writeOnlyValue(d, "secret_data")
+	if err != nil {
+		return nil, err
+	}
 	if data != "" {
 		err := json.Unmarshal([]byte(data), &duplo.SecretData)
 		if err != nil {
 			return nil, err
 		}
 	}
-	d.Set("client_secret_version", hashForData(data))
+	if writeOnlyInUse(d, "secret_data") {
+		d.Set("client_secret_version", "")
+	} else {
+		d.Set("client_secret_version", hashForData(data))
+	}
 	if duplo.SecretData == nil {
 		duplo.SecretData = map[string]interface{}{}
 	}
@@ -0,0 +1,271 @@
+package duplocloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// The container agent that Duplo uses to run services on Kubernetes.
+const k8sWorkloadAgentPlatform = 7
+
+func resourceKubernetesDeploymentV1() *schema.Resource {
+	return &schema.Resource{
+		Description: "`duplocloud_k8s_deployment` manages a Kubernetes deployment in a Duplo tenant, as a Duplo service.  " +
+			"Unlike `duplocloud_duplo_service`, the pod template is typed like an `apps/v1` Deployment, " +
+			"so that it is validated when planning and its changes are shown field by field.",
+		CreateContext: resourceKubernetesDeploymentV1Create,
+		ReadContext:   resourceKubernetesDeploymentV1Read,
+		UpdateContext: resourceKubernetesDeploymentV1Update,
+		DeleteContext: resourceKubernetesDeploymentV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: validateK8sWorkloadMainContainer,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the deployment will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("deployment", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec of the deployment.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: deploymentSpecFields(),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesDeploymentV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesDeploymentV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	update, err := expandKubernetesDeploymentV1(d, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rq := duplosdk.DuploReplicationControllerCreateRequest{
+		Name:              name,
+		Image:             update.Image,
+		AgentPlatform:     update.AgentPlatform,
+		Replicas:          update.Replicas,
+		OtherDockerConfig: update.OtherDockerConfig,
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.ReplicationControllerCreate(tenantId, &rq); err != nil {
+		return diag.Errorf("Failed to create deployment. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new deployment %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/deployment/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s deployment", id, func() (interface{}, duplosdk.ClientError) {
+		return c.ReplicationControllerGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesDeploymentV1Create(%s): end", tenantId)
+	return resourceKubernetesDeploymentV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesDeploymentV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "deployment")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading deployment %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	rc, cerr := c.ReplicationControllerGet(tenantId, name)
+	if cerr != nil {
+		if errors.Is(cerr, duplosdk.ErrNotFound) {
+			log.Printf("[TRACE] resourceKubernetesDeploymentV1Read(%s, %s): object not found", tenantId, name)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Failed to read deployment. API error: %s", cerr)
+	}
+	if rc == nil || rc.Template == nil {
+		d.SetId("")
+		return nil
+	}
+
+	metadata, template, defn, err := flattenK8sWorkloadOtherDockerConfig(name, k8sWorkloadImage(rc), rc.Template.OtherDockerConfig)
+	if err != nil {
+		return diag.Errorf("Failed to read the pod template of deployment '%s': %s", name, err)
+	}
+	spec := appsv1.DeploymentSpec{
+		Replicas: ptrToInt32(int32(rc.Replicas)),
+		Template: template,
+	}
+	if v, ok := defn["DeploymentStrategy"]; ok {
+		if err := convertK8sObject(v, &spec.Strategy); err != nil {
+			return diag.Errorf("Failed to read the strategy of deployment '%s': %s", name, err)
+		}
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+
+	specOut, specErr := flattenDeploymentSpec(spec, d, meta)
+	if specErr != nil {
+		return diag.FromErr(specErr)
+	}
+	if err := d.Set("spec", specOut); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesDeploymentV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesDeploymentV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq, err := expandKubernetesDeploymentV1(d, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.ReplicationControllerUpdate(tenantId, rq); err != nil {
+		return diag.Errorf("Failed to update deployment. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated deployment %s/%s", tenantId, name)
+
+	diags := resourceKubernetesDeploymentV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesDeploymentV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesDeploymentV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteK8sWorkload(ctx, d, meta, "deployment")
+}
+
+func expandKubernetesDeploymentV1(d *schema.ResourceData, name string) (*duplosdk.DuploReplicationControllerUpdateRequest, error) {
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a strategy, Duplo uses the default one of Kubernetes.
+	extra := map[string]interface{}{}
+	if spec.Strategy.Type != "" {
+		extra["DeploymentStrategy"] = spec.Strategy
+	}
+	image, config, err := expandK8sWorkloadOtherDockerConfig(metadata, spec.Template, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	rq := &duplosdk.DuploReplicationControllerUpdateRequest{
+		Name:              name,
+		Image:             image,
+		AgentPlatform:     k8sWorkloadAgentPlatform,
+		OtherDockerConfig: config,
+	}
+	if spec.Replicas != nil {
+		rq.Replicas = int(*spec.Replicas)
+	}
+	return rq, nil
+}
+
+// deleteK8sWorkload deletes the Duplo service that runs a Kubernetes workload, and waits for it to be gone.
+func deleteK8sWorkload(ctx context.Context, d *schema.ResourceData, meta interface{}, kind string) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), kind)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] deleteK8sWorkload(%s, %s, %s): start", kind, tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	existing, cerr := c.ReplicationControllerGet(tenantId, name)
+	if cerr != nil {
+		if errors.Is(cerr, duplosdk.ErrNotFound) {
+			return nil
+		}
+		return diag.FromErr(cerr)
+	}
+	if existing != nil {
+		rq := duplosdk.DuploReplicationControllerDeleteRequest{
+			Name:          name,
+			AgentPlatform: k8sWorkloadAgentPlatform,
+			Image:         k8sWorkloadImage(existing),
+		}
+		cerr := c.ReplicationControllerDelete(tenantId, &rq)
+		if cerr != nil && (cerr.Status() == 400 || errors.Is(cerr, duplosdk.ErrNotFound)) {
+			cerr = c.ReplicationControllerDeleteFallback(tenantId, &rq)
+		}
+		if cerr != nil && !errors.Is(cerr, duplosdk.ErrNotFound) {
+			return diag.Errorf("Failed to delete %s. API error: %s", kind, cerr)
+		}
+
+		diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s "+kind, d.Id(), func() (interface{}, duplosdk.ClientError) {
+			return c.ReplicationControllerGet(tenantId, name)
+		})
+		if diags != nil {
+			return diags
+		}
+	}
+
+	log.Printf("[TRACE] deleteK8sWorkload(%s, %s, %s): end", kind, tenantId, name)
+	return nil
+}
+
+// validateK8sWorkloadMainContainer checks that the main container is named after the workload, because Duplo
+// names the main container of a service after the service.
+func validateK8sWorkloadMainContainer(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	name := diff.Get("metadata.0.name").(string)
+	container := diff.Get("spec.0.template.0.spec.0.container.0.name").(string)
+	if name != "" && container != "" && name != container {
+		return fmt.Errorf("the first container must have the same name as the workload, '%s', instead of '%s'", name, container)
+	}
+	return nil
+}
+
+func k8sWorkloadImage(rc *duplosdk.DuploReplicationController) string {
+	if rc.Template != nil && rc.Template.Containers != nil && len(*rc.Template.Containers) > 0 {
+		return (*rc.Template.Containers)[0].Image
+	}
+	return ""
+}
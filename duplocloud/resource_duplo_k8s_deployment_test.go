@@ -0,0 +1,204 @@
+package duplocloud
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestK8sWorkloadOtherDockerConfig(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "web", Labels: map[string]string{"team": "a"}}
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"sidecar": "off"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "web",
+					Image: "nginx:1.27",
+					Env:   []corev1.EnvVar{{Name: "MODE", Value: "prod"}},
+					SecurityContext: &corev1.SecurityContext{
+						ReadOnlyRootFilesystem: ptrToBool(true),
+					},
+				},
+				{Name: "proxy", Image: "envoy:v1"},
+			},
+			RestartPolicy:      corev1.RestartPolicyAlways,
+			ServiceAccountName: "web",
+			NodeSelector:       map[string]string{"disk": "ssd"},
+			SecurityContext:    &corev1.PodSecurityContext{RunAsUser: ptrToInt64(1000)},
+		},
+	}
+
+	image, config, err := expandK8sWorkloadOtherDockerConfig(meta, template, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.27", image)
+
+	defn := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(config), &defn))
+	for _, key := range []string{"Env", "SecurityContext", "PodSecurityContext", "ServiceAccountName", "NodeSelector", "AdditionalContainers", "DeploymentLabels", "PodAnnotations"} {
+		assert.Contains(t, defn, key)
+	}
+	assert.NotContains(t, defn, "Name")
+	assert.NotContains(t, defn, "Image")
+
+	flatMeta, flatTemplate, _, err := flattenK8sWorkloadOtherDockerConfig("web", image, config)
+	require.NoError(t, err)
+	assert.Equal(t, meta, flatMeta)
+	assert.Equal(t, template, flatTemplate)
+}
+
+func TestEmulator_K8sDeployment(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesDeploymentV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "web",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"replicas": 2,
+			"template": []interface{}{map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{
+					"labels": map[string]interface{}{"tier": "frontend"},
+				}},
+				"spec": []interface{}{map[string]interface{}{
+					"container": []interface{}{map[string]interface{}{
+						"name":  "web",
+						"image": "nginx:1.27",
+						"env": []interface{}{map[string]interface{}{
+							"name":  "MODE",
+							"value": "prod",
+						}},
+					}},
+				}},
+			}},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/deployment/web", d.Id())
+
+	rc, err := c.ReplicationControllerGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	require.NotNil(t, rc)
+	assert.Equal(t, 2, rc.Replicas)
+	assert.Equal(t, "nginx:1.27", k8sWorkloadImage(rc))
+	assert.Contains(t, rc.Template.OtherDockerConfig, `"PodLabels":{"tier":"frontend"}`)
+	assert.NotContains(t, rc.Template.OtherDockerConfig, "DeploymentStrategy")
+
+	assert.Equal(t, "nginx:1.27", d.Get("spec.0.template.0.spec.0.container.0.image"))
+	assert.Equal(t, "prod", d.Get("spec.0.template.0.spec.0.container.0.env.0.value"))
+	assert.Equal(t, "frontend", d.Get("spec.0.template.0.metadata.0.labels.tier"))
+	assert.Equal(t, "RollingUpdate", d.Get("spec.0.strategy.0.type"))
+	assert.Equal(t, "25%", d.Get("spec.0.strategy.0.rolling_update.0.max_surge"))
+
+	strategy := expandDeploymentStrategy([]interface{}{map[string]interface{}{
+		"type":           "Recreate",
+		"rolling_update": []interface{}{map[string]interface{}{"max_surge": "25%", "max_unavailable": "25%"}},
+	}})
+	assert.Equal(t, "Recreate", string(strategy.Type))
+	assert.Nil(t, strategy.RollingUpdate)
+
+	// Scaling to zero replicas must send the zero to Duplo.
+	_, errs := k8sWorkloadReplicasSchema("deployment").ValidateFunc(0, "replicas")
+	assert.Empty(t, errs)
+	spec := d.Get("spec").([]interface{})
+	spec[0].(map[string]interface{})["replicas"] = 0
+	require.NoError(t, d.Set("spec", spec))
+	rq, eerr := expandKubernetesDeploymentV1(d, "web")
+	require.NoError(t, eerr)
+	body, jerr := json.Marshal(rq)
+	require.NoError(t, jerr)
+	assert.Contains(t, string(body), `"Replicas":0`)
+	diags = r.UpdateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	rc, err = c.ReplicationControllerGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	assert.Equal(t, 0, rc.Replicas)
+
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	rc, err = c.ReplicationControllerGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	assert.Nil(t, rc)
+}
+
+func TestEmulator_K8sStatefulSet(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesStatefulSetV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "db",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"template": []interface{}{map[string]interface{}{
+				"spec": []interface{}{map[string]interface{}{
+					"container": []interface{}{map[string]interface{}{
+						"name":  "db",
+						"image": "postgres:16",
+						"volume_mount": []interface{}{map[string]interface{}{
+							"name":       "data",
+							"mount_path": "/var/lib/postgresql/data",
+						}},
+					}},
+				}},
+			}},
+			"volume_claim_template": []interface{}{map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{
+					"name": "data",
+				}},
+				"spec": []interface{}{map[string]interface{}{
+					"access_modes": []interface{}{"ReadWriteOnce"},
+					"resources": []interface{}{map[string]interface{}{
+						"requests": map[string]interface{}{"storage": "10Gi"},
+					}},
+				}},
+			}},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	rc, err := c.ReplicationControllerGet(Tenant_testacc1a, "db")
+	require.Nil(t, err)
+	require.NotNil(t, rc)
+	assert.True(t, rc.ForceStatefulSet)
+	assert.JSONEq(t, `[{"Name":"data","Path":"/var/lib/postgresql/data","AccessMode":"ReadWriteOnce","Size":"10Gi"}]`, rc.Template.Volumes)
+	assert.NotContains(t, rc.Template.OtherDockerConfig, "VolumeMounts")
+
+	assert.Equal(t, "data", d.Get("spec.0.template.0.spec.0.container.0.volume_mount.0.name"))
+	assert.Equal(t, "/var/lib/postgresql/data", d.Get("spec.0.template.0.spec.0.container.0.volume_mount.0.mount_path"))
+	assert.Equal(t, "10Gi", d.Get("spec.0.volume_claim_template.0.spec.0.resources.0.requests.storage"))
+
+	_, err2 := expandKubernetesStatefulSetV1(schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "db"}},
+		"spec": []interface{}{map[string]interface{}{
+			"template": []interface{}{map[string]interface{}{
+				"spec": []interface{}{map[string]interface{}{
+					"container": []interface{}{map[string]interface{}{"name": "db", "image": "postgres:16"}},
+				}},
+			}},
+			"volume_claim_template": []interface{}{map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{"name": "data"}},
+				"spec": []interface{}{map[string]interface{}{
+					"access_modes": []interface{}{"ReadWriteOnce"},
+					"resources": []interface{}{map[string]interface{}{
+						"requests": map[string]interface{}{"storage": "10Gi"},
+					}},
+				}},
+			}},
+		}},
+	}), "db")
+	assert.ErrorContains(t, err2, "must be mounted by the first container")
+}
@@ -0,0 +1,186 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKubernetesHorizontalPodAutoscalerV2() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`duplocloud_k8s_horizontal_pod_autoscaler` manages a Kubernetes `autoscaling/v2` horizontal pod autoscaler in a Duplo tenant.",
+		CreateContext: resourceKubernetesHorizontalPodAutoscalerV2Create,
+		ReadContext:   resourceKubernetesHorizontalPodAutoscalerV2Read,
+		UpdateContext: resourceKubernetesHorizontalPodAutoscalerV2Update,
+		DeleteContext: resourceKubernetesHorizontalPodAutoscalerV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the horizontal pod autoscaler will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("horizontal pod autoscaler", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec of the horizontal pod autoscaler.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: horizontalPodAutoscalerSpecFields(),
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "The most recently observed status of the horizontal pod autoscaler.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: horizontalPodAutoscalerStatusFields(),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesHorizontalPodAutoscalerV2Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec, err := expandHorizontalPodAutoscalerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sHorizontalPodAutoscaler{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     spec,
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sHorizontalPodAutoscalerCreate(&rq); err != nil {
+		return diag.Errorf("Failed to create horizontal pod autoscaler. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new horizontal pod autoscaler %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/horizontalPodAutoscaler/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s horizontal pod autoscaler", id, func() (interface{}, duplosdk.ClientError) {
+		return c.K8sHorizontalPodAutoscalerGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesHorizontalPodAutoscalerV2Create(%s): end", tenantId)
+	return resourceKubernetesHorizontalPodAutoscalerV2Read(ctx, d, meta)
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "horizontalPodAutoscaler")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading horizontal pod autoscaler %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	hpa, cerr := c.K8sHorizontalPodAutoscalerGet(tenantId, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read horizontal pod autoscaler. API error: %s", cerr)
+	}
+	if hpa == nil {
+		log.Printf("[TRACE] resourceKubernetesHorizontalPodAutoscalerV2Read(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(hpa.Metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+	if err := d.Set("spec", flattenHorizontalPodAutoscalerSpec(hpa.Spec)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", flattenHorizontalPodAutoscalerStatus(hpa.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesHorizontalPodAutoscalerV2Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec, err := expandHorizontalPodAutoscalerSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sHorizontalPodAutoscaler{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     spec,
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sHorizontalPodAutoscalerUpdate(tenantId, name, &rq); err != nil {
+		return diag.Errorf("Failed to update horizontal pod autoscaler. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated horizontal pod autoscaler %s/%s", tenantId, name)
+
+	diags := resourceKubernetesHorizontalPodAutoscalerV2Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesHorizontalPodAutoscalerV2Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "horizontalPodAutoscaler")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesHorizontalPodAutoscalerV2Delete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sHorizontalPodAutoscalerDelete(tenantId, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete horizontal pod autoscaler. API error: %s", cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s horizontal pod autoscaler", d.Id(), func() (interface{}, duplosdk.ClientError) {
+		return c.K8sHorizontalPodAutoscalerGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesHorizontalPodAutoscalerV2Delete(%s, %s): end", tenantId, name)
+	return nil
+}
@@ -0,0 +1,388 @@
+package duplocloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The field manager that owns the fields applied by this provider, unless configured otherwise.
+const k8sManifestDefaultFieldManager = "duplocloud-terraform"
+
+func resourceKubernetesManifest() *schema.Resource {
+	return &schema.Resource{
+		Description: "`duplocloud_k8s_manifest` manages an arbitrary Kubernetes object, such as an instance of a CRD, in the namespace of a Duplo tenant.  " +
+			"The object is applied with server-side apply, and only the fields that are owned by the resource's field manager are compared with the configuration, " +
+			"so fields that are set by controllers or defaulted by Kubernetes never show as a diff.  " +
+			"Duplo has no API for arbitrary objects, so they are managed through the Kubernetes API server, with the tenant's just-in-time Kubernetes credentials: " +
+			"the tenant's Kubernetes role must allow the object's kind.",
+		CreateContext: resourceKubernetesManifestCreate,
+		ReadContext:   resourceKubernetesManifestRead,
+		UpdateContext: resourceKubernetesManifestUpdate,
+		DeleteContext: resourceKubernetesManifestDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKubernetesManifestImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the object will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"manifest": {
+				Description: "The object, as a YAML or JSON document.  It must have an `apiVersion`, a `kind` and a `metadata.name`.  " +
+					"`metadata.namespace` can be omitted, because the object is always created in the tenant's namespace.  " +
+					"Changing the `apiVersion`, the `kind` or the name of the object forces a new resource.",
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validateK8sManifest,
+				StateFunc: func(v interface{}) string {
+					return canonicalizeK8sManifest(v.(string))
+				},
+			},
+			"field_manager": {
+				Description: "The name of the field manager that owns the fields of the object that are applied by this resource.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     k8sManifestDefaultFieldManager,
+			},
+			"force_conflicts": {
+				Description: "When true, the ownership of fields that are owned by other field managers is taken over. " +
+					"Otherwise, applying a field that is owned by another field manager fails.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for": {
+				Description: "Conditions to wait for after the object is applied.  The wait is bounded by the create and update timeouts.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"condition": {
+							Description: "A condition of the object's `status.conditions` that must be met.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Description: "The type of the condition, such as `Ready`.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"status": {
+										Description: "The expected status of the condition.",
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "True",
+									},
+								},
+							},
+						},
+						"fields": {
+							Description: "A map of fields of the object, such as `status.phase`, to regular expressions that their values must match.  " +
+								"List items are selected by their index, such as `status.containerStatuses.0.ready`.",
+							Type:             schema.TypeMap,
+							Optional:         true,
+							Elem:             &schema.Schema{Type: schema.TypeString},
+							ValidateDiagFunc: validateK8sManifestWaitForFields,
+						},
+					},
+				},
+			},
+			"object": {
+				Description: "The live object as JSON, including the fields that are not managed by this resource, such as `status`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIfChange("manifest", func(ctx context.Context, old, new, meta interface{}) bool {
+				return k8sManifestIdentityChanged(old.(string), new.(string))
+			}),
+			customdiff.ComputedIf("object", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("manifest")
+			}),
+		),
+	}
+}
+
+func resourceKubernetesManifestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesManifestCreate(%s): start", tenantId)
+
+	c := meta.(*duplosdk.Client)
+	rq, err := expandKubernetesManifest(c, d, tenantId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := c.K8sManifestApply(tenantId, rq, d.Get("field_manager").(string), d.Get("force_conflicts").(bool)); err != nil {
+		return diag.Errorf("Failed to apply %s %s. API error: %s", rq.Kind(), rq.Name(), err)
+	}
+	log.Printf("[INFO] Applied new %s %s/%s", rq.Kind(), tenantId, rq.Name())
+
+	id := fmt.Sprintf("%s/%s/%s/%s", tenantId, rq.ApiVersion(), rq.Kind(), rq.Name())
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s manifest", id, kubernetesManifestGetter(c, tenantId, rq.ApiVersion(), rq.Kind(), rq.Name()))
+	if diags != nil {
+		return diags
+	}
+	if err := waitForKubernetesManifest(ctx, d, c, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[TRACE] resourceKubernetesManifestCreate(%s): end", tenantId)
+	return resourceKubernetesManifestRead(ctx, d, meta)
+}
+
+func resourceKubernetesManifestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, apiVersion, kind, name, err := parseKubernetesManifestIdParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading %s %s/%s", kind, tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	obj, cerr := c.K8sManifestGet(tenantId, apiVersion, kind, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read %s %s. API error: %s", kind, name, cerr)
+	}
+	if obj == nil {
+		log.Printf("[TRACE] resourceKubernetesManifestRead(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	// The previously applied manifest is only used when the API does not report the managed fields.
+	var previous duplosdk.DuploK8sManifest
+	if v := d.Get("manifest").(string); v != "" {
+		previous, _ = expandK8sManifest(v)
+	}
+
+	manifest, err := json.Marshal(flattenK8sManifest(obj, d.Get("field_manager").(string), previous))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	live := duplosdk.DuploK8sManifest{}
+	for k, v := range obj {
+		live[k] = v
+	}
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		liveMetadata := map[string]interface{}{}
+		for k, v := range metadata {
+			if k != "managedFields" {
+				liveMetadata[k] = v
+			}
+		}
+		live["metadata"] = liveMetadata
+	}
+	object, err := json.Marshal(live)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("tenant_id", tenantId)
+	d.Set("manifest", canonicalizeK8sManifest(string(manifest)))
+	d.Set("object", string(object))
+
+	return nil
+}
+
+func resourceKubernetesManifestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesManifestUpdate(%s): start", tenantId)
+
+	c := meta.(*duplosdk.Client)
+	rq, err := expandKubernetesManifest(c, d, tenantId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := c.K8sManifestApply(tenantId, rq, d.Get("field_manager").(string), d.Get("force_conflicts").(bool)); err != nil {
+		return diag.Errorf("Failed to apply %s %s. API error: %s", rq.Kind(), rq.Name(), err)
+	}
+	log.Printf("[INFO] Applied updated %s %s/%s", rq.Kind(), tenantId, rq.Name())
+
+	if err := waitForKubernetesManifest(ctx, d, c, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := resourceKubernetesManifestRead(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesManifestUpdate(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesManifestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, apiVersion, kind, name, err := parseKubernetesManifestIdParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesManifestDelete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sManifestDelete(tenantId, apiVersion, kind, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete %s %s. API error: %s", kind, name, cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s manifest", d.Id(), kubernetesManifestGetter(c, tenantId, apiVersion, kind, name))
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesManifestDelete(%s, %s): end", tenantId, name)
+	return nil
+}
+
+// kubernetesManifestGetter returns a getter for the wait helpers, which only treat nil pointers - not nil maps - as missing objects.
+func kubernetesManifestGetter(c *duplosdk.Client, tenantId, apiVersion, kind, name string) func() (interface{}, duplosdk.ClientError) {
+	return func() (interface{}, duplosdk.ClientError) {
+		obj, err := c.K8sManifestGet(tenantId, apiVersion, kind, name)
+		if obj == nil {
+			return nil, err
+		}
+		return obj, err
+	}
+}
+
+func resourceKubernetesManifestImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, _, _, err := parseKubernetesManifestIdParts(d.Id()); err != nil {
+		return nil, err
+	}
+	d.Set("field_manager", k8sManifestDefaultFieldManager)
+	d.Set("force_conflicts", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandKubernetesManifest(c *duplosdk.Client, d *schema.ResourceData, tenantId string) (duplosdk.DuploK8sManifest, error) {
+	rq, err := expandK8sManifest(d.Get("manifest").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects can only be managed in the tenant's namespace.
+	metadata := rq["metadata"].(map[string]interface{})
+	if namespace, ok := metadata["namespace"].(string); ok && namespace != "" {
+		tenantNamespace, err := c.GetDuploServicesPrefix(tenantId, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the namespace of tenant %s: %s", tenantId, err)
+		}
+		if namespace != tenantNamespace {
+			return nil, fmt.Errorf("the namespace of the manifest must be omitted or be %s, got %s", tenantNamespace, namespace)
+		}
+	}
+	return rq, nil
+}
+
+// parseKubernetesManifestIdParts parses an ID of the form TENANT_ID/API_VERSION/KIND/NAME, where API_VERSION may include a group.
+func parseKubernetesManifestIdParts(id string) (tenantId, apiVersion, kind, name string, err error) {
+	idParts := strings.Split(id, "/")
+	switch len(idParts) {
+	case 4:
+		tenantId, apiVersion, kind, name = idParts[0], idParts[1], idParts[2], idParts[3]
+	case 5:
+		tenantId, apiVersion, kind, name = idParts[0], idParts[1]+"/"+idParts[2], idParts[3], idParts[4]
+	default:
+		err = fmt.Errorf("invalid resource ID: %s, expected TENANT_ID/API_VERSION/KIND/NAME", id)
+	}
+	return
+}
+
+func k8sManifestIdentityChanged(old, new string) bool {
+	if old == "" || new == "" {
+		return false
+	}
+	o, err := expandK8sManifest(old)
+	if err != nil {
+		return false
+	}
+	n, err := expandK8sManifest(new)
+	if err != nil {
+		return false
+	}
+	return o.ApiVersion() != n.ApiVersion() || o.Kind() != n.Kind() || o.Name() != n.Name()
+}
+
+func validateK8sManifestWaitForFields(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for k, re := range v.(map[string]interface{}) {
+		if _, err := regexp.Compile(re.(string)); err != nil {
+			diags = append(diags, diag.Errorf("invalid regular expression for field %s: %s", k, err)...)
+		}
+	}
+	return diags
+}
+
+// waitForKubernetesManifest waits until the conditions of the wait_for block are met, if any.
+func waitForKubernetesManifest(ctx context.Context, d *schema.ResourceData, c *duplosdk.Client, timeout time.Duration) error {
+	waitFor, ok := d.Get("wait_for").([]interface{})
+	if !ok || len(waitFor) == 0 || waitFor[0] == nil {
+		return nil
+	}
+	in := waitFor[0].(map[string]interface{})
+	conditions := in["condition"].([]interface{})
+	fields := in["fields"].(map[string]interface{})
+
+	tenantId, apiVersion, kind, name, err := parseKubernetesManifestIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		obj, cerr := c.K8sManifestGet(tenantId, apiVersion, kind, name)
+		if cerr != nil {
+			return retry.NonRetryableError(fmt.Errorf("error getting %s %s: %s", kind, name, cerr))
+		}
+		if obj == nil {
+			return retry.RetryableError(fmt.Errorf("expected %s %s to be retrieved, but got: nil", kind, name))
+		}
+
+		for _, v := range conditions {
+			condition := v.(map[string]interface{})
+			if !k8sManifestHasCondition(obj, condition["type"].(string), condition["status"].(string)) {
+				return retry.RetryableError(fmt.Errorf("waiting for %s %s to have condition %s=%s", kind, name, condition["type"], condition["status"]))
+			}
+		}
+		for path, re := range fields {
+			value, ok := k8sManifestLookupField(map[string]interface{}(obj), path)
+			if !ok || !regexp.MustCompile(re.(string)).MatchString(value) {
+				return retry.RetryableError(fmt.Errorf("waiting for field %s of %s %s to match %s, got %q", path, kind, name, re, value))
+			}
+		}
+		return nil
+	})
+}
+
+func k8sManifestHasCondition(obj duplosdk.DuploK8sManifest, conditionType, status string) bool {
+	s, _ := obj["status"].(map[string]interface{})
+	conditions, _ := s["conditions"].([]interface{})
+	for _, v := range conditions {
+		condition, ok := v.(map[string]interface{})
+		if ok && condition["type"] == conditionType && condition["status"] == status {
+			return true
+		}
+	}
+	return false
+}
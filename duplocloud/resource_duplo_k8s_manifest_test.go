@@ -0,0 +1,193 @@
+package duplocloud
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+	"github.com/duplocloud/terraform-provider-duplocloud/internal/duplosdktest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testK8sManifestYaml = `
+apiVersion: secrets-store.csi.x-k8s.io/v1
+kind: SecretProviderClass
+metadata:
+  name: app-secrets
+  labels:
+    app: web
+spec:
+  provider: aws
+  parameters:
+    objects: |
+      - objectName: "app-secrets"
+        objectType: "secretsmanager"
+`
+
+func TestEmulator_K8sManifest(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	// The emulator also serves the Kubernetes API of the tenant.
+	creds := duplosdk.DuploTenantK8sCredentials{}
+	duplosdktest.PatchFixture("tenant/"+Tenant_testacc1a+"/k8s_credentials", &creds, func() {
+		creds.APIServer = testAccEmulator.URL
+		creds.CertificateAuthorityDataBase64 = ""
+	})
+
+	r := resourceKubernetesManifest()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"manifest":  testK8sManifestYaml,
+		"wait_for": []interface{}{map[string]interface{}{
+			"fields": map[string]interface{}{"spec.provider": "^aws$"},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, Tenant_testacc1a+"/secrets-store.csi.x-k8s.io/v1/SecretProviderClass/app-secrets", d.Id())
+	applied := d.Get("manifest").(string)
+	assert.Equal(t, canonicalizeK8sManifest(testK8sManifestYaml), applied)
+
+	obj, err := c.K8sManifestGet(Tenant_testacc1a, "secrets-store.csi.x-k8s.io/v1", "SecretProviderClass", "app-secrets")
+	require.Nil(t, err)
+	require.NotNil(t, obj)
+	assert.Equal(t, "aws", obj["spec"].(map[string]interface{})["provider"])
+
+	// Fields that are set by the server do not show as a diff, but are part of the live object.
+	location := "k8s/namespaces/" + creds.DefaultNamespace + "/secretproviderclasses/app-secrets"
+	live := duplosdk.DuploK8sManifest{}
+	duplosdktest.PatchFixture(location, &live, func() {
+		live["metadata"].(map[string]interface{})["uid"] = "8d1c9a6e"
+		live["status"] = map[string]interface{}{"byPod": []interface{}{}}
+	})
+	diags = r.ReadContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, applied, d.Get("manifest"))
+	assert.Contains(t, d.Get("object"), `"uid":"8d1c9a6e"`)
+	assert.Contains(t, d.Get("object"), `"status"`)
+
+	// When the API reports the managed fields, only the fields owned by the field manager are kept.
+	duplosdktest.PatchFixture(location, &live, func() {
+		metadata := live["metadata"].(map[string]interface{})
+		metadata["labels"].(map[string]interface{})["team"] = "platform"
+		metadata["managedFields"] = []interface{}{
+			map[string]interface{}{
+				"manager":   k8sManifestDefaultFieldManager,
+				"operation": "Apply",
+				"fieldsV1": map[string]interface{}{
+					"f:metadata": map[string]interface{}{"f:labels": map[string]interface{}{"f:app": map[string]interface{}{}}},
+					"f:spec":     map[string]interface{}{"f:provider": map[string]interface{}{}},
+				},
+			},
+			map[string]interface{}{
+				"manager":   "kubectl",
+				"operation": "Update",
+				"fieldsV1": map[string]interface{}{
+					"f:metadata": map[string]interface{}{"f:labels": map[string]interface{}{"f:team": map[string]interface{}{}}},
+				},
+			},
+		}
+	})
+	diags = r.ReadContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	var manifest map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(d.Get("manifest").(string)), &manifest))
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "secrets-store.csi.x-k8s.io/v1",
+		"kind":       "SecretProviderClass",
+		"metadata": map[string]interface{}{
+			"name":   "app-secrets",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{"provider": "aws"},
+	}, manifest)
+	assert.NotContains(t, d.Get("object"), "managedFields")
+
+	// An imported object has no previous manifest, so its server-populated fields are dropped.
+	di := r.Data(nil)
+	di.SetId(d.Id())
+	imported, ierr := r.Importer.StateContext(ctx, di, c)
+	require.NoError(t, ierr)
+	require.Len(t, imported, 1)
+	di = imported[0]
+	di.Set("field_manager", "someone-else")
+	diags = r.ReadContext(ctx, di, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, Tenant_testacc1a, di.Get("tenant_id"))
+	assert.NotContains(t, di.Get("manifest"), "uid")
+	assert.NotContains(t, di.Get("manifest"), "status")
+	assert.Contains(t, di.Get("manifest"), `"team":"platform"`)
+
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	obj, err = c.K8sManifestGet(Tenant_testacc1a, "secrets-store.csi.x-k8s.io/v1", "SecretProviderClass", "app-secrets")
+	require.Nil(t, err)
+	assert.Nil(t, obj)
+}
+
+func TestK8sManifestExpand(t *testing.T) {
+	obj, err := expandK8sManifest(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app"}, "data": {"replicas": "3"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", obj.ApiVersion())
+	assert.Equal(t, "ConfigMap", obj.Kind())
+	assert.Equal(t, "app", obj.Name())
+
+	_, err = expandK8sManifest("apiVersion: v1\nkind: ConfigMap\n")
+	assert.ErrorContains(t, err, "metadata.name")
+
+	_, err = expandK8sManifest("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n")
+	assert.ErrorContains(t, err, "exactly one object")
+
+	assert.Equal(t,
+		canonicalizeK8sManifest("kind: ConfigMap\napiVersion: v1\nmetadata:\n  name: app\n"),
+		canonicalizeK8sManifest(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app"}}`))
+}
+
+func TestK8sManifestProjectManagedFields(t *testing.T) {
+	var obj, fields interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"spec": {
+			"replicas": 3,
+			"template": {"spec": {"containers": [
+				{"name": "sidecar", "image": "envoy"},
+				{"name": "web", "image": "nginx", "imagePullPolicy": "Always", "ports": [{"containerPort": 80, "protocol": "TCP"}]}
+			]}},
+			"finalizers": ["a", "b"]
+		}
+	}`), &obj))
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"f:spec": {
+			"f:template": {"f:spec": {"f:containers": {
+				"k:{\"name\":\"web\"}": {".": {}, "f:name": {}, "f:image": {}, "f:ports": {"k:{\"containerPort\":80,\"protocol\":\"TCP\"}": {".": {}, "f:containerPort": {}}}}
+			}}},
+			"f:finalizers": {"v:\"b\"": {}}
+		}
+	}`), &fields))
+
+	out, err := json.Marshal(projectK8sManagedFields(obj, fields.(map[string]interface{})))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"spec": {
+			"template": {"spec": {"containers": [{"name": "web", "image": "nginx", "ports": [{"containerPort": 80}]}]}},
+			"finalizers": ["b"]
+		}
+	}`, string(out))
+}
+
+func TestK8sManifestParseId(t *testing.T) {
+	tenantId, apiVersion, kind, name, err := parseKubernetesManifestIdParts(Tenant_testacc1a + "/apps/v1/Deployment/web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{Tenant_testacc1a, "apps/v1", "Deployment", "web"}, []string{tenantId, apiVersion, kind, name})
+
+	_, apiVersion, kind, _, err = parseKubernetesManifestIdParts(Tenant_testacc1a + "/v1/ConfigMap/app")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", apiVersion)
+	assert.Equal(t, "ConfigMap", kind)
+
+	_, _, _, _, err = parseKubernetesManifestIdParts("v1/ConfigMap/app")
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKubernetesNetworkPolicyV1() *schema.Resource {
+	return &schema.Resource{
+		Description: "`duplocloud_k8s_network_policy` manages a Kubernetes network policy in a Duplo tenant.  " +
+			"Unlike `duplocloud_tenant_network_security_rule`, which controls traffic to the tenant's cloud resources, a network policy controls the traffic between pods inside the cluster.",
+		CreateContext: resourceKubernetesNetworkPolicyV1Create,
+		ReadContext:   resourceKubernetesNetworkPolicyV1Read,
+		UpdateContext: resourceKubernetesNetworkPolicyV1Update,
+		DeleteContext: resourceKubernetesNetworkPolicyV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the network policy will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("network policy", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec of the network policy.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: networkPolicySpecFields(),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesNetworkPolicyV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesNetworkPolicyV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sNetworkPolicy{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     expandNetworkPolicySpec(d.Get("spec").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sNetworkPolicyCreate(&rq); err != nil {
+		return diag.Errorf("Failed to create network policy. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new network policy %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/networkPolicy/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s network policy", id, func() (interface{}, duplosdk.ClientError) {
+		return c.K8sNetworkPolicyGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesNetworkPolicyV1Create(%s): end", tenantId)
+	return resourceKubernetesNetworkPolicyV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesNetworkPolicyV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "networkPolicy")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading network policy %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	np, cerr := c.K8sNetworkPolicyGet(tenantId, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read network policy. API error: %s", cerr)
+	}
+	if np == nil {
+		log.Printf("[TRACE] resourceKubernetesNetworkPolicyV1Read(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(np.Metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+	if err := d.Set("spec", flattenNetworkPolicySpec(np.Spec)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesNetworkPolicyV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesNetworkPolicyV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sNetworkPolicy{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     expandNetworkPolicySpec(d.Get("spec").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sNetworkPolicyUpdate(tenantId, name, &rq); err != nil {
+		return diag.Errorf("Failed to update network policy. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated network policy %s/%s", tenantId, name)
+
+	diags := resourceKubernetesNetworkPolicyV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesNetworkPolicyV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesNetworkPolicyV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "networkPolicy")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesNetworkPolicyV1Delete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sNetworkPolicyDelete(tenantId, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete network policy. API error: %s", cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s network policy", d.Id(), func() (interface{}, duplosdk.ClientError) {
+		return c.K8sNetworkPolicyGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesNetworkPolicyV1Delete(%s, %s): end", tenantId, name)
+	return nil
+}
@@ -0,0 +1,89 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmulator_K8sNetworkPolicy(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesNetworkPolicyV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "api-allow-web",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"pod_selector": []interface{}{map[string]interface{}{
+				"match_labels": map[string]interface{}{"app": "api"},
+			}},
+			"policy_types": []interface{}{"Ingress", "Egress"},
+			"ingress": []interface{}{map[string]interface{}{
+				"from": []interface{}{
+					map[string]interface{}{
+						"pod_selector": []interface{}{map[string]interface{}{
+							"match_labels": map[string]interface{}{"app": "web"},
+						}},
+					},
+					map[string]interface{}{
+						"ip_block": []interface{}{map[string]interface{}{
+							"cidr":   "10.0.0.0/16",
+							"except": []interface{}{"10.0.1.0/24"},
+						}},
+					},
+				},
+				"port": []interface{}{map[string]interface{}{
+					"port": "8080",
+				}},
+			}},
+			"egress": []interface{}{map[string]interface{}{
+				"port": []interface{}{map[string]interface{}{
+					"port":     "53",
+					"protocol": "UDP",
+				}},
+			}},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/networkPolicy/api-allow-web", d.Id())
+
+	np, err := c.K8sNetworkPolicyGet(Tenant_testacc1a, "api-allow-web")
+	require.Nil(t, err)
+	require.NotNil(t, np)
+	assert.Equal(t, "api", np.Spec.PodSelector.MatchLabels["app"])
+	require.Len(t, np.Spec.Ingress, 1)
+	require.Len(t, np.Spec.Ingress[0].From, 2)
+	assert.Equal(t, "web", np.Spec.Ingress[0].From[0].PodSelector.MatchLabels["app"])
+	assert.Nil(t, np.Spec.Ingress[0].From[0].NamespaceSelector)
+	assert.Equal(t, []string{"10.0.1.0/24"}, np.Spec.Ingress[0].From[1].IPBlock.Except)
+	assert.Equal(t, 8080, np.Spec.Ingress[0].Ports[0].Port.IntValue())
+	assert.Equal(t, "TCP", string(*np.Spec.Ingress[0].Ports[0].Protocol))
+	assert.Nil(t, np.Spec.Egress[0].To)
+
+	assert.Equal(t, "8080", d.Get("spec.0.ingress.0.port.0.port"))
+	assert.Equal(t, "UDP", d.Get("spec.0.egress.0.port.0.protocol"))
+	assert.Equal(t, "10.0.0.0/16", d.Get("spec.0.ingress.0.from.1.ip_block.0.cidr"))
+
+	ds := dataSourceK8sNetworkPolicies()
+	dd := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+	})
+	diags = ds.ReadContext(ctx, dd, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, dd.Get("network_policies.#"))
+	assert.Equal(t, "api-allow-web", dd.Get("network_policies.0.name"))
+	assert.Equal(t, "Egress", dd.Get("network_policies.0.spec.0.policy_types.1"))
+
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	np, err = c.K8sNetworkPolicyGet(Tenant_testacc1a, "api-allow-web")
+	require.Nil(t, err)
+	assert.Nil(t, np)
+}
@@ -0,0 +1,176 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKubernetesPodDisruptionBudgetV1() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`duplocloud_k8s_pod_disruption_budget` manages a Kubernetes pod disruption budget in a Duplo tenant.",
+		CreateContext: resourceKubernetesPodDisruptionBudgetV1Create,
+		ReadContext:   resourceKubernetesPodDisruptionBudgetV1Read,
+		UpdateContext: resourceKubernetesPodDisruptionBudgetV1Update,
+		DeleteContext: resourceKubernetesPodDisruptionBudgetV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the pod disruption budget will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("pod disruption budget", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec of the pod disruption budget.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: podDisruptionBudgetSpecFields(),
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "The most recently observed status of the pod disruption budget.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: podDisruptionBudgetStatusFields(),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesPodDisruptionBudgetV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesPodDisruptionBudgetV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sPodDisruptionBudget{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     expandPodDisruptionBudgetSpec(d.Get("spec").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sPodDisruptionBudgetCreate(&rq); err != nil {
+		return diag.Errorf("Failed to create pod disruption budget. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new pod disruption budget %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/podDisruptionBudget/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s pod disruption budget", id, func() (interface{}, duplosdk.ClientError) {
+		return c.K8sPodDisruptionBudgetGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesPodDisruptionBudgetV1Create(%s): end", tenantId)
+	return resourceKubernetesPodDisruptionBudgetV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesPodDisruptionBudgetV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "podDisruptionBudget")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading pod disruption budget %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	pdb, cerr := c.K8sPodDisruptionBudgetGet(tenantId, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read pod disruption budget. API error: %s", cerr)
+	}
+	if pdb == nil {
+		log.Printf("[TRACE] resourceKubernetesPodDisruptionBudgetV1Read(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(pdb.Metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+	if err := d.Set("spec", flattenPodDisruptionBudgetSpec(pdb.Spec)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", flattenPodDisruptionBudgetStatus(pdb.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesPodDisruptionBudgetV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesPodDisruptionBudgetV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sPodDisruptionBudget{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     expandPodDisruptionBudgetSpec(d.Get("spec").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sPodDisruptionBudgetUpdate(tenantId, name, &rq); err != nil {
+		return diag.Errorf("Failed to update pod disruption budget. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated pod disruption budget %s/%s", tenantId, name)
+
+	diags := resourceKubernetesPodDisruptionBudgetV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesPodDisruptionBudgetV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesPodDisruptionBudgetV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "podDisruptionBudget")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesPodDisruptionBudgetV1Delete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sPodDisruptionBudgetDelete(tenantId, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete pod disruption budget. API error: %s", cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s pod disruption budget", d.Id(), func() (interface{}, duplosdk.ClientError) {
+		return c.K8sPodDisruptionBudgetGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesPodDisruptionBudgetV1Delete(%s, %s): end", tenantId, name)
+	return nil
+}
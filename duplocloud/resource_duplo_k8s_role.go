@@ -0,0 +1,194 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKubernetesRoleV1() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`duplocloud_k8s_role` manages a Kubernetes RBAC role in the namespace of a Duplo tenant.",
+		CreateContext: resourceKubernetesRoleV1Create,
+		ReadContext:   resourceKubernetesRoleV1Read,
+		UpdateContext: resourceKubernetesRoleV1Update,
+		DeleteContext: resourceKubernetesRoleV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the role will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("role", false),
+			"rule": {
+				Type:        schema.TypeList,
+				Description: "The rules that grant permissions within the tenant's namespace.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_groups": {
+							Type:        schema.TypeSet,
+							Description: "The API groups that contain the resources. `\"\"` is the core API group.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+						},
+						"resource_names": {
+							Type:        schema.TypeSet,
+							Description: "An optional white list of names that the rule applies to. An empty set means that everything is allowed.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+						},
+						"resources": {
+							Type:        schema.TypeSet,
+							Description: "The resources that the rule applies to, such as `pods` or `deployments`. `*` represents all resources.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+						},
+						"verbs": {
+							Type:        schema.TypeSet,
+							Description: "The verbs that apply to all of the resources of the rule, such as `get` or `list`. `*` represents all verbs.",
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesRoleV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesRoleV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sRole{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Rules:    expandRBACPolicyRules(d.Get("rule").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sRoleCreate(&rq); err != nil {
+		return diag.Errorf("Failed to create role. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new role %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/role/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s role", id, func() (interface{}, duplosdk.ClientError) {
+		return c.K8sRoleGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesRoleV1Create(%s): end", tenantId)
+	return resourceKubernetesRoleV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesRoleV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "role")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading role %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	role, cerr := c.K8sRoleGet(tenantId, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read role. API error: %s", cerr)
+	}
+	if role == nil {
+		log.Printf("[TRACE] resourceKubernetesRoleV1Read(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(role.Metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+	if err := d.Set("rule", flattenRBACPolicyRules(role.Rules)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesRoleV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesRoleV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sRole{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Rules:    expandRBACPolicyRules(d.Get("rule").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sRoleUpdate(tenantId, name, &rq); err != nil {
+		return diag.Errorf("Failed to update role. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated role %s/%s", tenantId, name)
+
+	diags := resourceKubernetesRoleV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesRoleV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesRoleV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "role")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesRoleV1Delete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sRoleDelete(tenantId, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete role. API error: %s", cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s role", d.Id(), func() (interface{}, duplosdk.ClientError) {
+		return c.K8sRoleGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesRoleV1Delete(%s, %s): end", tenantId, name)
+	return nil
+}
@@ -0,0 +1,245 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func resourceKubernetesRoleBindingV1() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`duplocloud_k8s_role_binding` manages a Kubernetes RBAC role binding in the namespace of a Duplo tenant.",
+		CreateContext: resourceKubernetesRoleBindingV1Create,
+		ReadContext:   resourceKubernetesRoleBindingV1Read,
+		UpdateContext: resourceKubernetesRoleBindingV1Update,
+		DeleteContext: resourceKubernetesRoleBindingV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the role binding will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("role binding", false),
+			"role_ref": {
+				Type:        schema.TypeList,
+				Description: "The role that is bound. It can be a role in the tenant's namespace, or a cluster role. It cannot be changed once the binding is created.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_group": {
+							Type:        schema.TypeString,
+							Description: "The API group of the role.",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     rbacv1.GroupName,
+						},
+						"kind": {
+							Type:         schema.TypeString,
+							Description:  "The kind of the role. Valid values are `Role` and `ClusterRole`.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"Role", "ClusterRole"}, false),
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the role.",
+							Required:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"subject": {
+				Type:        schema.TypeList,
+				Description: "The users, groups and service accounts that the role is bound to.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_group": {
+							Type:        schema.TypeString,
+							Description: "The API group of the subject. Defaults to `rbac.authorization.k8s.io` for users and groups, and to the core API group for service accounts.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The kind of the subject. Valid values are `User`, `Group` and `ServiceAccount`.",
+							Required:    true,
+							ValidateFunc: validation.StringInSlice([]string{
+								rbacv1.UserKind,
+								rbacv1.GroupKind,
+								rbacv1.ServiceAccountKind,
+							}, false),
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the subject.",
+							Required:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "The namespace of a service account subject. Defaults to the tenant's namespace.",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesRoleBindingV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesRoleBindingV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c := meta.(*duplosdk.Client)
+	rq, err := expandKubernetesRoleBindingV1(c, d, tenantId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.K8sRoleBindingCreate(rq); err != nil {
+		return diag.Errorf("Failed to create role binding. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new role binding %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/roleBinding/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s role binding", id, func() (interface{}, duplosdk.ClientError) {
+		return c.K8sRoleBindingGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesRoleBindingV1Create(%s): end", tenantId)
+	return resourceKubernetesRoleBindingV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesRoleBindingV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "roleBinding")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading role binding %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	binding, cerr := c.K8sRoleBindingGet(tenantId, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read role binding. API error: %s", cerr)
+	}
+	if binding == nil {
+		log.Printf("[TRACE] resourceKubernetesRoleBindingV1Read(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(binding.Metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+	if err := d.Set("role_ref", flattenRBACRoleRef(binding.RoleRef)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("subject", flattenRBACSubjects(binding.Subjects)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesRoleBindingV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesRoleBindingV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c := meta.(*duplosdk.Client)
+	rq, err := expandKubernetesRoleBindingV1(c, d, tenantId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.K8sRoleBindingUpdate(tenantId, name, rq); err != nil {
+		return diag.Errorf("Failed to update role binding. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated role binding %s/%s", tenantId, name)
+
+	diags := resourceKubernetesRoleBindingV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesRoleBindingV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesRoleBindingV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "roleBinding")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesRoleBindingV1Delete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sRoleBindingDelete(tenantId, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete role binding. API error: %s", cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s role binding", d.Id(), func() (interface{}, duplosdk.ClientError) {
+		return c.K8sRoleBindingGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesRoleBindingV1Delete(%s, %s): end", tenantId, name)
+	return nil
+}
+
+func expandKubernetesRoleBindingV1(c *duplosdk.Client, d *schema.ResourceData, tenantId string) (*duplosdk.DuploK8sRoleBinding, error) {
+	rq := &duplosdk.DuploK8sRoleBinding{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		RoleRef:  expandRBACRoleRef(d.Get("role_ref").([]interface{})),
+		Subjects: expandRBACSubjects(d.Get("subject").([]interface{})),
+	}
+
+	// Service accounts must be qualified by their namespace, which is the tenant's unless configured otherwise.
+	for i := range rq.Subjects {
+		if rq.Subjects[i].Kind == rbacv1.ServiceAccountKind && rq.Subjects[i].Namespace == "" {
+			namespace, err := c.GetDuploServicesPrefix(tenantId, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the namespace of tenant %s: %s", tenantId, err)
+			}
+			rq.Subjects[i].Namespace = namespace
+		}
+	}
+	return rq, nil
+}
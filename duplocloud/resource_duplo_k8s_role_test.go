@@ -0,0 +1,75 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmulator_K8sRoleAndBinding(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesRoleV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "pod-reader",
+		}},
+		"rule": []interface{}{map[string]interface{}{
+			"api_groups": []interface{}{""},
+			"resources":  []interface{}{"pods", "pods/log"},
+			"verbs":      []interface{}{"get", "list", "watch"},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/role/pod-reader", d.Id())
+
+	role, err := c.K8sRoleGet(Tenant_testacc1a, "pod-reader")
+	require.Nil(t, err)
+	require.NotNil(t, role)
+	require.Len(t, role.Rules, 1)
+	assert.ElementsMatch(t, []string{"get", "list", "watch"}, role.Rules[0].Verbs)
+	assert.Equal(t, []string{""}, role.Rules[0].APIGroups)
+	assert.Equal(t, 2, d.Get("rule.0.resources").(*schema.Set).Len())
+
+	rb := resourceKubernetesRoleBindingV1()
+	db := schema.TestResourceDataRaw(t, rb.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "worker-pod-reader",
+		}},
+		"role_ref": []interface{}{map[string]interface{}{
+			"kind": "Role",
+			"name": "pod-reader",
+		}},
+		"subject": []interface{}{
+			map[string]interface{}{"kind": "ServiceAccount", "name": "worker"},
+			map[string]interface{}{"kind": "Group", "name": "developers"},
+		},
+	})
+	diags = rb.CreateContext(ctx, db, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/roleBinding/worker-pod-reader", db.Id())
+
+	binding, err := c.K8sRoleBindingGet(Tenant_testacc1a, "worker-pod-reader")
+	require.Nil(t, err)
+	require.NotNil(t, binding)
+	assert.Equal(t, "rbac.authorization.k8s.io", binding.RoleRef.APIGroup)
+	assert.Equal(t, "duploservices-testacc1a", binding.Subjects[0].Namespace)
+	assert.Equal(t, "", binding.Subjects[0].APIGroup)
+	assert.Equal(t, "rbac.authorization.k8s.io", binding.Subjects[1].APIGroup)
+	assert.Equal(t, "duploservices-testacc1a", db.Get("subject.0.namespace"))
+
+	diags = rb.DeleteContext(ctx, db, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	role, err = c.K8sRoleGet(Tenant_testacc1a, "pod-reader")
+	require.Nil(t, err)
+	assert.Nil(t, role)
+}
@@ -0,0 +1,176 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKubernetesServiceV1() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`duplocloud_k8s_service` manages a Kubernetes service in a Duplo tenant.",
+		CreateContext: resourceKubernetesServiceV1Create,
+		ReadContext:   resourceKubernetesServiceV1Read,
+		UpdateContext: resourceKubernetesServiceV1Update,
+		DeleteContext: resourceKubernetesServiceV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the service will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("service", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec of the service.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: serviceSpecFields(),
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "The most recently observed status of the service.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: serviceStatusFields(),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesServiceV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesServiceV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sService{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     expandServiceSpec(d.Get("spec").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sServiceCreate(&rq); err != nil {
+		return diag.Errorf("Failed to create service. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new service %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/service/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s service", id, func() (interface{}, duplosdk.ClientError) {
+		return c.K8sServiceGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesServiceV1Create(%s): end", tenantId)
+	return resourceKubernetesServiceV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesServiceV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "service")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading service %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	svc, cerr := c.K8sServiceGet(tenantId, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read service. API error: %s", cerr)
+	}
+	if svc == nil {
+		log.Printf("[TRACE] resourceKubernetesServiceV1Read(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(svc.Metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+	if err := d.Set("spec", flattenServiceSpec(svc.Spec)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", flattenServiceStatus(svc.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesServiceV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesServiceV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq := duplosdk.DuploK8sService{
+		TenantId: tenantId,
+		Metadata: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:     expandServiceSpec(d.Get("spec").([]interface{})),
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.K8sServiceUpdate(tenantId, name, &rq); err != nil {
+		return diag.Errorf("Failed to update service. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated service %s/%s", tenantId, name)
+
+	diags := resourceKubernetesServiceV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesServiceV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesServiceV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "service")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesServiceV1Delete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sServiceDelete(tenantId, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete service. API error: %s", cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s service", d.Id(), func() (interface{}, duplosdk.ClientError) {
+		return c.K8sServiceGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesServiceV1Delete(%s, %s): end", tenantId, name)
+	return nil
+}
@@ -0,0 +1,246 @@
+package duplocloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The annotation that EKS uses to give the pods of a service account the credentials of an IAM role.
+const k8sServiceAccountIamRoleAnnotation = "eks.amazonaws.com/role-arn"
+
+func resourceKubernetesServiceAccountV1() *schema.Resource {
+	return &schema.Resource{
+		Description: "`duplocloud_k8s_service_account` manages a Kubernetes service account in a Duplo tenant.  " +
+			"On AWS, the service account can be annotated with the tenant's IAM role, so that its pods get the tenant's permissions through IRSA.",
+		CreateContext: resourceKubernetesServiceAccountV1Create,
+		ReadContext:   resourceKubernetesServiceAccountV1Read,
+		UpdateContext: resourceKubernetesServiceAccountV1Update,
+		DeleteContext: resourceKubernetesServiceAccountV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: resourceKubernetesServiceAccountV1CustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the service account will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("service account", false),
+			"attach_tenant_iam_role": {
+				Description: "When true, the service account is annotated with the ARN of the tenant's IAM role, so that its pods can assume the role through IRSA. " +
+					"Only supported for AWS tenants, and rejected at plan time for the others.  " +
+					"On GKE, set the `iam.gke.io/gcp-service-account` annotation in the `metadata` block instead.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"automount_service_account_token": {
+				Description: "Whether pods running as this service account should have an API token automatically mounted.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"image_pull_secret": {
+				Description: "The secrets that pods running as this service account can use to pull their images.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the secret.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"iam_role_arn": {
+				Description: "The ARN of the IAM role that the service account is annotated with, if any.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesServiceAccountV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesServiceAccountV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c := meta.(*duplosdk.Client)
+	rq, err := expandKubernetesServiceAccountV1(c, d, tenantId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.K8sServiceAccountCreate(rq); err != nil {
+		return diag.Errorf("Failed to create service account. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new service account %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/serviceAccount/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s service account", id, func() (interface{}, duplosdk.ClientError) {
+		return c.K8sServiceAccountGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesServiceAccountV1Create(%s): end", tenantId)
+	return resourceKubernetesServiceAccountV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesServiceAccountV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "serviceAccount")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading service account %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	sa, cerr := c.K8sServiceAccountGet(tenantId, name)
+	if cerr != nil {
+		return diag.Errorf("Failed to read service account. API error: %s", cerr)
+	}
+	if sa == nil {
+		log.Printf("[TRACE] resourceKubernetesServiceAccountV1Read(%s, %s): object not found", tenantId, name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(sa.Metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+	d.Set("automount_service_account_token", sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken)
+	if err := d.Set("image_pull_secret", flattenLocalObjectReferenceArray(sa.ImagePullSecrets)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("iam_role_arn", sa.Metadata.Annotations[k8sServiceAccountIamRoleAnnotation])
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesServiceAccountV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesServiceAccountV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c := meta.(*duplosdk.Client)
+	rq, err := expandKubernetesServiceAccountV1(c, d, tenantId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.K8sServiceAccountUpdate(tenantId, name, rq); err != nil {
+		return diag.Errorf("Failed to update service account. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated service account %s/%s", tenantId, name)
+
+	diags := resourceKubernetesServiceAccountV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesServiceAccountV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesServiceAccountV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "serviceAccount")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[TRACE] resourceKubernetesServiceAccountV1Delete(%s, %s): start", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	if cerr := c.K8sServiceAccountDelete(tenantId, name); cerr != nil && cerr.Status() != 404 {
+		return diag.Errorf("Failed to delete service account. API error: %s", cerr)
+	}
+
+	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "k8s service account", d.Id(), func() (interface{}, duplosdk.ClientError) {
+		return c.K8sServiceAccountGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesServiceAccountV1Delete(%s, %s): end", tenantId, name)
+	return nil
+}
+
+// resourceKubernetesServiceAccountV1CustomizeDiff rejects attach_tenant_iam_role for the tenants that are not on AWS,
+// which would otherwise only fail when applied.
+func resourceKubernetesServiceAccountV1CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("attach_tenant_iam_role").(bool) || !d.NewValueKnown("tenant_id") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("attach_tenant_iam_role") {
+		return nil
+	}
+	return validateK8sServiceAccountTenantCloud(meta.(*duplosdk.Client), d.Get("tenant_id").(string))
+}
+
+func validateK8sServiceAccountTenantCloud(c *duplosdk.Client, tenantId string) error {
+	tenant, err := c.TenantGetV2(tenantId)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve tenant '%s': %s", tenantId, err)
+	}
+	if tenant == nil {
+		return fmt.Errorf("tenant '%s' not found", tenantId)
+	}
+	infra, err := c.InfrastructureGetConfig(tenant.PlanID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve duplo infrastructure '%s': %s", tenant.PlanID, err)
+	}
+	if infra != nil && infra.Cloud != AWS_CLOUD {
+		return fmt.Errorf("attach_tenant_iam_role is only supported for AWS tenants, and tenant '%s' is not on AWS", tenantId)
+	}
+	return nil
+}
+
+func expandKubernetesServiceAccountV1(c *duplosdk.Client, d *schema.ResourceData, tenantId string) (*duplosdk.DuploK8sServiceAccount, error) {
+	rq := &duplosdk.DuploK8sServiceAccount{
+		TenantId:                     tenantId,
+		Metadata:                     expandMetadata(d.Get("metadata").([]interface{})),
+		AutomountServiceAccountToken: ptrToBool(d.Get("automount_service_account_token").(bool)),
+	}
+	if v, ok := d.Get("image_pull_secret").([]interface{}); ok && len(v) > 0 {
+		rq.ImagePullSecrets = expandImagePullSecrets(v)
+	}
+
+	// The annotation is not part of the configured metadata, so it is never shown as a diff.
+	if d.Get("attach_tenant_iam_role").(bool) {
+		arn, err := c.TenantGetAwsIamRoleArn(tenantId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the IAM role of tenant %s: %s", tenantId, err)
+		}
+		if rq.Metadata.Annotations == nil {
+			rq.Metadata.Annotations = map[string]string{}
+		}
+		rq.Metadata.Annotations[k8sServiceAccountIamRoleAnnotation] = arn
+	}
+	return rq, nil
+}
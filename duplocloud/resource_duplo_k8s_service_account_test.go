@@ -0,0 +1,62 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+	"github.com/duplocloud/terraform-provider-duplocloud/internal/duplosdktest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmulator_K8sServiceAccount(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesServiceAccountV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name":        "worker",
+			"annotations": map[string]interface{}{"team": "data"},
+		}},
+		"attach_tenant_iam_role": true,
+		"image_pull_secret": []interface{}{map[string]interface{}{
+			"name": "registry",
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/serviceAccount/worker", d.Id())
+
+	sa, err := c.K8sServiceAccountGet(Tenant_testacc1a, "worker")
+	require.Nil(t, err)
+	require.NotNil(t, sa)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/duploservices-testacc1a", sa.Metadata.Annotations[k8sServiceAccountIamRoleAnnotation])
+	assert.Equal(t, "data", sa.Metadata.Annotations["team"])
+	assert.Equal(t, "registry", sa.ImagePullSecrets[0].Name)
+
+	assert.Equal(t, "arn:aws:iam::123456789012:role/duploservices-testacc1a", d.Get("iam_role_arn"))
+	assert.NotContains(t, d.Get("metadata.0.annotations"), k8sServiceAccountIamRoleAnnotation)
+	assert.Equal(t, true, d.Get("automount_service_account_token"))
+
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	sa, err = c.K8sServiceAccountGet(Tenant_testacc1a, "worker")
+	require.Nil(t, err)
+	assert.Nil(t, sa)
+}
+
+func TestEmulator_K8sServiceAccountTenantCloud(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	assert.NoError(t, validateK8sServiceAccountTenantCloud(c, Tenant_testacc1a))
+
+	infra := duplosdk.DuploInfrastructureConfig{}
+	duplosdktest.PatchFixture("infra/testacc1", &infra, func() { infra.Cloud = GCP_CLOUD })
+	defer duplosdktest.PatchFixture("infra/testacc1", &infra, func() { infra.Cloud = AWS_CLOUD })
+	assert.ErrorContains(t, validateK8sServiceAccountTenantCloud(c, Tenant_testacc1a), "only supported for AWS tenants")
+}
@@ -0,0 +1,167 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmulator_K8sService(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesServiceV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "web",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"selector": map[string]interface{}{"app": "web"},
+			"port": []interface{}{map[string]interface{}{
+				"name":        "http",
+				"port":        80,
+				"target_port": "8080",
+			}},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/service/web", d.Id())
+
+	svc, err := c.K8sServiceGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	require.NotNil(t, svc)
+	assert.Equal(t, "ClusterIP", string(svc.Spec.Type))
+	assert.Equal(t, "TCP", string(svc.Spec.Ports[0].Protocol))
+	assert.Equal(t, 8080, svc.Spec.Ports[0].TargetPort.IntValue())
+
+	assert.Equal(t, "web", d.Get("spec.0.selector.app"))
+	assert.Equal(t, "8080", d.Get("spec.0.port.0.target_port"))
+	assert.Equal(t, "None", d.Get("spec.0.session_affinity"))
+
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	svc, err = c.K8sServiceGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	assert.Nil(t, svc)
+}
+
+func TestEmulator_K8sHorizontalPodAutoscaler(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesHorizontalPodAutoscalerV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "web",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"max_replicas": 5,
+			"scale_target_ref": []interface{}{map[string]interface{}{
+				"kind": "Deployment",
+				"name": "web",
+			}},
+			"metric": []interface{}{
+				map[string]interface{}{
+					"type": "Resource",
+					"resource": []interface{}{map[string]interface{}{
+						"name": "cpu",
+						"target": []interface{}{map[string]interface{}{
+							"type":                "Utilization",
+							"average_utilization": 70,
+						}},
+					}},
+				},
+				map[string]interface{}{
+					"type": "External",
+					"external": []interface{}{map[string]interface{}{
+						"metric": []interface{}{map[string]interface{}{
+							"name": "queue_depth",
+							"selector": []interface{}{map[string]interface{}{
+								"match_labels": map[string]interface{}{"queue": "jobs"},
+							}},
+						}},
+						"target": []interface{}{map[string]interface{}{
+							"type":          "AverageValue",
+							"average_value": "30",
+						}},
+					}},
+				},
+			},
+			"behavior": []interface{}{map[string]interface{}{
+				"scale_down": []interface{}{map[string]interface{}{
+					"policy": []interface{}{map[string]interface{}{
+						"type":           "Pods",
+						"value":          1,
+						"period_seconds": 60,
+					}},
+				}},
+			}},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/horizontalPodAutoscaler/web", d.Id())
+
+	hpa, err := c.K8sHorizontalPodAutoscalerGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	require.NotNil(t, hpa)
+	assert.Equal(t, "apps/v1", hpa.Spec.ScaleTargetRef.APIVersion)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	require.Len(t, hpa.Spec.Metrics, 2)
+	assert.Equal(t, int32(70), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, "jobs", hpa.Spec.Metrics[1].External.Metric.Selector.MatchLabels["queue"])
+	assert.Nil(t, hpa.Spec.Behavior.ScaleDown.StabilizationWindowSeconds)
+
+	assert.Equal(t, "cpu", d.Get("spec.0.metric.0.resource.0.name"))
+	assert.Equal(t, "30", d.Get("spec.0.metric.1.external.0.target.0.average_value"))
+	assert.Equal(t, "Max", d.Get("spec.0.behavior.0.scale_down.0.select_policy"))
+
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	hpa, err = c.K8sHorizontalPodAutoscalerGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	assert.Nil(t, hpa)
+}
+
+func TestEmulator_K8sPodDisruptionBudget(t *testing.T) {
+	c := testAccEmulatorClient(t)
+	ctx := context.Background()
+
+	r := resourceKubernetesPodDisruptionBudgetV1()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tenant_id": Tenant_testacc1a,
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "web",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"max_unavailable": "25%",
+			"selector": []interface{}{map[string]interface{}{
+				"match_labels": map[string]interface{}{"app": "web"},
+			}},
+		}},
+	})
+	diags := r.CreateContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "v3/subscriptions/"+Tenant_testacc1a+"/k8s/podDisruptionBudget/web", d.Id())
+
+	pdb, err := c.K8sPodDisruptionBudgetGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	require.NotNil(t, pdb)
+	assert.Equal(t, "25%", pdb.Spec.MaxUnavailable.String())
+	assert.Nil(t, pdb.Spec.MinAvailable)
+
+	assert.Equal(t, "25%", d.Get("spec.0.max_unavailable"))
+	assert.Equal(t, "web", d.Get("spec.0.selector.0.match_labels.app"))
+
+	diags = r.DeleteContext(ctx, d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	pdb, err = c.K8sPodDisruptionBudgetGet(Tenant_testacc1a, "web")
+	require.Nil(t, err)
+	assert.Nil(t, pdb)
+}
@@ -0,0 +1,221 @@
+package duplocloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func resourceKubernetesStatefulSetV1() *schema.Resource {
+	return &schema.Resource{
+		Description: "`duplocloud_k8s_stateful_set` manages a Kubernetes stateful set in a Duplo tenant, as a Duplo service.  " +
+			"Unlike `duplocloud_duplo_service`, the pod template is typed like an `apps/v1` StatefulSet, " +
+			"so that it is validated when planning and its changes are shown field by field.",
+		CreateContext: resourceKubernetesStatefulSetV1Create,
+		ReadContext:   resourceKubernetesStatefulSetV1Read,
+		UpdateContext: resourceKubernetesStatefulSetV1Update,
+		DeleteContext: resourceKubernetesStatefulSetV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: validateK8sWorkloadMainContainer,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:  "The GUID of the tenant that the stateful set will be created in.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"metadata": namespacedMetadataSchema("stateful set", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec of the stateful set.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: statefulSetSpecFields(),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesStatefulSetV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesStatefulSetV1Create(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	update, err := expandKubernetesStatefulSetV1(d, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rq := duplosdk.DuploReplicationControllerCreateRequest{
+		Name:              name,
+		Image:             update.Image,
+		AgentPlatform:     update.AgentPlatform,
+		Replicas:          update.Replicas,
+		ForceStatefulSet:  update.ForceStatefulSet,
+		Volumes:           update.Volumes,
+		OtherDockerConfig: update.OtherDockerConfig,
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.ReplicationControllerCreate(tenantId, &rq); err != nil {
+		return diag.Errorf("Failed to create stateful set. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted new stateful set %s/%s", tenantId, name)
+
+	id := fmt.Sprintf("v3/subscriptions/%s/k8s/statefulSet/%s", tenantId, name)
+	d.SetId(id)
+
+	diags := waitForResourceToBePresentAfterCreate(ctx, d, "k8s stateful set", id, func() (interface{}, duplosdk.ClientError) {
+		return c.ReplicationControllerGet(tenantId, name)
+	})
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[TRACE] resourceKubernetesStatefulSetV1Create(%s): end", tenantId)
+	return resourceKubernetesStatefulSetV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesStatefulSetV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId, name, err := parseK8sV3IdParts(d.Id(), "statefulSet")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading stateful set %s/%s", tenantId, name)
+
+	c := meta.(*duplosdk.Client)
+	rc, cerr := c.ReplicationControllerGet(tenantId, name)
+	if cerr != nil {
+		if errors.Is(cerr, duplosdk.ErrNotFound) {
+			log.Printf("[TRACE] resourceKubernetesStatefulSetV1Read(%s, %s): object not found", tenantId, name)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Failed to read stateful set. API error: %s", cerr)
+	}
+	if rc == nil || rc.Template == nil {
+		d.SetId("")
+		return nil
+	}
+
+	metadata, template, _, err := flattenK8sWorkloadOtherDockerConfig(name, k8sWorkloadImage(rc), rc.Template.OtherDockerConfig)
+	if err != nil {
+		return diag.Errorf("Failed to read the pod template of stateful set '%s': %s", name, err)
+	}
+	claims, err := flattenStatefulSetVolumeClaimTemplates(rc.Template.Volumes, &template.Spec.Containers[0])
+	if err != nil {
+		return diag.Errorf("Failed to read the volumes of stateful set '%s': %s", name, err)
+	}
+	spec := appsv1.StatefulSetSpec{
+		Replicas:             ptrToInt32(int32(rc.Replicas)),
+		Template:             template,
+		VolumeClaimTemplates: claims,
+	}
+
+	d.Set("tenant_id", tenantId)
+
+	if metaErr := d.Set("metadata", flattenMetadata(metadata, d, meta)); metaErr != nil {
+		return diag.FromErr(metaErr)
+	}
+
+	specOut, specErr := flattenStatefulSetSpec(spec, d, meta)
+	if specErr != nil {
+		return diag.FromErr(specErr)
+	}
+	if err := d.Set("spec", specOut); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceKubernetesStatefulSetV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tenantId := d.Get("tenant_id").(string)
+	log.Printf("[TRACE] resourceKubernetesStatefulSetV1Update(%s): start", tenantId)
+
+	name, err := getK8sMetadataName(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rq, err := expandKubernetesStatefulSetV1(d, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c := meta.(*duplosdk.Client)
+	if err := c.ReplicationControllerUpdate(tenantId, rq); err != nil {
+		return diag.Errorf("Failed to update stateful set. API error: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated stateful set %s/%s", tenantId, name)
+
+	diags := resourceKubernetesStatefulSetV1Read(ctx, d, meta)
+	log.Printf("[TRACE] resourceKubernetesStatefulSetV1Update(%s): end", tenantId)
+	return diags
+}
+
+func resourceKubernetesStatefulSetV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteK8sWorkload(ctx, d, meta, "statefulSet")
+}
+
+func expandKubernetesStatefulSetV1(d *schema.ResourceData, name string) (*duplosdk.DuploReplicationControllerUpdateRequest, error) {
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec, err := expandStatefulSetSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.Template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("at least one container must be specified")
+	}
+
+	rq := &duplosdk.DuploReplicationControllerUpdateRequest{
+		Name:             name,
+		AgentPlatform:    k8sWorkloadAgentPlatform,
+		ForceStatefulSet: true,
+	}
+	if spec.Replicas != nil {
+		rq.Replicas = int(*spec.Replicas)
+	}
+
+	// The volume claim templates must be converted first, because it removes their mounts from the main container.
+	if len(spec.VolumeClaimTemplates) > 0 {
+		volumes, err := expandStatefulSetVolumeClaimTemplates(spec.VolumeClaimTemplates, &spec.Template.Spec.Containers[0])
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(volumes)
+		if err != nil {
+			return nil, err
+		}
+		rq.Volumes = string(encoded)
+	}
+
+	rq.Image, rq.OtherDockerConfig, err = expandK8sWorkloadOtherDockerConfig(metadata, spec.Template, nil)
+	if err != nil {
+		return nil, err
+	}
+	return rq, nil
+}
@@ -245,7 +245,8 @@ func rdsInstanceSchema() map[string]*schema.Schema {
 		},
 		"deletion_protection": {
 			Description: "If the DB instance should have deletion protection enabled." +
-				"The database can't be deleted when this value is set to `true`. This setting is not applicable for document db cluster instance.",
+				"The database can't be deleted when this value is set to `true`: the provider refuses to delete it, and so does AWS. " +
+				"To delete it, set this to `false` and apply the change first. The AWS setting is not applicable for document db cluster instance.",
 			Type:     schema.TypeBool,
 			Optional: true,
 			Default:  false,
@@ -380,6 +381,9 @@ func rdsInstanceSchema() map[string]*schema.Schema {
 
 // SCHEMA for resource crud
 func resourceDuploRdsInstance() *schema.Resource {
+	s := rdsInstanceSchema()
+	addWriteOnlyArgument(s, "master_password")
+
 	return &schema.Resource{
 		Description: "The `duplocloud_rds_instance` resource in DuploCloud manages the lifecycle of an RDS (Relational Database Service) instance within a cloud environment. It allows you to define, provision, and maintain database instances with customizable configurations, such as engine type, storage, and instance class, all within DuploCloud's automated infrastructure management.",
 
@@ -395,7 +399,7 @@ func resourceDuploRdsInstance() *schema.Resource {
 			Update: schema.DefaultTimeout(70 * time.Minute),
 			Delete: schema.DefaultTimeout(70 * time.Minute),
 		},
-		Schema:        rdsInstanceSchema(),
+		Schema:        s,
 		CustomizeDiff: customdiff.All(validateRDSParameters, validateRDSGroupParameters),
 	}
 }
@@ -436,6 +440,9 @@ func resourceDuploRdsInstanceRead(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(fmt.Sprintf("v2/subscriptions/%s/RDSDBInstance/%s", duplo.TenantID, duplo.Name))
 	// Convert the object into Terraform resource data
 	jo := rdsInstanceToState(duplo, d)
+	if writeOnlyInUse(d, "master_password") {
+		delete(jo, "master_password")
+	}
 	for key, val := range jo {
 		d.Set(key, val) //jo[key])
 	}
@@ -453,6 +460,10 @@ func resourceDuploRdsInstanceCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("Internal error: %s", err)
 	}
+	duplo.MasterPassword, err = writeOnlyValue(d, "master_password")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Populate the identifier field, and determine some other fields
 	duplo.Identifier = duplo.Name
@@ -655,9 +666,12 @@ func resourceDuploRdsInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 	// Request the password change in Duplo
-	if d.HasChange("master_password") {
+	if d.HasChanges("master_password", "master_password_wo_version") {
 		snapshotId, hasSnapshot := d.GetOk("snapshot_id")
-		masterPassword := d.Get("master_password").(string)
+		masterPassword, err := writeOnlyValue(d, "master_password")
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
 		// Condition to check snapshot_id and password.
 		if !(hasSnapshot && snapshotId.(string) != "" && masterPassword == "donotuse") {
@@ -847,6 +861,11 @@ func resourceDuploRdsInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceDuploRdsInstanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[TRACE] resourceDuploRdsInstanceDelete ******** start")
 
+	diags := checkDeletionProtection(d, m, "duplocloud_rds_instance", d.Get("name").(string))
+	if diags != nil {
+		return diags
+	}
+
 	// Delete the object from Duplo
 	c := m.(*duplosdk.Client)
 	id := d.Id()
@@ -873,7 +892,7 @@ func resourceDuploRdsInstanceDelete(ctx context.Context, d *schema.ResourceData,
 		}
 		return diag.FromErr(err)
 	}
-	diags := waitForResourceToBeMissingAfterDelete(ctx, d, "RDS DB instance", id, func() (interface{}, duplosdk.ClientError) {
+	diags = waitForResourceToBeMissingAfterDelete(ctx, d, "RDS DB instance", id, func() (interface{}, duplosdk.ClientError) {
 		return c.RdsInstanceGet(id)
 	})
 
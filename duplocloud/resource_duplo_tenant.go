@@ -2,9 +2,12 @@ package duplocloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,10 +22,12 @@ func resourceTenant() *schema.Resource {
 	return &schema.Resource{
 		Description: "`duplocloud_tenant` manages a tenant in Duplo." +
 			"<p>A **DuploCloud tenant** is an isolated environment within the DuploCloud platform where you can manage and provision cloud resources." +
-			" It essentially represents a distinct organizational unit or environment for deploying and managing infrastructure and applications.</p>",
+			" It essentially represents a distinct organizational unit or environment for deploying and managing infrastructure and applications.</p>" +
+			"<p>Tags, clean-up timers and feature toggles can be changed in place.  The labels and annotations of the tenant's kubernetes namespace, " +
+			"and the infrastructures the tenant may use, cannot be managed by this resource, as Duplo has no tenant setting for them.</p>",
 		ReadContext:   resourceTenantRead,
 		CreateContext: resourceTenantCreate,
-		UpdateContext: resourceTenantRead, // NO-OP
+		UpdateContext: resourceTenantUpdate,
 		DeleteContext: resourceTenantDelete,
 
 		Timeouts: &schema.ResourceTimeout{
@@ -90,9 +95,70 @@ func resourceTenant() *schema.Resource {
 				},
 			},
 			"tags": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem:     KeyValueSchema(),
+				Description: "A complete list of tenant-level tags, even ones not being managed by this resource.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        KeyValueSchema(),
+			},
+			"tag": {
+				Description: "A list of tenant-level tags to manage, expressed as key / value pairs.  Tags not listed here are left alone.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        KeyValueSchema(),
+			},
+			"cleanup_timers": {
+				Description: "The default clean-up timers of the tenant.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"expiry_time": {
+							Description:      "The expiry time of the tenant, in UTC with the format YYYY-MM-DDTHH:MM:SSZ (e.g. `2021-06-01T00:00:00Z`)",
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: diffSuppressEquivalentTenantTimer,
+						},
+						"pause_time": {
+							Description:      "The time to pause the tenant, in UTC with the format YYYY-MM-DDTHH:MM:SSZ (e.g. `2021-06-01T00:00:00Z`)",
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: diffSuppressEquivalentTenantTimer,
+						},
+					},
+				},
+			},
+			"feature_toggles": {
+				Description: "Tenant feature toggles to manage, keyed by the name of their tenant setting, such as `block_public_access_to_s3` or `enforce_ssl_for_s3`.  " +
+					"Toggles not listed here are left alone.  Do not manage the same setting with a `duplocloud_tenant_config` resource.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeBool},
+			},
+			"features": {
+				Description: "The features of the tenant, as reported by Duplo.  They are determined by the tenant's infrastructure.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cloud": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_kubernetes_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"use_lb_index": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"wait_until_created": {
 				Description: "Whether or not to wait until Duplo has created the tenant.",
@@ -106,6 +172,7 @@ func resourceTenant() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"deletion_protection": deletionProtectionSchema("tenant"),
 			"wait_until_deleted": {
 				Description: "Whether or not to wait until Duplo has destroyed the tenant.",
 				Type:        schema.TypeBool,
@@ -128,10 +195,10 @@ func resourceTenantRead(ctx context.Context, d *schema.ResourceData, m interface
 	log.Printf("[TRACE] resourceTenantRead(%s): start", tenantID)
 
 	// Get the object from Duplo, detecting a missing object
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 	duplo, err := c.TenantGetV2(tenantID)
 	if err != nil {
-		if err.Status() == 404 {
+		if errors.Is(err, duplosdk.ErrNotFound) {
 			log.Printf("Tenant %s not found", tenantID)
 			d.SetId("")
 			return nil
@@ -161,6 +228,38 @@ func resourceTenantRead(ctx context.Context, d *schema.ResourceData, m interface
 	}
 	d.Set("tags", keyValueToState("tags", duplo.Tags))
 	d.Set("allow_deletion", d.Get("allow_deletion").(bool))
+
+	// Only read back the settings that this resource manages.
+	if current := keyValueFromState("tag", d); len(*current) > 0 {
+		d.Set("tag", keyValueToState("tag", selectTenantTags(tenantTags(duplo), current)))
+	}
+	if len(d.Get("cleanup_timers").([]interface{})) > 0 {
+		d.Set("cleanup_timers", []interface{}{map[string]interface{}{
+			"expiry_time": duplo.Expiry,
+			"pause_time":  duplo.PauseTime,
+		}})
+	}
+	if managed := d.Get("feature_toggles").(map[string]interface{}); len(managed) > 0 {
+		config, err := c.TenantGetConfig(tenantID)
+		if err != nil {
+			return diag.Errorf("Unable to retrieve tenant config for '%s': %s", tenantID, err)
+		}
+		d.Set("feature_toggles", flattenTenantFeatureToggles(config.Metadata, managed))
+	}
+
+	// Older portals do not report the features of a tenant.
+	features, err := c.TenantFeaturesGet(tenantID)
+	if err != nil && !errors.Is(err, duplosdk.ErrNotFound) && !errors.Is(err, duplosdk.ErrAPIUnsupported) {
+		return diag.Errorf("Unable to retrieve tenant features for '%s': %s", tenantID, err)
+	}
+	if features != nil {
+		d.Set("features", []interface{}{map[string]interface{}{
+			"cloud":                 features.Cloud,
+			"region":                features.Region,
+			"is_kubernetes_enabled": features.IsKubernetesEnabled,
+			"use_lb_index":          features.UseLbIndex,
+		}})
+	}
 	log.Printf("[TRACE] resourceTenantRead(%s): end", tenantID)
 	return nil
 }
@@ -179,7 +278,7 @@ func resourceTenantCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	log.Printf("[TRACE] resourceTenantCreate(%s): start", rq.AccountName)
 
 	// Post the object to Duplo
-	c := m.(*duplosdk.Client)
+	c := m.(*duplosdk.Client).WithoutCache()
 
 	diags := validateTenantSchema(d, c)
 	if diags != nil {
@@ -200,8 +299,10 @@ func resourceTenantCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	if err != nil {
-		return diag.Errorf("Duplocloud resource '%s'\n%s", accountName, err)
-
+		return clientErrorDiagnostics(fmt.Sprintf("Duplocloud resource '%s'", accountName), err, map[string]string{
+			"AccountName": "account_name",
+			"PlanID":      "plan_id",
+		})
 	}
 
 	// Wait up to 60 seconds for Duplo to be able to return the tenant.
@@ -231,6 +332,12 @@ func resourceTenantCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		return diags
 	}
 
+	// Apply any settings that Duplo does not take at creation time.
+	diags = updateTenantSettings(c, rp.TenantID, d)
+	if diags != nil {
+		return diags
+	}
+
 	diags = resourceTenantRead(ctx, d, m)
 	if diags != nil {
 		return diags
@@ -239,6 +346,31 @@ func resourceTenantCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	return nil
 }
 
+// UPDATE resource
+func resourceTenantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	// Parse the identifying attributes
+	tenantID := parseDuploTenantIdParts(id)
+	if tenantID == "" {
+		return diag.Errorf("Invalid resource ID: %s", id)
+	}
+	log.Printf("[TRACE] resourceTenantUpdate(%s): start", tenantID)
+
+	c := m.(*duplosdk.Client).WithoutCache()
+	diags := updateTenantSettings(c, tenantID, d)
+	if diags != nil {
+		return diags
+	}
+
+	diags = resourceTenantRead(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	log.Printf("[TRACE] resourceTenantUpdate(%s): end", tenantID)
+	return nil
+}
+
 // DELETE resource
 func resourceTenantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Id()
@@ -250,13 +382,18 @@ func resourceTenantDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 	log.Printf("[TRACE] resourceTenantDelete(%s): start", tenantID)
 
+	diags := checkDeletionProtection(d, m, "duplocloud_tenant", d.Get("account_name").(string))
+	if diags != nil {
+		return diags
+	}
+
 	if d.Get("allow_deletion").(bool) {
 
 		// Delete the object with Duplo
-		c := m.(*duplosdk.Client)
+		c := m.(*duplosdk.Client).WithoutCache()
 		duplo, err := c.TenantGetV2(tenantID)
 		if err != nil {
-			if err.Status() == 404 {
+			if errors.Is(err, duplosdk.ErrNotFound) {
 				log.Printf("Tenant %s not found", tenantID)
 				return nil
 			}
@@ -267,7 +404,7 @@ func resourceTenantDelete(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 		err = c.TenantDelete(tenantID)
 		if err != nil {
-			if err.Status() == 404 {
+			if errors.Is(err, duplosdk.ErrNotFound) {
 				log.Printf("Tenant %s not found", tenantID)
 				return nil
 			}
@@ -339,3 +476,170 @@ func validateTenantSchema(d *schema.ResourceData, c *duplosdk.Client) diag.Diagn
 	}
 	return nil
 }
+
+// updateTenantSettings applies the changes to the settings that can be changed after a tenant is created.
+func updateTenantSettings(c *duplosdk.Client, tenantID string, d *schema.ResourceData) diag.Diagnostics {
+
+	// Tags: set the new or changed ones, then delete the ones that are no longer managed.
+	if d.HasChange("tag") {
+		o, n := d.GetChange("tag")
+		previous := keyValueFromStateList("tag", map[string]interface{}{"tag": o})
+		desired := keyValueFromStateList("tag", map[string]interface{}{"tag": n})
+		for _, rq := range tenantTagChanges(tenantID, previous, desired) {
+			var err duplosdk.ClientError
+			if rq.State == "delete" {
+				err = c.TenantTagDelete(rq)
+			} else {
+				err = c.TenantTagCreate(rq)
+			}
+			if err != nil {
+				return diag.Errorf("Unable to update tenant tag '%s', '%s': %s", tenantID, rq.Key, err)
+			}
+		}
+	}
+
+	// Clean-up timers: removing a timer from the configuration removes it from the tenant.
+	if d.HasChange("cleanup_timers") {
+		o, n := d.GetChange("cleanup_timers")
+		rq := tenantCleanUpTimersChange(tenantID, o.([]interface{}), n.([]interface{}))
+		if rq != nil {
+			diags := validateTenantCleanUpTimersUpdateRequest(rq)
+			if diags != nil {
+				return diags
+			}
+			err := c.UpdateTenantCleanUpTimers(rq)
+			if err != nil {
+				return diag.Errorf("Error updating tenant cleanup timers '%s': %s", tenantID, err)
+			}
+		}
+	}
+
+	// Feature toggles are kept in the tenant's config: removing a toggle from the configuration removes its setting.
+	if d.HasChange("feature_toggles") {
+		o, n := d.GetChange("feature_toggles")
+		previous := expandTenantFeatureToggles(o.(map[string]interface{}))
+		desired := expandTenantFeatureToggles(n.(map[string]interface{}))
+		err := c.TenantChangeConfig(tenantID, previous, desired)
+		if err != nil {
+			return diag.Errorf("Unable to update tenant feature toggles for '%s': %s", tenantID, err)
+		}
+	}
+
+	return nil
+}
+
+// tenantTags returns the tags of a tenant, never nil.
+func tenantTags(duplo *duplosdk.DuploTenant) *[]duplosdk.DuploKeyStringValue {
+	if duplo.Tags == nil {
+		return &[]duplosdk.DuploKeyStringValue{}
+	}
+	return duplo.Tags
+}
+
+// selectTenantTags returns the current values of the managed tags that still exist, in the order they are managed.
+func selectTenantTags(all, managed *[]duplosdk.DuploKeyStringValue) *[]duplosdk.DuploKeyStringValue {
+	values := map[string]string{}
+	for _, kv := range *all {
+		values[kv.Key] = kv.Value
+	}
+
+	tags := make([]duplosdk.DuploKeyStringValue, 0, len(*managed))
+	for _, kv := range *managed {
+		if value, ok := values[kv.Key]; ok {
+			tags = append(tags, duplosdk.DuploKeyStringValue{Key: kv.Key, Value: value})
+		}
+	}
+	return &tags
+}
+
+// tenantTagChanges returns the requests that turn the previous managed tags into the desired ones.
+func tenantTagChanges(tenantID string, previous, desired *[]duplosdk.DuploKeyStringValue) []duplosdk.DuploTenantConfigUpdateRequest {
+	old := map[string]string{}
+	for _, kv := range *previous {
+		old[kv.Key] = kv.Value
+	}
+
+	changes := []duplosdk.DuploTenantConfigUpdateRequest{}
+	present := map[string]bool{}
+	for _, kv := range *desired {
+		present[kv.Key] = true
+		if value, ok := old[kv.Key]; !ok || value != kv.Value {
+			changes = append(changes, duplosdk.DuploTenantConfigUpdateRequest{TenantID: tenantID, Key: kv.Key, Value: kv.Value})
+		}
+	}
+	for _, kv := range *previous {
+		if !present[kv.Key] {
+			changes = append(changes, duplosdk.DuploTenantConfigUpdateRequest{TenantID: tenantID, Key: kv.Key, Value: kv.Value, State: "delete"})
+		}
+	}
+	return changes
+}
+
+// tenantCleanUpTimersChange returns the request that turns the previous clean-up timers into the desired ones,
+// or nil if there is nothing to change.
+func tenantCleanUpTimersChange(tenantID string, previous, desired []interface{}) *duplosdk.DuploTenantCleanUpTimersUpdateRequest {
+	timer := func(list []interface{}, name string) string {
+		if len(list) == 0 || list[0] == nil {
+			return ""
+		}
+		return list[0].(map[string]interface{})[name].(string)
+	}
+
+	rq := duplosdk.DuploTenantCleanUpTimersUpdateRequest{
+		TenantId:   tenantID,
+		ExpiryTime: timer(desired, "expiry_time"),
+		PauseTime:  timer(desired, "pause_time"),
+	}
+	rq.RemoveExpiryTime = rq.ExpiryTime == "" && timer(previous, "expiry_time") != ""
+	rq.RemovePauseTime = rq.PauseTime == "" && timer(previous, "pause_time") != ""
+	if rq.ExpiryTime == "" && rq.PauseTime == "" && !rq.RemoveExpiryTime && !rq.RemovePauseTime {
+		return nil
+	}
+	return &rq
+}
+
+// expandTenantFeatureToggles converts the feature_toggles map to tenant config settings.
+func expandTenantFeatureToggles(toggles map[string]interface{}) *[]duplosdk.DuploKeyStringValue {
+	settings := make([]duplosdk.DuploKeyStringValue, 0, len(toggles))
+	for key, enabled := range toggles {
+		settings = append(settings, duplosdk.DuploKeyStringValue{Key: key, Value: strconv.FormatBool(enabled.(bool))})
+	}
+
+	// Keep a stable order, so that changes are applied in the same order every time.
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return &settings
+}
+
+// flattenTenantFeatureToggles returns the current values of the managed feature toggles, from the tenant's config.
+// Toggles whose setting is missing, or is not a boolean, are left out so that they show as a diff.
+func flattenTenantFeatureToggles(metadata *[]duplosdk.DuploKeyStringValue, managed map[string]interface{}) map[string]interface{} {
+	toggles := map[string]interface{}{}
+	if metadata == nil {
+		return toggles
+	}
+	for _, kv := range *metadata {
+		if _, ok := managed[kv.Key]; !ok {
+			continue
+		}
+		if enabled, err := strconv.ParseBool(kv.Value); err == nil {
+			toggles[kv.Key] = enabled
+		}
+	}
+	return toggles
+}
+
+// diffSuppressEquivalentTenantTimer ignores differences in how Duplo formats the same clean-up time.
+func diffSuppressEquivalentTenantTimer(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		o, err = time.Parse("2006-01-02T15:04:05", old)
+	}
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	return err == nil && o.Equal(n)
+}
@@ -16,7 +16,7 @@ import (
 
 // Resource for managing an AWS ElasticSearch instance
 func resourceTenantSecret() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Description: "`duplocloud_tenant_secret` manages a tenant secret in Duplo.",
 
 		ReadContext:   resourceTenantSecretRead,
@@ -100,6 +100,8 @@ func resourceTenantSecret() *schema.Resource {
 			},
 		},
 	}
+	addWriteOnlyArgument(resource.Schema, "data")
+	return resource
 }
 
 // READ resource
@@ -155,7 +157,9 @@ func resourceTenantSecretRead(ctx context.Context, d *schema.ResourceData, m int
 		d.SetId("") // object missing
 		return nil
 	}
-	d.Set("data", value.SecretString)
+	if !writeOnlyInUse(d, "data") {
+		d.Set("data", value.SecretString)
+	}
 	d.Set("version_id", value.VersionId)
 
 	log.Printf("[TRACE] resourceTenantSecretRead(%s, %s): end", tenantID, name)
@@ -166,9 +170,13 @@ func resourceTenantSecretRead(ctx context.Context, d *schema.ResourceData, m int
 func resourceTenantSecretCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var err error
 
+	data, err := writeOnlyValue(d, "data")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	duploObject := duplosdk.DuploAwsSecretCreateRequest{
 		Name:         d.Get("name_suffix").(string),
-		SecretString: d.Get("data").(string),
+		SecretString: data,
 	}
 
 	log.Printf("[TRACE] resourceTenantSecretCreate(%s): start", duploObject.Name)
@@ -213,15 +221,21 @@ func resourceTenantSecretUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	log.Printf("[TRACE] resourceTenantSecretUpdate(%s, %s): start", tenantID, name)
 
-	// Update the object with Duplo
-	c := m.(*duplosdk.Client)
-	rq := duplosdk.DuploAwsSecretUpdateRequest{
-		SecretId:     name,
-		SecretString: d.Get("data").(string),
-	}
-	_, err := c.TenantUpdateAwsSecret(tenantID, name, &rq)
-	if err != nil {
-		return diag.Errorf("error updating secret '%s': %s", id, err)
+	// Update the object with Duplo, unless only the arguments managed by the provider changed.
+	if d.HasChanges("data", "data_wo_version") {
+		data, err := writeOnlyValue(d, "data")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		c := m.(*duplosdk.Client)
+		rq := duplosdk.DuploAwsSecretUpdateRequest{
+			SecretId:     name,
+			SecretString: data,
+		}
+		_, err = c.TenantUpdateAwsSecret(tenantID, name, &rq)
+		if err != nil {
+			return diag.Errorf("error updating secret '%s': %s", id, err)
+		}
 	}
 
 	log.Printf("[TRACE] resourceTenantSecretUpdate(%s, %s): end", tenantID, name)
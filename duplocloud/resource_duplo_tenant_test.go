@@ -1,6 +1,7 @@
 package duplocloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,7 +9,10 @@ import (
 	"github.com/duplocloud/terraform-provider-duplocloud/internal/duplosdktest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccResource_duplocloud_tenant_basic(t *testing.T) {
@@ -105,3 +109,73 @@ func TestAccResource_duplocloud_tenant_basic(t *testing.T) {
 	// 	},
 	// })
 }
+
+func TestTenantTagChanges(t *testing.T) {
+	previous := &[]dsdk.DuploKeyStringValue{{Key: "owner", Value: "ops"}, {Key: "team", Value: "web"}, {Key: "cost", Value: "1"}}
+	desired := &[]dsdk.DuploKeyStringValue{{Key: "owner", Value: "ops"}, {Key: "team", Value: "api"}, {Key: "env", Value: "dev"}}
+
+	changes := tenantTagChanges("tid", previous, desired)
+	assert.Equal(t, []dsdk.DuploTenantConfigUpdateRequest{
+		{TenantID: "tid", Key: "team", Value: "api"},
+		{TenantID: "tid", Key: "env", Value: "dev"},
+		{TenantID: "tid", Key: "cost", Value: "1", State: "delete"},
+	}, changes)
+}
+
+func TestTenantCleanUpTimersChange(t *testing.T) {
+	timers := func(expiry, pause string) []interface{} {
+		return []interface{}{map[string]interface{}{"expiry_time": expiry, "pause_time": pause}}
+	}
+
+	assert.Nil(t, tenantCleanUpTimersChange("tid", nil, nil))
+	assert.Equal(t, &dsdk.DuploTenantCleanUpTimersUpdateRequest{TenantId: "tid", ExpiryTime: "2030-01-01T00:00:00Z"},
+		tenantCleanUpTimersChange("tid", nil, timers("2030-01-01T00:00:00Z", "")))
+	assert.Equal(t, &dsdk.DuploTenantCleanUpTimersUpdateRequest{TenantId: "tid", PauseTime: "2030-01-01T00:00:00Z", RemoveExpiryTime: true},
+		tenantCleanUpTimersChange("tid", timers("2029-01-01T00:00:00Z", ""), timers("", "2030-01-01T00:00:00Z")))
+	assert.Equal(t, &dsdk.DuploTenantCleanUpTimersUpdateRequest{TenantId: "tid", RemoveExpiryTime: true, RemovePauseTime: true},
+		tenantCleanUpTimersChange("tid", timers("2029-01-01T00:00:00Z", "2029-02-01T00:00:00Z"), nil))
+}
+
+func TestEmulator_TenantSettings(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTenant().Schema, map[string]interface{}{
+		"account_name": "testacc1a",
+		"plan_id":      "testacc1",
+		"feature_toggles": map[string]interface{}{
+			"block_public_access_to_s3": true,
+			"enforce_ssl_for_s3":        false,
+		},
+	})
+	d.SetId("v2/admin/TenantV2/" + Tenant_testacc1a)
+
+	diags := updateTenantSettings(c, Tenant_testacc1a, d)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	config, err := c.TenantGetConfig(Tenant_testacc1a)
+	require.Nil(t, err)
+	assert.Equal(t, []dsdk.DuploKeyStringValue{{Key: "block_public_access_to_s3", Value: "true"}, {Key: "enforce_ssl_for_s3", Value: "false"}},
+		*selectKeyValues(config.Metadata, []string{"block_public_access_to_s3", "enforce_ssl_for_s3"}))
+
+	// Settings that are not managed by the tenant resource are left alone.
+	assert.Len(t, *selectKeyValues(config.Metadata, []string{"foo", "xyz"}), 2)
+
+	// Portals that do not report the features of a tenant can still read it.
+	duplosdktest.InjectFaults(duplosdktest.EmuFault{
+		Method: "GET",
+		Route:  "/v3/features/tenant/:tenantId",
+		Status: 400,
+		Body:   `{"Message":"No action was found on the controller 'Features' that matches the request."}`,
+	})
+	diags = resourceTenantRead(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, map[string]interface{}{"block_public_access_to_s3": true, "enforce_ssl_for_s3": false}, d.Get("feature_toggles"))
+	assert.Empty(t, d.Get("features"))
+}
+
+func TestFlattenTenantFeatureToggles(t *testing.T) {
+	metadata := &[]dsdk.DuploKeyStringValue{{Key: "enforce_ssl_for_s3", Value: "True"}, {Key: "delete_protection", Value: "yes"}, {Key: "other", Value: "false"}}
+	managed := map[string]interface{}{"enforce_ssl_for_s3": true, "delete_protection": true, "missing": false}
+
+	assert.Equal(t, map[string]interface{}{"enforce_ssl_for_s3": true}, flattenTenantFeatureToggles(metadata, managed))
+}
@@ -0,0 +1,164 @@
+package duplocloud
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// k8sWorkloadTemplateSchema returns the pod template of a workload that Duplo runs as a service.
+func k8sWorkloadTemplateSchema(objectName string) *schema.Schema {
+	podTemplateFields := map[string]*schema.Schema{
+		"metadata": metadataSchema(objectName, false),
+		"spec": {
+			Type:        schema.TypeList,
+			Description: fmt.Sprintf("Spec of the pods managed by the %s.", objectName),
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: podSpecFields(true, false),
+			},
+		},
+	}
+
+	// Duplo services only allow restart_policy=Always, like DaemonSets.
+	podTemplateSpecSchema := podTemplateFields["spec"].Elem.(*schema.Resource)
+	restartPolicy := podTemplateSpecSchema.Schema["restart_policy"]
+	restartPolicy.Default = string(corev1.RestartPolicyAlways)
+	restartPolicy.ValidateFunc = validation.StringInSlice([]string{
+		string(corev1.RestartPolicyAlways),
+	}, false)
+	restartPolicy.Description = fmt.Sprintf("Restart policy for all containers within the pod. A %s only supports `Always`. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#restart-policy.", objectName)
+
+	// The first container is the main container of the Duplo service, which is named after it.
+	container := podTemplateSpecSchema.Schema["container"]
+	container.Required = true
+	container.Optional = false
+	container.MinItems = 1
+	container.Description = fmt.Sprintf("List of containers belonging to the pod. The first container is the main container of the %s, and must have the same name as the %s. More info: https://kubernetes.io/docs/concepts/containers/", objectName, objectName)
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "An object that describes the pod that will be created.",
+		Required:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: podTemplateFields,
+		},
+	}
+}
+
+func k8sWorkloadReplicasSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1,
+		ValidateFunc: validateNonNegativeInteger,
+		Description:  fmt.Sprintf("The number of desired replicas of the %s.", objectName),
+	}
+}
+
+func deploymentSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"replicas": k8sWorkloadReplicasSchema("deployment"),
+		"strategy": {
+			Type:        schema.TypeList,
+			Description: "The deployment strategy to use to replace existing pods with new ones.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:     schema.TypeString,
+						Optional: true,
+						Default:  string(appsv1.RollingUpdateDeploymentStrategyType),
+						ValidateFunc: validation.StringInSlice([]string{
+							string(appsv1.RollingUpdateDeploymentStrategyType),
+							string(appsv1.RecreateDeploymentStrategyType),
+						}, false),
+						Description: "Type of deployment. Can be `Recreate` or `RollingUpdate`.",
+					},
+					"rolling_update": {
+						Type:        schema.TypeList,
+						Description: "Rolling update config params. Present only if type = RollingUpdate.",
+						Optional:    true,
+						Computed:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"max_surge": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									Default:      "25%",
+									ValidateFunc: validation.StringMatch(intOrPercentRegexp, "must be a non-negative integer (e.g. 5) or a percentage (e.g. 10%)"),
+									Description:  "The maximum number of pods that can be scheduled above the desired number of pods. Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).",
+								},
+								"max_unavailable": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									Default:      "25%",
+									ValidateFunc: validation.StringMatch(intOrPercentRegexp, "must be a non-negative integer (e.g. 5) or a percentage (e.g. 10%)"),
+									Description:  "The maximum number of pods that can be unavailable during the update. Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"template": k8sWorkloadTemplateSchema("deployment"),
+	}
+}
+
+func statefulSetSpecFields() map[string]*schema.Schema {
+	claimSpecFields := persistentVolumeClaimSpecFields()
+	delete(claimSpecFields, "volume_name")
+	delete(claimSpecFields, "volume_mode")
+	claimSpecFields["access_modes"].MaxItems = 1
+	claimSpecFields["access_modes"].Description = "The access mode the volume should have. Duplo supports a single access mode per volume claim template."
+	delete(claimSpecFields["resources"].Elem.(*schema.Resource).Schema, "limits")
+
+	return map[string]*schema.Schema{
+		"replicas": k8sWorkloadReplicasSchema("stateful set"),
+		"template": k8sWorkloadTemplateSchema("stateful set"),
+		"volume_claim_template": {
+			Type:        schema.TypeList,
+			Description: "A list of claims that pods are allowed to reference. Each claim must be mounted by a `volume_mount` of the first container with the same name, which must come after its other volume mounts.",
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metadata": {
+						Type:        schema.TypeList,
+						Description: "Standard persistent volume claim's metadata.",
+						Required:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:         schema.TypeString,
+									Description:  "Name of the persistent volume claim template, which is also the name of the volume.",
+									Required:     true,
+									ValidateFunc: validateName,
+								},
+							},
+						},
+					},
+					"spec": {
+						Type:        schema.TypeList,
+						Description: "Spec of the persistent volume claims.",
+						Required:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: claimSpecFields,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,328 @@
+package duplocloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func horizontalPodAutoscalerSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"behavior": {
+			Type:        schema.TypeList,
+			Description: "The scaling behavior of the target in both up and down directions.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"scale_down": {
+						Type:        schema.TypeList,
+						Description: "The scaling policy for scaling down. If not set, the default is to allow scaling down to `min_replicas` pods, with a 300 second stabilization window.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: hpaScalingRulesFields(),
+						},
+					},
+					"scale_up": {
+						Type:        schema.TypeList,
+						Description: "The scaling policy for scaling up. If not set, the default is the highest of doubling the number of pods or adding 4 pods every 15 seconds, without a stabilization window.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: hpaScalingRulesFields(),
+						},
+					},
+				},
+			},
+		},
+		"max_replicas": {
+			Type:         schema.TypeInt,
+			Description:  "The upper limit for the number of pods that can be set by the autoscaler.",
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"metric": {
+			Type:        schema.TypeList,
+			Description: "The metrics used to calculate the desired replica count. The maximum replica count across all metrics is used. If not set, the default metric is 80% average CPU utilization.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: hpaMetricFields(),
+			},
+		},
+		"min_replicas": {
+			Type:         schema.TypeInt,
+			Description:  "The lower limit for the number of pods that can be set by the autoscaler.",
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"scale_target_ref": {
+			Type:        schema.TypeList,
+			Description: "The workload to scale, such as a deployment or a stateful set.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: hpaCrossVersionObjectReferenceFields(),
+			},
+		},
+	}
+}
+
+func hpaScalingRulesFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"policy": {
+			Type:        schema.TypeList,
+			Description: "The policies that may be used when scaling. At least one policy must be specified.",
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"period_seconds": {
+						Type:         schema.TypeInt,
+						Description:  "The window of time for which the policy should hold true. Must be between 1 and 1800 seconds.",
+						Required:     true,
+						ValidateFunc: validation.IntBetween(1, 1800),
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Description: "The type of the scaling policy. Valid values are `Pods` and `Percent`.",
+						Required:    true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(autoscalingv2.PodsScalingPolicy),
+							string(autoscalingv2.PercentScalingPolicy),
+						}, false),
+					},
+					"value": {
+						Type:         schema.TypeInt,
+						Description:  "The amount of change which is permitted by the policy.",
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		},
+		"select_policy": {
+			Type:        schema.TypeString,
+			Description: "Which policy should be used. Valid values are `Max`, `Min` and `Disabled`.",
+			Optional:    true,
+			Default:     string(autoscalingv2.MaxChangePolicySelect),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(autoscalingv2.MaxChangePolicySelect),
+				string(autoscalingv2.MinChangePolicySelect),
+				string(autoscalingv2.DisabledPolicySelect),
+			}, false),
+		},
+		"stabilization_window_seconds": {
+			Type:         schema.TypeInt,
+			Description:  "The number of seconds for which past recommendations should be considered while scaling. Must be between 0 and 3600 seconds. If not set, the default is 0 for scaling up and 300 for scaling down.",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 3600),
+		},
+	}
+}
+
+func hpaCrossVersionObjectReferenceFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"api_version": {
+			Type:        schema.TypeString,
+			Description: "The API version of the referent.",
+			Optional:    true,
+			Default:     "apps/v1",
+		},
+		"kind": {
+			Type:        schema.TypeString,
+			Description: "The kind of the referent, such as `Deployment` or `StatefulSet`.",
+			Required:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name of the referent.",
+			Required:    true,
+		},
+	}
+}
+
+func hpaMetricFields() map[string]*schema.Schema {
+	metricIdentifier := func() *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Description: "The metric to use.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "The name of the metric.",
+						Required:    true,
+					},
+					"selector": {
+						Type:        schema.TypeList,
+						Description: "A label selector that narrows down the metric. When not set, only the metric name is used to gather metrics.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: labelSelectorFields(true),
+						},
+					},
+				},
+			},
+		}
+	}
+	resourceName := func() *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "The name of the resource, such as `cpu` or `memory`.",
+			Required:    true,
+		}
+	}
+
+	return map[string]*schema.Schema{
+		"container_resource": {
+			Type:        schema.TypeList,
+			Description: "A resource metric of a single container in each pod of the target. Required when `type` is `ContainerResource`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"container": {
+						Type:        schema.TypeString,
+						Description: "The name of the container in the pods of the target.",
+						Required:    true,
+					},
+					"name":   resourceName(),
+					"target": hpaMetricTargetSchema(),
+				},
+			},
+		},
+		"external": {
+			Type:        schema.TypeList,
+			Description: "A global metric that is not associated with any Kubernetes object. Required when `type` is `External`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metric": metricIdentifier(),
+					"target": hpaMetricTargetSchema(),
+				},
+			},
+		},
+		"object": {
+			Type:        schema.TypeList,
+			Description: "A metric describing a single Kubernetes object, such as the hits per second of an ingress. Required when `type` is `Object`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"described_object": {
+						Type:        schema.TypeList,
+						Description: "The object that the metric describes.",
+						Required:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: hpaCrossVersionObjectReferenceFields(),
+						},
+					},
+					"metric": metricIdentifier(),
+					"target": hpaMetricTargetSchema(),
+				},
+			},
+		},
+		"pods": {
+			Type:        schema.TypeList,
+			Description: "A metric describing each pod of the target, such as the transactions processed per second. Required when `type` is `Pods`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metric": metricIdentifier(),
+					"target": hpaMetricTargetSchema(),
+				},
+			},
+		},
+		"resource": {
+			Type:        schema.TypeList,
+			Description: "A resource metric of the pods of the target, such as their CPU or memory. Required when `type` is `Resource`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name":   resourceName(),
+					"target": hpaMetricTargetSchema(),
+				},
+			},
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Description: "The type of the metric source. Valid values are `Resource`, `ContainerResource`, `Pods`, `Object` and `External`. The block of the same name must be set.",
+			Required:    true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(autoscalingv2.ResourceMetricSourceType),
+				string(autoscalingv2.ContainerResourceMetricSourceType),
+				string(autoscalingv2.PodsMetricSourceType),
+				string(autoscalingv2.ObjectMetricSourceType),
+				string(autoscalingv2.ExternalMetricSourceType),
+			}, false),
+		},
+	}
+}
+
+func hpaMetricTargetSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The target value of the metric.",
+		Required:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"average_utilization": {
+					Type:         schema.TypeInt,
+					Description:  "The target average of the resource metric across all pods, as a percentage of the requested value of the resource. Only valid for `Resource` and `ContainerResource` metrics, when `type` is `Utilization`.",
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"average_value": {
+					Type:             schema.TypeString,
+					Description:      "The target average of the metric across all pods, as a quantity. Required when `type` is `AverageValue`.",
+					Optional:         true,
+					ValidateFunc:     validateResourceQuantity,
+					DiffSuppressFunc: suppressEquivalentResourceQuantity,
+				},
+				"type": {
+					Type:        schema.TypeString,
+					Description: "The type of the target. Valid values are `Utilization`, `Value` and `AverageValue`.",
+					Required:    true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(autoscalingv2.UtilizationMetricType),
+						string(autoscalingv2.ValueMetricType),
+						string(autoscalingv2.AverageValueMetricType),
+					}, false),
+				},
+				"value": {
+					Type:             schema.TypeString,
+					Description:      "The target value of the metric, as a quantity. Required when `type` is `Value`.",
+					Optional:         true,
+					ValidateFunc:     validateResourceQuantity,
+					DiffSuppressFunc: suppressEquivalentResourceQuantity,
+				},
+			},
+		},
+	}
+}
+
+func horizontalPodAutoscalerStatusFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"current_replicas": {
+			Type:        schema.TypeInt,
+			Description: "The current number of pods managed by the autoscaler.",
+			Computed:    true,
+		},
+		"desired_replicas": {
+			Type:        schema.TypeInt,
+			Description: "The desired number of pods managed by the autoscaler, as last calculated.",
+			Computed:    true,
+		},
+	}
+}
@@ -0,0 +1,154 @@
+package duplocloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func networkPolicySpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"pod_selector": {
+			Type:        schema.TypeList,
+			Description: "A label query over the pods in the tenant that the policy applies to. An empty selector selects all of the pods in the tenant.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+		},
+		"policy_types": {
+			Type:        schema.TypeList,
+			Description: "The types of rules that the policy applies to. Valid values are `Ingress` and `Egress`.",
+			Required:    true,
+			MinItems:    1,
+			MaxItems:    2,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"Ingress", "Egress"}, false),
+			},
+		},
+		"ingress": {
+			Type:        schema.TypeList,
+			Description: "The ingress rules of the policy. Traffic is allowed to the selected pods if it matches at least one rule.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"from": {
+						Type:        schema.TypeList,
+						Description: "The sources that are allowed by the rule. When omitted, traffic from all sources is allowed.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: networkPolicyPeerFields(),
+						},
+					},
+					"port": {
+						Type:        schema.TypeList,
+						Description: "The ports that are allowed by the rule. When omitted, traffic to all ports is allowed.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: networkPolicyPortFields(),
+						},
+					},
+				},
+			},
+		},
+		"egress": {
+			Type:        schema.TypeList,
+			Description: "The egress rules of the policy. Traffic is allowed from the selected pods if it matches at least one rule.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"to": {
+						Type:        schema.TypeList,
+						Description: "The destinations that are allowed by the rule. When omitted, traffic to all destinations is allowed.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: networkPolicyPeerFields(),
+						},
+					},
+					"port": {
+						Type:        schema.TypeList,
+						Description: "The ports that are allowed by the rule. When omitted, traffic to all ports is allowed.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: networkPolicyPortFields(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func networkPolicyPeerFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"ip_block": {
+			Type:        schema.TypeList,
+			Description: "A CIDR range that is allowed. Conflicts with `namespace_selector` and `pod_selector`.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cidr": {
+						Type:         schema.TypeString,
+						Description:  "The CIDR range, such as `10.0.0.0/16`.",
+						Required:     true,
+						ValidateFunc: validation.IsCIDR,
+					},
+					"except": {
+						Type:        schema.TypeList,
+						Description: "The CIDR ranges inside of `cidr` that are not allowed.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.IsCIDR,
+						},
+					},
+				},
+			},
+		},
+		"namespace_selector": {
+			Type: schema.TypeList,
+			Description: "A label query over namespaces, such as those of other tenants. " +
+				"When combined with `pod_selector`, only the matching pods in the matching namespaces are allowed.",
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+		},
+		"pod_selector": {
+			Type: schema.TypeList,
+			Description: "A label query over pods. " +
+				"Unless combined with `namespace_selector`, only pods in the tenant are matched.",
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+		},
+	}
+}
+
+func networkPolicyPortFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"port": {
+			Type:        schema.TypeString,
+			Description: "The port number or name. When omitted, all ports are matched.",
+			Optional:    true,
+		},
+		"end_port": {
+			Type:         schema.TypeInt,
+			Description:  "When set, the rule matches the range of ports from `port` to `end_port`. `port` must be a number.",
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"protocol": {
+			Type:         schema.TypeString,
+			Description:  "The protocol of the port. Valid values are `TCP`, `UDP` and `SCTP`.",
+			Optional:     true,
+			Default:      "TCP",
+			ValidateFunc: validation.StringInSlice([]string{"TCP", "UDP", "SCTP"}, false),
+		},
+	}
+}
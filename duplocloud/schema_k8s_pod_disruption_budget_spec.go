@@ -0,0 +1,59 @@
+package duplocloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func podDisruptionBudgetSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"max_unavailable": {
+			Type:         schema.TypeString,
+			Description:  "The number or percentage of the selected pods that can be unavailable after an eviction. Conflicts with `min_available`.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(intOrPercentRegexp, ""),
+			ExactlyOneOf: []string{"spec.0.max_unavailable", "spec.0.min_available"},
+		},
+		"min_available": {
+			Type:         schema.TypeString,
+			Description:  "The number or percentage of the selected pods that must still be available after an eviction. Conflicts with `max_unavailable`.",
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(intOrPercentRegexp, ""),
+			ExactlyOneOf: []string{"spec.0.max_unavailable", "spec.0.min_available"},
+		},
+		"selector": {
+			Type:        schema.TypeList,
+			Description: "A label query over the pods whose evictions are managed by the disruption budget.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: labelSelectorFields(true),
+			},
+		},
+	}
+}
+
+func podDisruptionBudgetStatusFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"current_healthy": {
+			Type:        schema.TypeInt,
+			Description: "The current number of healthy pods.",
+			Computed:    true,
+		},
+		"desired_healthy": {
+			Type:        schema.TypeInt,
+			Description: "The minimum desired number of healthy pods.",
+			Computed:    true,
+		},
+		"disruptions_allowed": {
+			Type:        schema.TypeInt,
+			Description: "The number of pod disruptions that are currently allowed.",
+			Computed:    true,
+		},
+		"expected_pods": {
+			Type:        schema.TypeInt,
+			Description: "The total number of pods counted by the disruption budget.",
+			Computed:    true,
+		},
+	}
+}
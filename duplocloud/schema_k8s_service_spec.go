@@ -0,0 +1,161 @@
+package duplocloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func serviceSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cluster_ip": {
+			Type:        schema.TypeString,
+			Description: "The IP address of the service. It is usually assigned randomly by the master. If an address is specified manually and is not in use by others, it will be allocated to the service; otherwise, creation of the service will fail. `None` can be specified for headless services when proxying is not required. Ignored if type is `ExternalName`. More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies",
+			Optional:    true,
+			ForceNew:    true,
+			Computed:    true,
+		},
+		"external_name": {
+			Type:        schema.TypeString,
+			Description: "The external reference that kubedns or equivalent will return as a CNAME record for this service. No proxying will be involved. Must be a valid DNS name and requires `type` to be `ExternalName`.",
+			Optional:    true,
+		},
+		"external_traffic_policy": {
+			Type:        schema.TypeString,
+			Description: "Denotes if this service desires to route external traffic to node-local or cluster-wide endpoints. `Local` preserves the client source IP and avoids a second hop for LoadBalancer and NodePort type services, but risks potentially imbalanced traffic spreading. `Cluster` obscures the client source IP and may cause a second hop to another node, but should have good overall load-spreading. More info: https://kubernetes.io/docs/tasks/access-application-cluster/create-external-load-balancer/#preserving-the-client-source-ip",
+			Optional:    true,
+			Computed:    true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(corev1.ServiceExternalTrafficPolicyTypeLocal),
+				string(corev1.ServiceExternalTrafficPolicyTypeCluster),
+			}, false),
+		},
+		"load_balancer_source_ranges": {
+			Type:        schema.TypeSet,
+			Description: "If specified and supported by the platform, this will restrict traffic through the cloud-provider load-balancer will be restricted to the specified client IPs. This field will be ignored if the cloud-provider does not support the feature. More info: https://kubernetes.io/docs/tasks/access-application-cluster/configure-cloud-provider-firewall/",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsCIDR,
+			},
+			Set: schema.HashString,
+		},
+		"port": {
+			Type:        schema.TypeList,
+			Description: "The list of ports that are exposed by this service. More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies",
+			Optional:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"app_protocol": {
+						Type:        schema.TypeString,
+						Description: "The application protocol for this port. This field follows standard Kubernetes label syntax. Un-prefixed names are reserved for IANA standard service names. Non-standard protocols should use prefixed names such as `mycompany.com/my-custom-protocol`.",
+						Optional:    true,
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Description: "The name of this port within the service. All ports within the service must have unique names. Optional if only one ServicePort is defined on this service.",
+						Optional:    true,
+					},
+					"node_port": {
+						Type:         schema.TypeInt,
+						Description:  "The port on each node on which this service is exposed when `type` is `NodePort` or `LoadBalancer`. Usually assigned by the system. If specified, it will be allocated to the service if unused or else creation of the service will fail. More info: https://kubernetes.io/docs/concepts/services-networking/service/#type-nodeport",
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IntBetween(0, 65535),
+					},
+					"port": {
+						Type:         schema.TypeInt,
+						Description:  "The port that will be exposed by this service.",
+						Required:     true,
+						ValidateFunc: validation.IsPortNumber,
+					},
+					"protocol": {
+						Type:        schema.TypeString,
+						Description: "The IP protocol for this port. Supports `TCP`, `UDP` and `SCTP`.",
+						Optional:    true,
+						Default:     string(corev1.ProtocolTCP),
+						ValidateFunc: validation.StringInSlice([]string{
+							string(corev1.ProtocolTCP),
+							string(corev1.ProtocolUDP),
+							string(corev1.ProtocolSCTP),
+						}, false),
+					},
+					"target_port": {
+						Type:        schema.TypeString,
+						Description: "Number or name of the port to access on the pods targeted by the service. Number must be in the range 1 to 65535. This field is ignored for services with `cluster_ip = \"None\"`, and defaults to the value of `port`. More info: https://kubernetes.io/docs/concepts/services-networking/service/#defining-a-service",
+						Optional:    true,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"publish_not_ready_addresses": {
+			Type:        schema.TypeBool,
+			Description: "When set to true, indicates that DNS implementations must publish the `notReadyAddresses` of subsets for the Endpoints associated with the Service. The default value is `false`. The primary use case for setting this field is to use a StatefulSet's Headless Service to propagate SRV records for its Pods without respect to their readiness for purpose of peer discovery.",
+			Optional:    true,
+			Default:     false,
+		},
+		"selector": {
+			Type:        schema.TypeMap,
+			Description: "Route service traffic to pods with label keys and values matching this selector. Only applies to types `ClusterIP`, `NodePort`, and `LoadBalancer`. More info: https://kubernetes.io/docs/concepts/services-networking/service/",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"session_affinity": {
+			Type:        schema.TypeString,
+			Description: "Used to maintain session affinity. Supports `ClientIP` and `None`. More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies",
+			Optional:    true,
+			Default:     string(corev1.ServiceAffinityNone),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(corev1.ServiceAffinityClientIP),
+				string(corev1.ServiceAffinityNone),
+			}, false),
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Description: "Determines how the service is exposed. Valid options are `ExternalName`, `ClusterIP`, `NodePort`, and `LoadBalancer`. `ExternalName` maps to the specified `external_name`. More info: https://kubernetes.io/docs/concepts/services-networking/service/#publishing-services---service-types",
+			Optional:    true,
+			Default:     string(corev1.ServiceTypeClusterIP),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(corev1.ServiceTypeClusterIP),
+				string(corev1.ServiceTypeExternalName),
+				string(corev1.ServiceTypeNodePort),
+				string(corev1.ServiceTypeLoadBalancer),
+			}, false),
+		},
+	}
+}
+
+func serviceStatusFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"load_balancer": {
+			Type:        schema.TypeList,
+			Description: "The current status of the load-balancer, if one is present.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ingress": {
+						Type:        schema.TypeList,
+						Description: "The ingress points for the load-balancer.",
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"hostname": {
+									Type:        schema.TypeString,
+									Description: "The DNS name of the ingress point, for load-balancers that are DNS based.",
+									Computed:    true,
+								},
+								"ip": {
+									Type:        schema.TypeString,
+									Description: "The IP address of the ingress point, for load-balancers that are IP based.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,329 @@
+package duplocloud
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func expandHorizontalPodAutoscalerSpec(s []interface{}) (autoscalingv2.HorizontalPodAutoscalerSpec, error) {
+	obj := autoscalingv2.HorizontalPodAutoscalerSpec{}
+	if len(s) == 0 || s[0] == nil {
+		return obj, nil
+	}
+	in := s[0].(map[string]interface{})
+
+	if v, ok := in["behavior"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		behavior := v[0].(map[string]interface{})
+		obj.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleUp:   expandHPAScalingRules(behavior["scale_up"].([]interface{})),
+			ScaleDown: expandHPAScalingRules(behavior["scale_down"].([]interface{})),
+		}
+	}
+	obj.MaxReplicas = int32(in["max_replicas"].(int))
+	if v, ok := in["metric"].([]interface{}); ok && len(v) > 0 {
+		metrics, err := expandHPAMetrics(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Metrics = metrics
+	}
+	if v, ok := in["min_replicas"].(int); ok && v > 0 {
+		obj.MinReplicas = ptrToInt32(int32(v))
+	}
+	if v, ok := in["scale_target_ref"].([]interface{}); ok && len(v) > 0 {
+		obj.ScaleTargetRef = expandHPACrossVersionObjectReference(v)
+	}
+
+	return obj, nil
+}
+
+func expandHPAScalingRules(l []interface{}) *autoscalingv2.HPAScalingRules {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+
+	obj := &autoscalingv2.HPAScalingRules{}
+	if v, ok := in["select_policy"].(string); ok && v != "" {
+		policy := autoscalingv2.ScalingPolicySelect(v)
+		obj.SelectPolicy = &policy
+	}
+	// Zero is left unset, so that Kubernetes applies the default window of the scaling direction.
+	if v, ok := in["stabilization_window_seconds"].(int); ok && v > 0 {
+		obj.StabilizationWindowSeconds = ptrToInt32(int32(v))
+	}
+	if v, ok := in["policy"].([]interface{}); ok {
+		for _, p := range v {
+			if p == nil {
+				continue
+			}
+			policy := p.(map[string]interface{})
+			obj.Policies = append(obj.Policies, autoscalingv2.HPAScalingPolicy{
+				Type:          autoscalingv2.HPAScalingPolicyType(policy["type"].(string)),
+				Value:         int32(policy["value"].(int)),
+				PeriodSeconds: int32(policy["period_seconds"].(int)),
+			})
+		}
+	}
+	return obj
+}
+
+func expandHPACrossVersionObjectReference(l []interface{}) autoscalingv2.CrossVersionObjectReference {
+	if len(l) == 0 || l[0] == nil {
+		return autoscalingv2.CrossVersionObjectReference{}
+	}
+	in := l[0].(map[string]interface{})
+	return autoscalingv2.CrossVersionObjectReference{
+		APIVersion: in["api_version"].(string),
+		Kind:       in["kind"].(string),
+		Name:       in["name"].(string),
+	}
+}
+
+func expandHPAMetrics(l []interface{}) ([]autoscalingv2.MetricSpec, error) {
+	metrics := make([]autoscalingv2.MetricSpec, 0, len(l))
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+		in := v.(map[string]interface{})
+		metric := autoscalingv2.MetricSpec{
+			Type: autoscalingv2.MetricSourceType(in["type"].(string)),
+		}
+
+		if v, ok := in["container_resource"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			source := v[0].(map[string]interface{})
+			target, err := expandHPAMetricTarget(source["target"].([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			metric.ContainerResource = &autoscalingv2.ContainerResourceMetricSource{
+				Container: source["container"].(string),
+				Name:      corev1.ResourceName(source["name"].(string)),
+				Target:    target,
+			}
+		}
+		if v, ok := in["external"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			source := v[0].(map[string]interface{})
+			target, err := expandHPAMetricTarget(source["target"].([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			metric.External = &autoscalingv2.ExternalMetricSource{
+				Metric: expandHPAMetricIdentifier(source["metric"].([]interface{})),
+				Target: target,
+			}
+		}
+		if v, ok := in["object"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			source := v[0].(map[string]interface{})
+			target, err := expandHPAMetricTarget(source["target"].([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			metric.Object = &autoscalingv2.ObjectMetricSource{
+				DescribedObject: expandHPACrossVersionObjectReference(source["described_object"].([]interface{})),
+				Metric:          expandHPAMetricIdentifier(source["metric"].([]interface{})),
+				Target:          target,
+			}
+		}
+		if v, ok := in["pods"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			source := v[0].(map[string]interface{})
+			target, err := expandHPAMetricTarget(source["target"].([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			metric.Pods = &autoscalingv2.PodsMetricSource{
+				Metric: expandHPAMetricIdentifier(source["metric"].([]interface{})),
+				Target: target,
+			}
+		}
+		if v, ok := in["resource"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			source := v[0].(map[string]interface{})
+			target, err := expandHPAMetricTarget(source["target"].([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			metric.Resource = &autoscalingv2.ResourceMetricSource{
+				Name:   corev1.ResourceName(source["name"].(string)),
+				Target: target,
+			}
+		}
+
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
+
+func expandHPAMetricIdentifier(l []interface{}) autoscalingv2.MetricIdentifier {
+	obj := autoscalingv2.MetricIdentifier{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	obj.Name = in["name"].(string)
+	if v, ok := in["selector"].([]interface{}); ok && len(v) > 0 {
+		obj.Selector = expandLabelSelector(v)
+	}
+	return obj
+}
+
+func expandHPAMetricTarget(l []interface{}) (autoscalingv2.MetricTarget, error) {
+	obj := autoscalingv2.MetricTarget{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	obj.Type = autoscalingv2.MetricTargetType(in["type"].(string))
+	if v, ok := in["average_utilization"].(int); ok && v > 0 {
+		obj.AverageUtilization = ptrToInt32(int32(v))
+	}
+	if v, ok := in["average_value"].(string); ok && v != "" {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.AverageValue = &q
+	}
+	if v, ok := in["value"].(string); ok && v != "" {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Value = &q
+	}
+	return obj, nil
+}
+
+func flattenHorizontalPodAutoscalerSpec(in autoscalingv2.HorizontalPodAutoscalerSpec) []interface{} {
+	att := make(map[string]interface{})
+
+	if in.Behavior != nil {
+		behavior := map[string]interface{}{}
+		if in.Behavior.ScaleUp != nil {
+			behavior["scale_up"] = flattenHPAScalingRules(in.Behavior.ScaleUp)
+		}
+		if in.Behavior.ScaleDown != nil {
+			behavior["scale_down"] = flattenHPAScalingRules(in.Behavior.ScaleDown)
+		}
+		att["behavior"] = []interface{}{behavior}
+	}
+	att["max_replicas"] = int(in.MaxReplicas)
+	if len(in.Metrics) > 0 {
+		att["metric"] = flattenHPAMetrics(in.Metrics)
+	}
+	att["min_replicas"] = 1
+	if in.MinReplicas != nil {
+		att["min_replicas"] = int(*in.MinReplicas)
+	}
+	att["scale_target_ref"] = flattenHPACrossVersionObjectReference(in.ScaleTargetRef)
+
+	return []interface{}{att}
+}
+
+func flattenHPAScalingRules(in *autoscalingv2.HPAScalingRules) []interface{} {
+	att := make(map[string]interface{})
+
+	policies := make([]interface{}, 0, len(in.Policies))
+	for _, p := range in.Policies {
+		policies = append(policies, map[string]interface{}{
+			"type":           string(p.Type),
+			"value":          int(p.Value),
+			"period_seconds": int(p.PeriodSeconds),
+		})
+	}
+	att["policy"] = policies
+	if in.SelectPolicy != nil {
+		att["select_policy"] = string(*in.SelectPolicy)
+	}
+	if in.StabilizationWindowSeconds != nil {
+		att["stabilization_window_seconds"] = int(*in.StabilizationWindowSeconds)
+	}
+
+	return []interface{}{att}
+}
+
+func flattenHPACrossVersionObjectReference(in autoscalingv2.CrossVersionObjectReference) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"api_version": in.APIVersion,
+		"kind":        in.Kind,
+		"name":        in.Name,
+	}}
+}
+
+func flattenHPAMetrics(in []autoscalingv2.MetricSpec) []interface{} {
+	metrics := make([]interface{}, 0, len(in))
+	for _, metric := range in {
+		att := map[string]interface{}{
+			"type": string(metric.Type),
+		}
+		if v := metric.ContainerResource; v != nil {
+			att["container_resource"] = []interface{}{map[string]interface{}{
+				"container": v.Container,
+				"name":      string(v.Name),
+				"target":    flattenHPAMetricTarget(v.Target),
+			}}
+		}
+		if v := metric.External; v != nil {
+			att["external"] = []interface{}{map[string]interface{}{
+				"metric": flattenHPAMetricIdentifier(v.Metric),
+				"target": flattenHPAMetricTarget(v.Target),
+			}}
+		}
+		if v := metric.Object; v != nil {
+			att["object"] = []interface{}{map[string]interface{}{
+				"described_object": flattenHPACrossVersionObjectReference(v.DescribedObject),
+				"metric":           flattenHPAMetricIdentifier(v.Metric),
+				"target":           flattenHPAMetricTarget(v.Target),
+			}}
+		}
+		if v := metric.Pods; v != nil {
+			att["pods"] = []interface{}{map[string]interface{}{
+				"metric": flattenHPAMetricIdentifier(v.Metric),
+				"target": flattenHPAMetricTarget(v.Target),
+			}}
+		}
+		if v := metric.Resource; v != nil {
+			att["resource"] = []interface{}{map[string]interface{}{
+				"name":   string(v.Name),
+				"target": flattenHPAMetricTarget(v.Target),
+			}}
+		}
+		metrics = append(metrics, att)
+	}
+	return metrics
+}
+
+func flattenHPAMetricIdentifier(in autoscalingv2.MetricIdentifier) []interface{} {
+	att := map[string]interface{}{
+		"name": in.Name,
+	}
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
+	return []interface{}{att}
+}
+
+func flattenHPAMetricTarget(in autoscalingv2.MetricTarget) []interface{} {
+	att := map[string]interface{}{
+		"type": string(in.Type),
+	}
+	if in.AverageUtilization != nil {
+		att["average_utilization"] = int(*in.AverageUtilization)
+	}
+	if in.AverageValue != nil {
+		att["average_value"] = in.AverageValue.String()
+	}
+	if in.Value != nil {
+		att["value"] = in.Value.String()
+	}
+	return []interface{}{att}
+}
+
+func flattenHorizontalPodAutoscalerStatus(in autoscalingv2.HorizontalPodAutoscalerStatus) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"current_replicas": int(in.CurrentReplicas),
+		"desired_replicas": int(in.DesiredReplicas),
+	}}
+}
@@ -0,0 +1,313 @@
+package duplocloud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+	"github.com/ucarion/jcs"
+	"gopkg.in/yaml.v3"
+)
+
+// expandK8sManifest parses a YAML or JSON document that holds exactly one kubernetes object.
+func expandK8sManifest(encoded string) (duplosdk.DuploK8sManifest, error) {
+	var doc interface{}
+	decoder := yaml.NewDecoder(strings.NewReader(encoded))
+	if err := decoder.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("the manifest is empty")
+		}
+		return nil, fmt.Errorf("the manifest is not valid YAML or JSON: %s", err)
+	}
+	var extra interface{}
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("the manifest must contain exactly one object")
+	}
+
+	// Round-trip through JSON, so that the values have the same types as the ones read from the API.
+	buff, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("the manifest cannot be converted to JSON: %s", err)
+	}
+	obj := duplosdk.DuploK8sManifest{}
+	if err := json.Unmarshal(buff, &obj); err != nil {
+		return nil, fmt.Errorf("the manifest must be an object: %s", err)
+	}
+
+	if obj.ApiVersion() == "" || obj.Kind() == "" || obj.Name() == "" {
+		return nil, fmt.Errorf("the manifest must have an apiVersion, a kind and a metadata.name")
+	}
+	return obj, nil
+}
+
+// canonicalizeK8sManifest converts a YAML or JSON manifest to canonical JSON, so that formatting
+// and key order never show as a diff.  Invalid manifests are returned unchanged.
+func canonicalizeK8sManifest(encoded string) string {
+	obj, err := expandK8sManifest(encoded)
+	if err != nil {
+		return encoded
+	}
+	canonical, err := jcs.Format(map[string]interface{}(obj))
+	if err != nil {
+		return encoded
+	}
+	return canonical
+}
+
+func validateK8sManifest(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := expandK8sManifest(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
+
+// k8sManifestManagedFields merges the fields that a field manager owns through server-side apply,
+// in the FieldsV1 format.  Nil is returned if the field manager owns nothing.
+func k8sManifestManagedFields(obj duplosdk.DuploK8sManifest, fieldManager string) map[string]interface{} {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	entries, _ := metadata["managedFields"].([]interface{})
+
+	var owned map[string]interface{}
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok || entry["manager"] != fieldManager || entry["operation"] != "Apply" {
+			continue
+		}
+		if fields, ok := entry["fieldsV1"].(map[string]interface{}); ok {
+			if owned == nil {
+				owned = map[string]interface{}{}
+			}
+			mergeK8sManagedFields(owned, fields)
+		}
+	}
+	return owned
+}
+
+func mergeK8sManagedFields(to, from map[string]interface{}) {
+	for k, v := range from {
+		sub, _ := v.(map[string]interface{})
+		if existing, ok := to[k].(map[string]interface{}); ok && sub != nil {
+			mergeK8sManagedFields(existing, sub)
+		} else {
+			to[k] = v
+		}
+	}
+}
+
+// projectK8sManagedFields keeps only the parts of an object that are listed in a FieldsV1 set.
+//
+// See https://kubernetes.io/docs/reference/using-api/server-side-apply/#field-management
+func projectK8sManagedFields(obj interface{}, fields map[string]interface{}) interface{} {
+	// A leaf, or a field that is owned as a whole.
+	if len(fields) == 0 || (len(fields) == 1 && fields["."] != nil) {
+		return obj
+	}
+
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, sub := range fields {
+			if !strings.HasPrefix(k, "f:") {
+				continue
+			}
+			name := k[2:]
+			if value, ok := v[name]; ok {
+				subFields, _ := sub.(map[string]interface{})
+				out[name] = projectK8sManagedFields(value, subFields)
+			}
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for i, item := range v {
+			for k, sub := range fields {
+				if k8sManagedFieldsMatchesItem(k, i, item) {
+					subFields, _ := sub.(map[string]interface{})
+					out = append(out, projectK8sManagedFields(item, subFields))
+					break
+				}
+			}
+		}
+		return out
+	}
+
+	return obj
+}
+
+// k8sManagedFieldsMatchesItem tells if a FieldsV1 key selects a list item, by its keys ("k:"), its value ("v:") or its index ("i:").
+func k8sManagedFieldsMatchesItem(key string, index int, item interface{}) bool {
+	switch {
+	case strings.HasPrefix(key, "k:"):
+		var keys map[string]interface{}
+		if err := json.Unmarshal([]byte(key[2:]), &keys); err != nil {
+			return false
+		}
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range keys {
+			if !reflect.DeepEqual(m[k], v) {
+				return false
+			}
+		}
+		return true
+	case strings.HasPrefix(key, "v:"):
+		var value interface{}
+		if err := json.Unmarshal([]byte(key[2:]), &value); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(item, value)
+	case strings.HasPrefix(key, "i:"):
+		i, err := strconv.Atoi(key[2:])
+		return err == nil && i == index
+	}
+	return false
+}
+
+// projectK8sManifestShape keeps only the parts of an object that are present in another object.
+//
+// It is used instead of projectK8sManagedFields when the API does not report the managed fields.
+func projectK8sManifestShape(obj, shape interface{}) interface{} {
+	switch s := shape.(type) {
+	case map[string]interface{}:
+		v, ok := obj.(map[string]interface{})
+		if !ok {
+			return obj
+		}
+		out := map[string]interface{}{}
+		for k, sub := range s {
+			if value, ok := v[k]; ok {
+				out[k] = projectK8sManifestShape(value, sub)
+			}
+		}
+		return out
+
+	case []interface{}:
+		v, ok := obj.([]interface{})
+		if !ok {
+			return obj
+		}
+		out := make([]interface{}, 0, len(v))
+		for i, item := range v {
+			if i < len(s) {
+				item = projectK8sManifestShape(item, s[i])
+			}
+			out = append(out, item)
+		}
+		return out
+	}
+
+	return obj
+}
+
+// flattenK8sManifest returns the part of a live object that is managed by Terraform.
+//
+// When the field manager owns fields of the object, only those are kept, so that fields set by
+// controllers or defaulted by the API server never show as a diff.  Otherwise, the object is
+// projected on the previously applied manifest.  If there is none - such as after an import -
+// the object is returned without its server-populated fields.
+func flattenK8sManifest(obj duplosdk.DuploK8sManifest, fieldManager string, previous duplosdk.DuploK8sManifest) duplosdk.DuploK8sManifest {
+	var out map[string]interface{}
+	if owned := k8sManifestManagedFields(obj, fieldManager); owned != nil {
+		out, _ = projectK8sManagedFields(map[string]interface{}(obj), owned).(map[string]interface{})
+	} else if previous != nil {
+		out, _ = projectK8sManifestShape(map[string]interface{}(obj), map[string]interface{}(previous)).(map[string]interface{})
+	} else {
+		return stripK8sServerFields(obj)
+	}
+
+	// The identity of the object is never part of the managed fields.
+	out["apiVersion"] = obj.ApiVersion()
+	out["kind"] = obj.Kind()
+	metadata, _ := out["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+		out["metadata"] = metadata
+	}
+	metadata["name"] = obj.Name()
+	if previousMetadata, ok := previous["metadata"].(map[string]interface{}); ok && previousMetadata["namespace"] != nil {
+		liveMetadata, _ := obj["metadata"].(map[string]interface{})
+		metadata["namespace"] = liveMetadata["namespace"]
+	}
+
+	return out
+}
+
+// stripK8sServerFields returns a copy of an object without the fields that are populated by the API server.
+func stripK8sServerFields(obj duplosdk.DuploK8sManifest) duplosdk.DuploK8sManifest {
+	out := duplosdk.DuploK8sManifest{}
+	for k, v := range obj {
+		if k != "status" {
+			out[k] = v
+		}
+	}
+
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		m := map[string]interface{}{}
+		for k, v := range metadata {
+			switch k {
+			case "managedFields", "uid", "resourceVersion", "generation", "creationTimestamp", "selfLink", "namespace",
+				"deletionTimestamp", "deletionGracePeriodSeconds":
+				continue
+			}
+			m[k] = v
+		}
+		if annotations, ok := m["annotations"].(map[string]interface{}); ok {
+			a := map[string]interface{}{}
+			for k, v := range annotations {
+				if k != "kubectl.kubernetes.io/last-applied-configuration" {
+					a[k] = v
+				}
+			}
+			if len(a) > 0 {
+				m["annotations"] = a
+			} else {
+				delete(m, "annotations")
+			}
+		}
+		out["metadata"] = m
+	}
+
+	return out
+}
+
+// k8sManifestLookupField returns the string value of a field of an object, given a dotted path such as `status.phase`.
+// List items are selected by their index, such as `status.containerStatuses.0.ready`.
+func k8sManifestLookupField(obj interface{}, path string) (string, bool) {
+	for _, part := range strings.Split(path, ".") {
+		switch v := obj.(type) {
+		case map[string]interface{}:
+			value, ok := v[part]
+			if !ok {
+				return "", false
+			}
+			obj = value
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", false
+			}
+			obj = v[i]
+		default:
+			return "", false
+		}
+	}
+
+	switch v := obj.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	default:
+		// Numbers and booleans are formatted the same way as in YAML, and objects as JSON.
+		buff, _ := json.Marshal(v)
+		return string(buff), true
+	}
+}
@@ -0,0 +1,184 @@
+package duplocloud
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func expandNetworkPolicySpec(s []interface{}) networkingv1.NetworkPolicySpec {
+	obj := networkingv1.NetworkPolicySpec{}
+	if len(s) == 0 || s[0] == nil {
+		return obj
+	}
+	in := s[0].(map[string]interface{})
+
+	// An empty pod selector is valid, and selects every pod in the namespace.
+	if v, ok := in["pod_selector"].([]interface{}); ok {
+		obj.PodSelector = *expandLabelSelector(v)
+	}
+	if v, ok := in["policy_types"].([]interface{}); ok {
+		for _, t := range v {
+			obj.PolicyTypes = append(obj.PolicyTypes, networkingv1.PolicyType(t.(string)))
+		}
+	}
+	if v, ok := in["ingress"].([]interface{}); ok && len(v) > 0 {
+		obj.Ingress = make([]networkingv1.NetworkPolicyIngressRule, 0, len(v))
+		for _, r := range v {
+			rule := networkingv1.NetworkPolicyIngressRule{}
+			if r != nil {
+				m := r.(map[string]interface{})
+				rule.From = expandNetworkPolicyPeers(m["from"].([]interface{}))
+				rule.Ports = expandNetworkPolicyPorts(m["port"].([]interface{}))
+			}
+			obj.Ingress = append(obj.Ingress, rule)
+		}
+	}
+	if v, ok := in["egress"].([]interface{}); ok && len(v) > 0 {
+		obj.Egress = make([]networkingv1.NetworkPolicyEgressRule, 0, len(v))
+		for _, r := range v {
+			rule := networkingv1.NetworkPolicyEgressRule{}
+			if r != nil {
+				m := r.(map[string]interface{})
+				rule.To = expandNetworkPolicyPeers(m["to"].([]interface{}))
+				rule.Ports = expandNetworkPolicyPorts(m["port"].([]interface{}))
+			}
+			obj.Egress = append(obj.Egress, rule)
+		}
+	}
+
+	return obj
+}
+
+func expandNetworkPolicyPeers(l []interface{}) []networkingv1.NetworkPolicyPeer {
+	if len(l) == 0 {
+		return nil
+	}
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(l))
+	for _, v := range l {
+		peer := networkingv1.NetworkPolicyPeer{}
+		if v != nil {
+			in := v.(map[string]interface{})
+			if b, ok := in["ip_block"].([]interface{}); ok && len(b) > 0 && b[0] != nil {
+				block := b[0].(map[string]interface{})
+				peer.IPBlock = &networkingv1.IPBlock{CIDR: block["cidr"].(string)}
+				for _, e := range block["except"].([]interface{}) {
+					peer.IPBlock.Except = append(peer.IPBlock.Except, e.(string))
+				}
+			}
+			// An empty selector block is kept, because it matches everything rather than nothing.
+			if s, ok := in["namespace_selector"].([]interface{}); ok && len(s) > 0 {
+				peer.NamespaceSelector = expandLabelSelector(s)
+			}
+			if s, ok := in["pod_selector"].([]interface{}); ok && len(s) > 0 {
+				peer.PodSelector = expandLabelSelector(s)
+			}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+func expandNetworkPolicyPorts(l []interface{}) []networkingv1.NetworkPolicyPort {
+	if len(l) == 0 {
+		return nil
+	}
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(l))
+	for _, v := range l {
+		port := networkingv1.NetworkPolicyPort{}
+		if v != nil {
+			in := v.(map[string]interface{})
+			if p, ok := in["port"].(string); ok && p != "" {
+				val := intstr.Parse(p)
+				port.Port = &val
+			}
+			if p, ok := in["end_port"].(int); ok && p != 0 {
+				port.EndPort = ptrToInt32(int32(p))
+			}
+			if p, ok := in["protocol"].(string); ok && p != "" {
+				protocol := corev1.Protocol(p)
+				port.Protocol = &protocol
+			}
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func flattenNetworkPolicySpec(in networkingv1.NetworkPolicySpec) []interface{} {
+	att := make(map[string]interface{})
+
+	att["pod_selector"] = flattenLabelSelector(&in.PodSelector)
+
+	policyTypes := make([]interface{}, 0, len(in.PolicyTypes))
+	for _, t := range in.PolicyTypes {
+		policyTypes = append(policyTypes, string(t))
+	}
+	att["policy_types"] = policyTypes
+
+	if len(in.Ingress) > 0 {
+		rules := make([]interface{}, 0, len(in.Ingress))
+		for _, rule := range in.Ingress {
+			rules = append(rules, map[string]interface{}{
+				"from": flattenNetworkPolicyPeers(rule.From),
+				"port": flattenNetworkPolicyPorts(rule.Ports),
+			})
+		}
+		att["ingress"] = rules
+	}
+	if len(in.Egress) > 0 {
+		rules := make([]interface{}, 0, len(in.Egress))
+		for _, rule := range in.Egress {
+			rules = append(rules, map[string]interface{}{
+				"to":   flattenNetworkPolicyPeers(rule.To),
+				"port": flattenNetworkPolicyPorts(rule.Ports),
+			})
+		}
+		att["egress"] = rules
+	}
+
+	return []interface{}{att}
+}
+
+func flattenNetworkPolicyPeers(in []networkingv1.NetworkPolicyPeer) []interface{} {
+	peers := make([]interface{}, 0, len(in))
+	for _, peer := range in {
+		m := make(map[string]interface{})
+		if peer.IPBlock != nil {
+			except := make([]interface{}, 0, len(peer.IPBlock.Except))
+			for _, e := range peer.IPBlock.Except {
+				except = append(except, e)
+			}
+			m["ip_block"] = []interface{}{map[string]interface{}{
+				"cidr":   peer.IPBlock.CIDR,
+				"except": except,
+			}}
+		}
+		if peer.NamespaceSelector != nil {
+			m["namespace_selector"] = flattenLabelSelector(peer.NamespaceSelector)
+		}
+		if peer.PodSelector != nil {
+			m["pod_selector"] = flattenLabelSelector(peer.PodSelector)
+		}
+		peers = append(peers, m)
+	}
+	return peers
+}
+
+func flattenNetworkPolicyPorts(in []networkingv1.NetworkPolicyPort) []interface{} {
+	ports := make([]interface{}, 0, len(in))
+	for _, port := range in {
+		m := make(map[string]interface{})
+		if port.Port != nil {
+			m["port"] = port.Port.String()
+		}
+		if port.EndPort != nil {
+			m["end_port"] = int(*port.EndPort)
+		}
+		if port.Protocol != nil {
+			m["protocol"] = string(*port.Protocol)
+		}
+		ports = append(ports, m)
+	}
+	return ports
+}
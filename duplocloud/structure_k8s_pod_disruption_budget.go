@@ -0,0 +1,53 @@
+package duplocloud
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func expandPodDisruptionBudgetSpec(s []interface{}) policyv1.PodDisruptionBudgetSpec {
+	obj := policyv1.PodDisruptionBudgetSpec{}
+	if len(s) == 0 || s[0] == nil {
+		return obj
+	}
+	in := s[0].(map[string]interface{})
+
+	if v, ok := in["max_unavailable"].(string); ok && v != "" {
+		val := intstr.Parse(v)
+		obj.MaxUnavailable = &val
+	}
+	if v, ok := in["min_available"].(string); ok && v != "" {
+		val := intstr.Parse(v)
+		obj.MinAvailable = &val
+	}
+	if v, ok := in["selector"].([]interface{}); ok && len(v) > 0 {
+		obj.Selector = expandLabelSelector(v)
+	}
+
+	return obj
+}
+
+func flattenPodDisruptionBudgetSpec(in policyv1.PodDisruptionBudgetSpec) []interface{} {
+	att := make(map[string]interface{})
+
+	if in.MaxUnavailable != nil {
+		att["max_unavailable"] = in.MaxUnavailable.String()
+	}
+	if in.MinAvailable != nil {
+		att["min_available"] = in.MinAvailable.String()
+	}
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
+
+	return []interface{}{att}
+}
+
+func flattenPodDisruptionBudgetStatus(in policyv1.PodDisruptionBudgetStatus) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"current_healthy":     int(in.CurrentHealthy),
+		"desired_healthy":     int(in.DesiredHealthy),
+		"disruptions_allowed": int(in.DisruptionsAllowed),
+		"expected_pods":       int(in.ExpectedPods),
+	}}
+}
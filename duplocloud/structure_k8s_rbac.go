@@ -0,0 +1,102 @@
+package duplocloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func expandRBACPolicyRules(l []interface{}) []rbacv1.PolicyRule {
+	rules := make([]rbacv1.PolicyRule, 0, len(l))
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+		in := v.(map[string]interface{})
+		rule := rbacv1.PolicyRule{
+			Verbs: expandStringSet(in["verbs"].(*schema.Set)),
+		}
+		// Unlike expandStringSet, empty strings are kept, because they stand for the core API group.
+		if v, ok := in["api_groups"].(*schema.Set); ok && v.Len() > 0 {
+			for _, group := range v.List() {
+				rule.APIGroups = append(rule.APIGroups, group.(string))
+			}
+		}
+		if v, ok := in["resources"].(*schema.Set); ok && v.Len() > 0 {
+			rule.Resources = expandStringSet(v)
+		}
+		if v, ok := in["resource_names"].(*schema.Set); ok && v.Len() > 0 {
+			rule.ResourceNames = expandStringSet(v)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func flattenRBACPolicyRules(in []rbacv1.PolicyRule) []interface{} {
+	rules := make([]interface{}, 0, len(in))
+	for _, rule := range in {
+		rules = append(rules, map[string]interface{}{
+			"api_groups":     flattenStringSet(rule.APIGroups),
+			"resources":      flattenStringSet(rule.Resources),
+			"resource_names": flattenStringSet(rule.ResourceNames),
+			"verbs":          flattenStringSet(rule.Verbs),
+		})
+	}
+	return rules
+}
+
+func expandRBACRoleRef(l []interface{}) rbacv1.RoleRef {
+	if len(l) == 0 || l[0] == nil {
+		return rbacv1.RoleRef{}
+	}
+	in := l[0].(map[string]interface{})
+	return rbacv1.RoleRef{
+		APIGroup: in["api_group"].(string),
+		Kind:     in["kind"].(string),
+		Name:     in["name"].(string),
+	}
+}
+
+func flattenRBACRoleRef(in rbacv1.RoleRef) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"api_group": in.APIGroup,
+		"kind":      in.Kind,
+		"name":      in.Name,
+	}}
+}
+
+func expandRBACSubjects(l []interface{}) []rbacv1.Subject {
+	subjects := make([]rbacv1.Subject, 0, len(l))
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+		in := v.(map[string]interface{})
+		subject := rbacv1.Subject{
+			Kind:      in["kind"].(string),
+			Name:      in["name"].(string),
+			Namespace: in["namespace"].(string),
+			APIGroup:  in["api_group"].(string),
+		}
+
+		// Users and groups belong to the RBAC API group, while service accounts belong to the core API group.
+		if subject.APIGroup == "" && subject.Kind != rbacv1.ServiceAccountKind {
+			subject.APIGroup = rbacv1.GroupName
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects
+}
+
+func flattenRBACSubjects(in []rbacv1.Subject) []interface{} {
+	subjects := make([]interface{}, 0, len(in))
+	for _, subject := range in {
+		subjects = append(subjects, map[string]interface{}{
+			"api_group": subject.APIGroup,
+			"kind":      subject.Kind,
+			"name":      subject.Name,
+			"namespace": subject.Namespace,
+		})
+	}
+	return subjects
+}
@@ -0,0 +1,131 @@
+package duplocloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func expandServiceSpec(s []interface{}) corev1.ServiceSpec {
+	obj := corev1.ServiceSpec{}
+	if len(s) == 0 || s[0] == nil {
+		return obj
+	}
+	in := s[0].(map[string]interface{})
+
+	if v, ok := in["cluster_ip"].(string); ok && v != "" {
+		obj.ClusterIP = v
+	}
+	if v, ok := in["external_name"].(string); ok && v != "" {
+		obj.ExternalName = v
+	}
+	if v, ok := in["external_traffic_policy"].(string); ok && v != "" {
+		obj.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyType(v)
+	}
+	if v, ok := in["load_balancer_source_ranges"].(*schema.Set); ok && v.Len() > 0 {
+		obj.LoadBalancerSourceRanges = expandStringSet(v)
+	}
+	if v, ok := in["port"].([]interface{}); ok && len(v) > 0 {
+		obj.Ports = expandServicePorts(v)
+	}
+	if v, ok := in["publish_not_ready_addresses"].(bool); ok {
+		obj.PublishNotReadyAddresses = v
+	}
+	if v, ok := in["selector"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.Selector = expandStringMap(v)
+	}
+	if v, ok := in["session_affinity"].(string); ok && v != "" {
+		obj.SessionAffinity = corev1.ServiceAffinity(v)
+	}
+	if v, ok := in["type"].(string); ok && v != "" {
+		obj.Type = corev1.ServiceType(v)
+	}
+
+	return obj
+}
+
+func expandServicePorts(l []interface{}) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0, len(l))
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+		in := v.(map[string]interface{})
+		port := corev1.ServicePort{
+			Name:     in["name"].(string),
+			Port:     int32(in["port"].(int)),
+			Protocol: corev1.Protocol(in["protocol"].(string)),
+			NodePort: int32(in["node_port"].(int)),
+		}
+		if v, ok := in["app_protocol"].(string); ok && v != "" {
+			port.AppProtocol = &v
+		}
+		if v, ok := in["target_port"].(string); ok && v != "" {
+			port.TargetPort = intstr.Parse(v)
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func flattenServiceSpec(in corev1.ServiceSpec) []interface{} {
+	att := make(map[string]interface{})
+
+	att["cluster_ip"] = in.ClusterIP
+	att["external_name"] = in.ExternalName
+	att["external_traffic_policy"] = string(in.ExternalTrafficPolicy)
+	if len(in.LoadBalancerSourceRanges) > 0 {
+		att["load_balancer_source_ranges"] = flattenStringSet(in.LoadBalancerSourceRanges)
+	}
+	att["port"] = flattenServicePorts(in.Ports)
+	att["publish_not_ready_addresses"] = in.PublishNotReadyAddresses
+	att["selector"] = in.Selector
+
+	// Default like Kubernetes, to avoid perpetual diffs when the backend omits these fields.
+	att["session_affinity"] = string(corev1.ServiceAffinityNone)
+	if in.SessionAffinity != "" {
+		att["session_affinity"] = string(in.SessionAffinity)
+	}
+	att["type"] = string(corev1.ServiceTypeClusterIP)
+	if in.Type != "" {
+		att["type"] = string(in.Type)
+	}
+
+	return []interface{}{att}
+}
+
+func flattenServicePorts(in []corev1.ServicePort) []interface{} {
+	ports := make([]interface{}, 0, len(in))
+	for _, port := range in {
+		m := map[string]interface{}{
+			"name":        port.Name,
+			"node_port":   int(port.NodePort),
+			"port":        int(port.Port),
+			"protocol":    string(port.Protocol),
+			"target_port": port.TargetPort.String(),
+		}
+		if port.Protocol == "" {
+			m["protocol"] = string(corev1.ProtocolTCP)
+		}
+		if port.AppProtocol != nil {
+			m["app_protocol"] = *port.AppProtocol
+		}
+		ports = append(ports, m)
+	}
+	return ports
+}
+
+func flattenServiceStatus(in corev1.ServiceStatus) []interface{} {
+	ingress := make([]interface{}, 0, len(in.LoadBalancer.Ingress))
+	for _, v := range in.LoadBalancer.Ingress {
+		ingress = append(ingress, map[string]interface{}{
+			"hostname": v.Hostname,
+			"ip":       v.IP,
+		})
+	}
+	return []interface{}{map[string]interface{}{
+		"load_balancer": []interface{}{map[string]interface{}{
+			"ingress": ingress,
+		}},
+	}}
+}
@@ -1,6 +1,11 @@
 package duplocloud
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
 
 func expandPodTemplate(l []interface{}) (*corev1.PodTemplateSpec, error) {
 	obj := &corev1.PodTemplateSpec{}
@@ -20,3 +25,104 @@ func expandPodTemplate(l []interface{}) (*corev1.PodTemplateSpec, error) {
 	}
 	return obj, nil
 }
+
+func expandDeploymentSpec(s []interface{}) (appsv1.DeploymentSpec, error) {
+	obj := appsv1.DeploymentSpec{}
+	if len(s) == 0 || s[0] == nil {
+		return obj, nil
+	}
+	in := s[0].(map[string]interface{})
+
+	if v, ok := in["replicas"].(int); ok {
+		obj.Replicas = ptrToInt32(int32(v))
+	}
+
+	template, err := expandPodTemplate(in["template"].([]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Template = *template
+
+	if v, ok := in["strategy"].([]interface{}); ok && len(v) > 0 {
+		obj.Strategy = expandDeploymentStrategy(v)
+	}
+
+	return obj, nil
+}
+
+func expandDeploymentStrategy(s []interface{}) appsv1.DeploymentStrategy {
+	obj := appsv1.DeploymentStrategy{}
+	if len(s) == 0 || s[0] == nil {
+		return obj
+	}
+	in := s[0].(map[string]interface{})
+
+	if v, ok := in["type"].(string); ok && v != "" {
+		obj.Type = appsv1.DeploymentStrategyType(v)
+	}
+
+	if obj.Type != appsv1.RecreateDeploymentStrategyType {
+		if v, ok := in["rolling_update"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ru := v[0].(map[string]interface{})
+			rollingUpdate := &appsv1.RollingUpdateDeployment{}
+			if mu, ok := ru["max_unavailable"].(string); ok && mu != "" {
+				parsed := intstr.Parse(mu)
+				rollingUpdate.MaxUnavailable = &parsed
+			}
+			if ms, ok := ru["max_surge"].(string); ok && ms != "" {
+				parsed := intstr.Parse(ms)
+				rollingUpdate.MaxSurge = &parsed
+			}
+			obj.RollingUpdate = rollingUpdate
+		}
+	}
+
+	return obj
+}
+
+func flattenDeploymentSpec(in appsv1.DeploymentSpec, d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	if in.Replicas != nil {
+		att["replicas"] = *in.Replicas
+	}
+
+	podSpec, err := flattenPodTemplateSpec(in.Template, d, meta, "spec.0.template.0.")
+	if err != nil {
+		return nil, err
+	}
+	att["template"] = podSpec
+
+	att["strategy"] = flattenDeploymentStrategy(in.Strategy)
+
+	return []interface{}{att}, nil
+}
+
+func flattenDeploymentStrategy(in appsv1.DeploymentStrategy) []interface{} {
+	att := make(map[string]interface{})
+
+	// Default to RollingUpdate, like Kubernetes, when Duplo was not given a strategy.
+	strategyType := in.Type
+	if strategyType == "" {
+		strategyType = appsv1.RollingUpdateDeploymentStrategyType
+	}
+	att["type"] = string(strategyType)
+
+	if strategyType == appsv1.RollingUpdateDeploymentStrategyType {
+		ru := map[string]interface{}{
+			"max_surge":       "25%",
+			"max_unavailable": "25%",
+		}
+		if in.RollingUpdate != nil {
+			if in.RollingUpdate.MaxSurge != nil {
+				ru["max_surge"] = in.RollingUpdate.MaxSurge.String()
+			}
+			if in.RollingUpdate.MaxUnavailable != nil {
+				ru["max_unavailable"] = in.RollingUpdate.MaxUnavailable.String()
+			}
+		}
+		att["rolling_update"] = []interface{}{ru}
+	}
+
+	return []interface{}{att}
+}
@@ -326,3 +326,22 @@ func expandMapToResourceList(m map[string]interface{}) (*api.ResourceList, error
 	}
 	return &out, nil
 }
+
+func getK8sMetadataName(d *schema.ResourceData) (string, error) {
+	name := d.Get("metadata.0.name").(string)
+	if name == "" {
+		return "", fmt.Errorf("name must be specified inside the metadata block")
+	}
+	return name, nil
+}
+
+func parseK8sV3IdParts(id, kind string) (tenantId, name string, err error) {
+	r := regexp.MustCompile(`^v3/subscriptions/([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12})/k8s/` + kind + `/([^/]+)$`)
+	matches := r.FindStringSubmatch(id)
+	if len(matches) == 3 {
+		tenantId, name = matches[1], matches[2]
+	} else {
+		err = fmt.Errorf("invalid resource ID: %s", id)
+	}
+	return
+}
@@ -0,0 +1,226 @@
+package duplocloud
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Duplo runs a Kubernetes workload as a service, whose other_docker_config holds the pod template:
+// the settings of the main container are inlined, and the rest of the pod spec uses upper camel-case
+// keys, except for the pod's security context which is under PodSecurityContext.
+
+// expandK8sWorkloadOtherDockerConfig converts a workload into the image and other_docker_config of a Duplo service.
+func expandK8sWorkloadOtherDockerConfig(meta metav1.ObjectMeta, template corev1.PodTemplateSpec, extra map[string]interface{}) (image, config string, err error) {
+	if len(template.Spec.Containers) == 0 {
+		return "", "", fmt.Errorf("at least one container must be specified")
+	}
+	defn := map[string]interface{}{}
+
+	// Inline the main container, whose name and image are the ones of the service.
+	main := template.Spec.Containers[0]
+	image = main.Image
+	main.Name, main.Image = "", ""
+	if err = mergeK8sObjectIntoMap(defn, main); err != nil {
+		return
+	}
+
+	pod := template.Spec.DeepCopy()
+	pod.Containers = nil
+	pod.SecurityContext = nil
+	if err = mergeK8sObjectIntoMap(defn, pod); err != nil {
+		return
+	}
+	delete(defn, "name")
+	delete(defn, "containers")
+	if len(template.Spec.Containers) > 1 {
+		defn["AdditionalContainers"] = template.Spec.Containers[1:]
+	}
+	if template.Spec.SecurityContext != nil {
+		defn["PodSecurityContext"] = template.Spec.SecurityContext
+	}
+
+	// Labels and annotations.
+	if len(meta.Labels) > 0 {
+		defn["DeploymentLabels"] = meta.Labels
+	}
+	if len(meta.Annotations) > 0 {
+		defn["DeploymentAnnotations"] = meta.Annotations
+	}
+	if len(template.Labels) > 0 {
+		defn["PodLabels"] = template.Labels
+	}
+	if len(template.Annotations) > 0 {
+		defn["PodAnnotations"] = template.Annotations
+	}
+
+	for k, v := range extra {
+		defn[k] = v
+	}
+	reduceNilOrEmptyMapEntries(defn)
+	makeMapUpperCamelCase(defn)
+
+	encoded, err := json.Marshal(defn)
+	return image, string(encoded), err
+}
+
+// flattenK8sWorkloadOtherDockerConfig converts the image and other_docker_config of a Duplo service into a workload.
+//
+// The decoded other_docker_config is also returned, so that callers can read the keys that are specific to a workload.
+func flattenK8sWorkloadOtherDockerConfig(name, image, config string) (meta metav1.ObjectMeta, template corev1.PodTemplateSpec, defn map[string]interface{}, err error) {
+	defn = map[string]interface{}{}
+	if config != "" {
+		if err = json.Unmarshal([]byte(config), &defn); err != nil {
+			return
+		}
+	}
+	makeMapUpperCamelCase(defn)
+
+	// The main container ignores the pod-level keys, except for the restart policy.
+	main := corev1.Container{}
+	if err = convertK8sObject(defn, &main); err != nil {
+		return
+	}
+	main.Name, main.Image, main.RestartPolicy = name, image, nil
+
+	podDefn := map[string]interface{}{}
+	for k, v := range defn {
+		podDefn[k] = v
+	}
+	delete(podDefn, "SecurityContext")
+	if v, ok := defn["PodSecurityContext"]; ok {
+		podDefn["SecurityContext"] = v
+	}
+	if err = convertK8sObject(podDefn, &template.Spec); err != nil {
+		return
+	}
+	template.Spec.Containers = []corev1.Container{main}
+	if v, ok := defn["AdditionalContainers"]; ok {
+		additional := []corev1.Container{}
+		if err = convertK8sObject(v, &additional); err != nil {
+			return
+		}
+		template.Spec.Containers = append(template.Spec.Containers, additional...)
+	}
+
+	meta.Name = name
+	for key, labels := range map[string]*map[string]string{
+		"DeploymentLabels":      &meta.Labels,
+		"DeploymentAnnotations": &meta.Annotations,
+		"PodLabels":             &template.Labels,
+		"PodAnnotations":        &template.Annotations,
+	} {
+		if v, ok := defn[key]; ok {
+			if err = convertK8sObject(v, labels); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// expandStatefulSetVolumeClaimTemplates converts volume claim templates into the volumes of a Duplo service.
+//
+// Duplo mounts the volumes itself, so their mounts are removed from the main container.
+func expandStatefulSetVolumeClaimTemplates(claims []corev1.PersistentVolumeClaim, main *corev1.Container) ([]duplosdk.DuploReplicationControllerVolume, error) {
+	volumes := make([]duplosdk.DuploReplicationControllerVolume, 0, len(claims))
+	for _, claim := range claims {
+		volume := duplosdk.DuploReplicationControllerVolume{Name: claim.Name}
+
+		for i, mount := range main.VolumeMounts {
+			if mount.Name == claim.Name {
+				volume.Path = mount.MountPath
+				main.VolumeMounts = append(main.VolumeMounts[:i], main.VolumeMounts[i+1:]...)
+				break
+			}
+		}
+		if volume.Path == "" {
+			return nil, fmt.Errorf("volume claim template '%s' must be mounted by the first container", claim.Name)
+		}
+
+		if len(claim.Spec.AccessModes) != 1 {
+			return nil, fmt.Errorf("volume claim template '%s' must have exactly one access mode", claim.Name)
+		}
+		volume.AccessMode = string(claim.Spec.AccessModes[0])
+
+		size, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !ok {
+			return nil, fmt.Errorf("volume claim template '%s' must request storage", claim.Name)
+		}
+		volume.Size = size.String()
+
+		if claim.Spec.StorageClassName != nil {
+			volume.StorageClassName = *claim.Spec.StorageClassName
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// flattenStatefulSetVolumeClaimTemplates converts the volumes of a Duplo service into volume claim templates,
+// and mounts them in the main container.
+func flattenStatefulSetVolumeClaimTemplates(encoded string, main *corev1.Container) ([]corev1.PersistentVolumeClaim, error) {
+	claims := []corev1.PersistentVolumeClaim{}
+	if encoded == "" {
+		return claims, nil
+	}
+
+	volumes := []duplosdk.DuploReplicationControllerVolume{}
+	if err := json.Unmarshal([]byte(encoded), &volumes); err != nil {
+		return nil, err
+	}
+	for _, volume := range volumes {
+		if volume.AccessMode == "" || volume.Size == "" {
+			continue
+		}
+
+		size, err := resource.ParseQuantity(volume.Size)
+		if err != nil {
+			return nil, err
+		}
+		claim := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: volume.Name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.PersistentVolumeAccessMode(volume.AccessMode)},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+				},
+			},
+		}
+		if volume.StorageClassName != "" {
+			claim.Spec.StorageClassName = &volume.StorageClassName
+		}
+		claims = append(claims, claim)
+
+		main.VolumeMounts = append(main.VolumeMounts, corev1.VolumeMount{Name: volume.Name, MountPath: volume.Path})
+	}
+	return claims, nil
+}
+
+// mergeK8sObjectIntoMap adds the JSON fields of a Kubernetes object to a map.
+func mergeK8sObjectIntoMap(defn map[string]interface{}, obj interface{}) error {
+	fields := map[string]interface{}{}
+	if err := convertK8sObject(obj, &fields); err != nil {
+		return err
+	}
+	for k, v := range fields {
+		defn[k] = v
+	}
+	return nil
+}
+
+// convertK8sObject converts between two representations of the same JSON.
+//
+// Decoding ignores the case of the keys, which lets the upper camel-case keys of Duplo fill Kubernetes objects.
+func convertK8sObject(in interface{}, out interface{}) error {
+	encoded, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, out)
+}
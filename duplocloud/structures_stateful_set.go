@@ -2,6 +2,7 @@ package duplocloud
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -30,3 +31,109 @@ func flattenPodTemplateSpec(t corev1.PodTemplateSpec, d *schema.ResourceData, me
 
 	return []interface{}{template}, nil
 }
+
+func expandStatefulSetSpec(s []interface{}) (appsv1.StatefulSetSpec, error) {
+	obj := appsv1.StatefulSetSpec{}
+	if len(s) == 0 || s[0] == nil {
+		return obj, nil
+	}
+	in := s[0].(map[string]interface{})
+
+	if v, ok := in["replicas"].(int); ok {
+		obj.Replicas = ptrToInt32(int32(v))
+	}
+
+	template, err := expandPodTemplate(in["template"].([]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Template = *template
+
+	if v, ok := in["volume_claim_template"].([]interface{}); ok && len(v) > 0 {
+		claims, err := expandStatefulSetVolumeClaimTemplateList(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.VolumeClaimTemplates = claims
+	}
+
+	return obj, nil
+}
+
+func expandStatefulSetVolumeClaimTemplateList(l []interface{}) ([]corev1.PersistentVolumeClaim, error) {
+	claims := make([]corev1.PersistentVolumeClaim, 0, len(l))
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+		in := v.(map[string]interface{})
+		claim := corev1.PersistentVolumeClaim{}
+
+		if m, ok := in["metadata"].([]interface{}); ok && len(m) > 0 && m[0] != nil {
+			claim.Name = m[0].(map[string]interface{})["name"].(string)
+		}
+
+		if s, ok := in["spec"].([]interface{}); ok && len(s) > 0 && s[0] != nil {
+			spec := s[0].(map[string]interface{})
+
+			for _, mode := range expandStringSet(spec["access_modes"].(*schema.Set)) {
+				claim.Spec.AccessModes = append(claim.Spec.AccessModes, corev1.PersistentVolumeAccessMode(mode))
+			}
+
+			if r, ok := spec["resources"].([]interface{}); ok && len(r) > 0 && r[0] != nil {
+				if requests, ok := r[0].(map[string]interface{})["requests"].(map[string]interface{}); ok && len(requests) > 0 {
+					rl, err := expandMapToResourceList(requests)
+					if err != nil {
+						return claims, err
+					}
+					claim.Spec.Resources.Requests = *rl
+				}
+			}
+
+			if v, ok := spec["storage_class_name"].(string); ok && v != "" {
+				claim.Spec.StorageClassName = &v
+			}
+		}
+
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+func flattenStatefulSetSpec(in appsv1.StatefulSetSpec, d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	if in.Replicas != nil {
+		att["replicas"] = *in.Replicas
+	}
+
+	podSpec, err := flattenPodTemplateSpec(in.Template, d, meta, "spec.0.template.0.")
+	if err != nil {
+		return nil, err
+	}
+	att["template"] = podSpec
+
+	claims := make([]interface{}, 0, len(in.VolumeClaimTemplates))
+	for _, claim := range in.VolumeClaimTemplates {
+		modes := make([]string, 0, len(claim.Spec.AccessModes))
+		for _, mode := range claim.Spec.AccessModes {
+			modes = append(modes, string(mode))
+		}
+		spec := map[string]interface{}{
+			"access_modes": flattenStringSet(modes),
+			"resources": []interface{}{map[string]interface{}{
+				"requests": flattenResourceList(claim.Spec.Resources.Requests),
+			}},
+		}
+		if claim.Spec.StorageClassName != nil {
+			spec["storage_class_name"] = *claim.Spec.StorageClassName
+		}
+		claims = append(claims, map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": claim.Name}},
+			"spec":     []interface{}{spec},
+		})
+	}
+	att["volume_claim_template"] = claims
+
+	return []interface{}{att}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/duplocloud/terraform-provider-duplocloud/duplosdk"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -30,6 +32,8 @@ const (
 	MAX_DUPLOSERVICES_LENGTH            = len("duploservices-1234567890ab-")
 	MAX_DUPLOSERVICES_AND_SUFFIX_LENGTH = len("duploservices-1234567890ab--1234567890ab")
 	RDS_DOCUMENT_DB_ENGINE              = 13
+	AWS_CLOUD                           = 0
+	AZURE_CLOUD                         = 2
 	GCP_CLOUD                           = 3
 )
 
@@ -896,6 +900,60 @@ func errorsToDiagnostics(prefix string, errors []error) diag.Diagnostics {
 	return diags
 }
 
+// Hints added to the diagnostics of Duplo API errors, by kind of error.
+var clientErrorHints = []struct {
+	err  error
+	hint string
+}{
+	{duplosdk.ErrUnauthorized, "Check that the Duplo token is valid, and that it grants access to this object."},
+	{duplosdk.ErrRateLimited, "Duplo is throttling requests: consider lowering requests_per_second or max_concurrent_requests."},
+	{duplosdk.ErrAPIUnsupported, "This Duplo portal does not support the API: it may need to be upgraded, or the feature enabled."},
+	{duplosdk.ErrConflict, "The object already exists, or was changed concurrently."},
+}
+
+// clientErrorDiagnostics turns a Duplo API error into diagnostics.
+//
+// When Duplo rejects a request as invalid, each invalid field is reported against the attribute it
+// maps to in fields, which is keyed by the Duplo field name (matched case-insensitively), so that
+// Terraform points at the offending configuration.
+func clientErrorDiagnostics(summary string, err error, fields map[string]string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var verr *duplosdk.ValidationError
+	if errors.As(err, &verr) && len(verr.Fields) > 0 {
+		diags := diag.Diagnostics{}
+		for name, messages := range verr.Fields {
+			d := diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   fmt.Sprintf("%s: %s", name, strings.Join(messages, ", ")),
+			}
+			// ASP.NET prefixes field names with the name of the request, e.g. "rq.Name".
+			field := name[strings.LastIndex(name, ".")+1:]
+			for duploName, attr := range fields {
+				if strings.EqualFold(duploName, field) {
+					d.AttributePath = cty.GetAttrPath(attr)
+					break
+				}
+			}
+			diags = append(diags, d)
+		}
+		sort.Slice(diags, func(i, j int) bool { return diags[i].Detail < diags[j].Detail })
+		return diags
+	}
+
+	detail := err.Error()
+	for _, h := range clientErrorHints {
+		if errors.Is(err, h.err) {
+			detail += "\n\n" + h.hint
+			break
+		}
+	}
+	return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: detail}}
+}
+
 func flattenStringList(list []string) []interface{} {
 	vs := make([]interface{}, 0, len(list))
 	for _, v := range list {
@@ -0,0 +1,70 @@
+package duplocloud
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// addWriteOnlyArgument adds a write-only alternative to a sensitive string argument:
+//
+//   - "<argument>_wo" is never stored in the state or shown in the plan
+//   - "<argument>_wo_version" is stored, and must be changed to send a new value to Duplo
+//
+// The argument itself becomes optional, and exactly one of the two must be set if it was required.
+func addWriteOnlyArgument(s map[string]*schema.Schema, argument string) {
+	plaintext := s[argument]
+	wo, version := argument+"_wo", argument+"_wo_version"
+
+	if plaintext.Required {
+		plaintext.Required = false
+		plaintext.Optional = true
+		plaintext.ExactlyOneOf = []string{argument, wo}
+	} else {
+		plaintext.ConflictsWith = append(plaintext.ConflictsWith, wo)
+	}
+
+	s[wo] = &schema.Schema{
+		Description: fmt.Sprintf("A write-only alternative to `%s`, which is never stored in the state.  "+
+			"Change `%s` to send a new value.  Requires Terraform 1.11 or later.", argument, version),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		WriteOnly:    true,
+		ValidateFunc: plaintext.ValidateFunc,
+		RequiredWith: []string{version},
+	}
+	s[version] = &schema.Schema{
+		Description:  fmt.Sprintf("The version of `%s`.  Changing it sends the current value of `%s` to Duplo.", wo, wo),
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		RequiredWith: []string{wo},
+	}
+}
+
+// writeOnlyInUse returns true if a resource uses the write-only alternative of an argument.
+func writeOnlyInUse(d *schema.ResourceData, argument string) bool {
+	_, ok := d.GetOk(argument + "_wo_version")
+	return ok
+}
+
+// writeOnlyValue returns the value to send to Duplo for an argument that has a write-only alternative.
+//
+// Write-only values are only available in the configuration, so this must be called from Create or Update.
+func writeOnlyValue(d *schema.ResourceData, argument string) (string, error) {
+	if !writeOnlyInUse(d, argument) {
+		return d.Get(argument).(string), nil
+	}
+
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(argument + "_wo"))
+	if diags.HasError() {
+		return "", fmt.Errorf("unable to read %s_wo from the configuration: %s", argument, diags[0].Summary)
+	}
+	if !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return "", fmt.Errorf("%s_wo must be set to a known value when %s_wo_version is set", argument, argument)
+	}
+	return v.AsString(), nil
+}
@@ -0,0 +1,75 @@
+package duplocloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddWriteOnlyArgument(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"data": {Type: schema.TypeString, Required: true, Sensitive: true},
+	}
+	addWriteOnlyArgument(s, "data")
+
+	assert.False(t, s["data"].Required)
+	assert.True(t, s["data"].Optional)
+	assert.Equal(t, []string{"data", "data_wo"}, s["data"].ExactlyOneOf)
+	assert.True(t, s["data_wo"].WriteOnly)
+	assert.True(t, s["data_wo"].Sensitive)
+	assert.Equal(t, []string{"data_wo_version"}, s["data_wo"].RequiredWith)
+	assert.Equal(t, schema.TypeInt, s["data_wo_version"].Type)
+	assert.Equal(t, []string{"data_wo"}, s["data_wo_version"].RequiredWith)
+
+	r := &schema.Resource{Schema: s}
+	assert.NoError(t, r.InternalValidate(nil, true))
+}
+
+// testApplyCreate creates a resource through the SDK, with a raw configuration as Terraform would send it.
+func testApplyCreate(t *testing.T, r *schema.Resource, args map[string]cty.Value, meta interface{}) *terraform.InstanceState {
+	ctx := context.Background()
+
+	values := map[string]cty.Value{}
+	for name, typ := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		if v, ok := args[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = cty.NullVal(typ)
+		}
+	}
+	raw := cty.ObjectVal(values)
+
+	diff, err := r.Diff(ctx, nil, terraform.NewResourceConfigShimmed(raw, r.CoreConfigSchema()), meta)
+	require.NoError(t, err)
+	diff.RawConfig = raw
+
+	state, diags := r.Apply(ctx, nil, diff, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	return state
+}
+
+func TestEmulator_K8SecretWriteOnly(t *testing.T) {
+	c := testAccEmulatorClient(t)
+
+	state := testApplyCreate(t, resourceK8Secret(), map[string]cty.Value{
+		"tenant_id":              cty.StringVal(Tenant_testacc1a),
+		"secret_name":            cty.StringVal("creds"),
+		"secret_type":            cty.StringVal("Opaque"),
+		"secret_data_wo":         cty.StringVal(`{"user":"admin"}`),
+		"secret_data_wo_version": cty.NumberIntVal(1),
+	}, c)
+
+	secret, err := c.K8SecretGet(Tenant_testacc1a, "creds")
+	require.Nil(t, err)
+	require.NotNil(t, secret)
+	assert.Equal(t, "admin", secret.SecretData["user"])
+
+	assert.Equal(t, "1", state.Attributes["secret_data_wo_version"])
+	assert.Empty(t, state.Attributes["secret_data"])
+	assert.Empty(t, state.Attributes["client_secret_version"])
+}
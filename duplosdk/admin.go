@@ -90,7 +90,7 @@ func (c *Client) AdminGetAwsCredentials() (*DuploAdminAwsCredentials, ClientErro
 
 func (c *Client) AdminGetSystemFeatures() (*DuploSystemFeatures, ClientError) {
 	features := DuploSystemFeatures{}
-	err := c.getAPI("AdminGetSystemFeatures()", "v3/features/system", &features)
+	err := c.getAPICached("AdminGetSystemFeatures()", "v3/features/system", &features, func() bool { return true })
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +98,7 @@ func (c *Client) AdminGetSystemFeatures() (*DuploSystemFeatures, ClientError) {
 }
 
 func (c *Client) IsAzureCustomPrefixesEnabled() bool {
-	features := DuploSystemFeatures{}
-	err := c.getAPI("AdminGetSystemFeatures()", "v3/features/system", &features)
+	features, err := c.AdminGetSystemFeatures()
 	if err != nil || features.AzureResourcePrefix == nil || !features.AzureResourcePrefix.IsAzureCustomPrefixesEnabled {
 		return false
 	}
@@ -139,9 +138,8 @@ func (c *Client) IsAwsServiceTagBasedOwnershipEnabled(service string) bool {
 }
 
 func (c *Client) getPrefixFromResourceType(resourceType string, isInfraResource bool) (string, error) {
-	features := DuploSystemFeatures{}
 	resourcePrefixEnabled := false
-	err := c.getAPI("AdminGetSystemFeatures()", "v3/features/system", &features)
+	features, err := c.AdminGetSystemFeatures()
 	if err != nil {
 		return "", err
 	}
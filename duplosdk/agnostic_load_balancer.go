@@ -2,8 +2,6 @@ package duplosdk
 
 import (
 	"fmt"
-	"strings"
-	"time"
 )
 
 // AgnosticLbSettings represents a load balancer's settings.
@@ -39,42 +37,18 @@ type AgnosticLbSettingsGcp struct {
 // TenantUpdateLbSettings updates a load balancer's settings via Duplo.
 func (c *Client) TenantUpdateLbSettings(tenantID, loadBalancerID string, rq *AgnosticLbSettings) (*AgnosticLbSettings, ClientError) {
 	rp := AgnosticLbSettings{}
-	_, err := RetryWithExponentialBackoff(func() (interface{}, ClientError) {
-		err := c.putAPI("TenantUpdateLbSettings",
-			fmt.Sprintf("v3/subscriptions/%s/agnostic/loadBalancer/%s/setting", tenantID, loadBalancerID),
-			&rq,
-			&rp)
-		return &rp, err
-	},
-		RetryConfig{
-			MinDelay:  1 * time.Second,
-			MaxDelay:  5 * time.Second,
-			MaxJitter: 2000,
-			Timeout:   60 * time.Second,
-			IsRetryable: func(error ClientError) bool {
-				return error.Status() == 400 || strings.Contains(error.Error(), "context deadline exceeded")
-			},
-		})
+	err := c.withBusyRetries().putAPI("TenantUpdateLbSettings",
+		fmt.Sprintf("v3/subscriptions/%s/agnostic/loadBalancer/%s/setting", tenantID, loadBalancerID),
+		&rq,
+		&rp)
 	return &rp, err
 }
 
 // TenantGetLbSettings retrieves a load balancer's settings via Duplo.
 func (c *Client) TenantGetLbSettings(tenantID, loadBalancerID string) (*AgnosticLbSettings, ClientError) {
 	rp := AgnosticLbSettings{}
-	_, err := RetryWithExponentialBackoff(func() (interface{}, ClientError) {
-		err := c.getAPI("TenantGetLbSettings",
-			fmt.Sprintf("v3/subscriptions/%s/agnostic/loadBalancer/%s/setting", tenantID, loadBalancerID),
-			&rp)
-		return &rp, err
-	},
-		RetryConfig{
-			MinDelay:  1 * time.Second,
-			MaxDelay:  5 * time.Second,
-			MaxJitter: 2000,
-			Timeout:   60 * time.Second,
-			IsRetryable: func(error ClientError) bool {
-				return error.Status() == 400 || strings.Contains(error.Error(), "context deadline exceeded")
-			},
-		})
+	err := c.withBusyRetries().getAPI("TenantGetLbSettings",
+		fmt.Sprintf("v3/subscriptions/%s/agnostic/loadBalancer/%s/setting", tenantID, loadBalancerID),
+		&rp)
 	return &rp, err
 }
@@ -92,12 +92,10 @@ type DuploAsgProfileDeleteReq struct {
 // AsgProfileGetList retrieves a list of ASG profiles via the Duplo API.
 func (c *Client) AsgProfileGetList(tenantID string) (*[]DuploAsgProfile, ClientError) {
 	log.Printf("[DEBUG] Duplo API - Get ASG Profile List(TenantId-%s)", tenantID)
-	conf := NewRetryConf()
-
 	rp := []DuploAsgProfile{}
 	err := c.getAPIWithRetry(fmt.Sprintf("AsgProfileGetList(%s)", tenantID),
 		fmt.Sprintf("subscriptions/%s/GetTenantAsgProfiles", tenantID),
-		&rp, &conf)
+		&rp)
 	return &rp, err
 }
 
@@ -1,6 +1,9 @@
 package duplosdk
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 //  --------------- Scheduling Policies ---------------
 
@@ -396,52 +399,36 @@ func (c *Client) AwsBatchJobDefinitionGetAllRevisions(tenantID string, name stri
 	return &jobRev, nil
 }
 
+// Job definitions are slow to deregister, and AWS throttles their APIs heavily, so they get longer retry budgets.
+var (
+	awsBatchJobDefinitionRetryPolicy           = RetryPolicy{MaxRetries: 15, MinBackoff: 3 * time.Second, MaxBackoff: 30 * time.Second}
+	awsBatchJobDefinitionDeleteRetryPolicy     = RetryPolicy{MaxRetries: 9, MinBackoff: 3 * time.Second, MaxBackoff: 30 * time.Second}
+	awsBatchJobDefinitionBulkDeleteRetryPolicy = RetryPolicy{MaxRetries: 15, MinBackoff: 5 * time.Second, MaxBackoff: 60 * time.Second}
+)
+
 func (c *Client) AwsBatchJobDefinitionList(tenantID, name string) (*[]DuploAwsBatchJobDefinitionResp, ClientError) {
 	rp := []DuploAwsBatchJobDefinitionResp{}
-	conf := NewRetryConf()
-	conf.RateExceededMaxRetries = 15
-	conf.MinDelay = 10
-	conf.MaxDelay = 30
-	conf.MinStartingDelay = 3
-	conf.MaxStartingDelay = 10
-	err := c.getAPIWithRetry(
+	err := c.withRetryPolicy(awsBatchJobDefinitionRetryPolicy).getAPIWithRetry(
 		fmt.Sprintf("AwsBatchJobDefinitionList(%s)", tenantID),
 		fmt.Sprintf("v3/subscriptions/%s/aws/batchJobDefinition?job-definition-name=%s", tenantID, name),
 		&rp,
-		&conf,
 	)
 	return &rp, err
 }
 
 func (c *Client) AwsBatchJobDefinitionDelete(tenantID string, name string) ClientError {
-	conf := NewRetryConf()
-	conf.MinDelay = 10
-	conf.MaxDelay = 30
-	conf.MinStartingDelay = 3
-	conf.MaxStartingDelay = 10
-	return c.deleteAPIWithRetry(
+	return c.withRetryPolicy(awsBatchJobDefinitionDeleteRetryPolicy).deleteAPIWithRetry(
 		fmt.Sprintf("AwsBatchJobDefinitionDelete(%s, %s)", tenantID, name),
 		fmt.Sprintf("v3/subscriptions/%s/aws/batchJobDefinition/%s", tenantID, name),
 		nil,
-		&conf,
 	)
 }
 
 func (c *Client) AwsBatchJobDefinitionBulkDelete(tenantID string, name string) ClientError {
-	conf := NewRetryConf()
-	// Enhanced retry configuration for AWS Batch Job Definition deletion
-	// These are slower operations that may take longer to complete
-	conf.RateExceededMaxRetries = 15 // Increased from 9 to 15 for more retry attempts
-	conf.MinDelay = 15               // Increased from 10 to 15 seconds
-	conf.MaxDelay = 60               // Increased from 30 to 60 seconds for longer backoff
-	conf.MinStartingDelay = 5        // Increased from 3 to 5 seconds
-	conf.MaxStartingDelay = 15       // Increased from 10 to 15 seconds
-	conf.MinJitterDelay = 10         // Added jitter for better distribution
-	return c.deleteAPIWithRetry(
+	return c.withRetryPolicy(awsBatchJobDefinitionBulkDeleteRetryPolicy).deleteAPIWithRetry(
 		fmt.Sprintf("AwsBatchJobDefinitionBulkDelete(%s, %s)", tenantID, name),
 		fmt.Sprintf("v3/subscriptions/%s/aws/batchJobDefinition/%s/revisions", tenantID, name),
 		nil,
-		&conf,
 	)
 }
 
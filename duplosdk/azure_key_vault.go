@@ -200,11 +200,10 @@ func (c *Client) TenantGetSoftDeletedKeyVault(tenantID, vaultName string) (*Dupl
 
 func (c *Client) TenantKeyVaultListDeletedVaults(tenantID string) (*[]DuploAzureTenantKeyVault, ClientError) {
 	resp := []DuploAzureTenantKeyVault{}
-	conf := NewRetryConf()
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("TenantKeyVaultListDeletedVaults(%s)", tenantID),
 		fmt.Sprintf("v3/subscriptions/%s/azure/keyvault/deleted-vaults", tenantID),
-		&resp, &conf,
+		&resp,
 	)
 	return &resp, err
 }
@@ -269,12 +268,10 @@ func (c *Client) TenantKeyVaultSecretGet(tenantID, vaultName, secretName string)
 
 func (c *Client) TenantKeyVaultSecretList(tenantID, vaultName string) (*[]DuploAzureTenantKeyVaultSecret, ClientError) {
 	resp := []DuploAzureTenantKeyVaultSecret{}
-	conf := NewRetryConf()
-
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("TenantKeyVaultSecretList(%s, %s)", tenantID, vaultName),
 		fmt.Sprintf("v3/subscriptions/%s/azure/keyvault/%s/secret", tenantID, vaultName),
-		&resp, &conf)
+		&resp)
 	return &resp, err
 }
 
@@ -1,6 +1,7 @@
 package duplosdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -46,10 +47,19 @@ type CustomError struct {
 	response map[string]interface{}
 }
 
+func (e CustomError) Error() string {
+	return e.message
+}
+
 func (e CustomError) Status() int {
 	return e.status
 }
 
+// Unwrap returns the sentinel errors that match the custom error's status.
+func (e CustomError) Unwrap() []error {
+	return clientError{message: e.message, status: e.status, response: e.response}.Unwrap()
+}
+
 func NewCustomError(message string, status int) CustomError {
 	return CustomError{
 		message:  message,
@@ -59,12 +69,17 @@ func NewCustomError(message string, status int) CustomError {
 	}
 }
 
+// ClientError is an error returned by the Duplo API client.
+//
+// It wraps one of the sentinel errors such as ErrNotFound, or a *ValidationError, so that it can be
+// tested with errors.Is and errors.As.
 type ClientError interface {
 	Error() string
 	Status() int
 	PossibleMissingAPI() bool
 	URL() string
 	Response() map[string]interface{}
+	Unwrap() []error
 }
 
 func newHttpError(req *http.Request, status int, message string) ClientError {
@@ -105,7 +120,7 @@ func responseHttpError(req *http.Request, res *http.Response) ClientError {
 	bytes, err := io.ReadAll(res.Body)
 	message := "(read of body failed)"
 	if err == nil {
-		message = string(bytes)
+		message = redactBody(bytes)
 	}
 
 	// Older APIs do not always return helpful errors to API clients.
@@ -140,6 +155,49 @@ type Client struct {
 	HostURL     string
 	Token       string
 	UserAccount string
+
+	// TokenSource supplies the bearer token when it is not static, or is nil to always use Token.
+	TokenSource TokenSource
+
+	// RetryPolicy controls how transient Duplo API failures are retried.
+	RetryPolicy RetryPolicy
+
+	// RateLimiter throttles requests sent to the Duplo API, or is nil for no throttling.
+	RateLimiter *RateLimiter
+
+	// DeletionProtection lists the resources that must not be deleted, or is nil to protect nothing.
+	DeletionProtection *DeletionProtectionPolicy
+
+	// ReadCache memoizes reads that do not change during a run, or is nil for no caching.
+	ReadCache *ReadCache
+
+	// k8sClients keeps the clients of the tenants' Kubernetes API servers, or is nil for no caching.
+	k8sClients *k8sClientCache
+
+	// bypassCache is set on copies returned by WithoutCache.
+	bypassCache bool
+
+	// ctx is the context that all requests made through this client are bound to.
+	ctx context.Context
+}
+
+// WithContext returns a shallow copy of the client whose requests (and retry sleeps) are bound to ctx.
+// Cancelling ctx, or reaching its deadline, aborts any in-flight Duplo API call made through the copy.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the context that requests made through this client are bound to.
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
 }
 
 // NewClient creates a new Duplo API client
@@ -147,44 +205,178 @@ func NewClient(host, token string) (*Client, error) {
 	if host != "" && token != "" {
 		tokenBearer := fmt.Sprintf("Bearer %s", token)
 		c := Client{
-			HTTPClient: &http.Client{Timeout: 30 * time.Second},
-			HostURL:    host,
-			Token:      tokenBearer,
+			HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+			HostURL:     host,
+			Token:       tokenBearer,
+			RetryPolicy: DefaultRetryPolicy(),
+			ReadCache:   NewReadCache(DefaultReadCacheTTL),
+			k8sClients:  newK8sClientCache(),
 		}
 		return &c, nil
 	}
 	return nil, fmt.Errorf("missing provider config for 'duplo_token' 'duplo_host'. Not defined in environment var / main.tf")
 }
 
+// NewClientWithTokenSource creates a new Duplo API client, that obtains its bearer token from a TokenSource.
+func NewClientWithTokenSource(host string, ts TokenSource) (*Client, error) {
+	if host == "" || ts == nil {
+		return nil, fmt.Errorf("missing provider config for 'duplo_host' or the Duplo token source. Not defined in environment var / main.tf")
+	}
+	c := Client{
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+		HostURL:     host,
+		TokenSource: ts,
+		RetryPolicy: DefaultRetryPolicy(),
+		ReadCache:   NewReadCache(DefaultReadCacheTTL),
+		k8sClients:  newK8sClientCache(),
+	}
+	return &c, nil
+}
+
+// UseCassette records the client's traffic to a directory, or replays it from one, depending on mode.
+func (c *Client) UseCassette(dir, mode string) error {
+	var cassette *Cassette
+	var err error
+	switch mode {
+	case CassetteRecord:
+		cassette, err = NewCassetteRecorder(dir, c.HTTPClient.Transport)
+	case CassetteReplay:
+		cassette, err = NewCassetteReplayer(dir)
+	default:
+		err = fmt.Errorf("cassette: unknown mode %q, expected %q or %q", mode, CassetteRecord, CassetteReplay)
+	}
+	if err != nil {
+		return err
+	}
+	c.HTTPClient.Transport = cassette
+	return nil
+}
+
+// authorization returns the value of the Authorization header.
+func (c *Client) authorization(ctx context.Context) (string, error) {
+	if c.TokenSource == nil {
+		return c.Token, nil
+	}
+	token, err := c.TokenSource.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + token, nil
+}
+
 func (c *Client) doRequestWithStatus(req *http.Request, expectedStatus int) ([]byte, ClientError) {
-	req.Header.Set("Authorization", c.Token)
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
 	if c.UserAccount != "" {
 		req.Header.Set("DuploUser", c.UserAccount)
 	}
-	res, err := c.HTTPClient.Do(req)
+	logRequest(req)
+	start := time.Now()
 
-	// Handle I/O errors
-	if err != nil {
-		return nil, ioHttpError(req, err)
+	var res *http.Response
+	for refreshed := false; ; refreshed = true {
+		authorization, err := c.authorization(req.Context())
+		if err != nil {
+			return nil, requestHttpError(req.URL.String(), err.Error())
+		}
+		req.Header.Set("Authorization", authorization)
+
+		res, err = c.doWithRetries(req)
+
+		// Handle I/O errors
+		if err != nil {
+			logResponse(req, start, 0, nil, err)
+			return nil, ioHttpError(req, err)
+		}
+
+		// Refresh a rejected token once, if it comes from a token source.
+		if res.StatusCode != http.StatusUnauthorized || c.TokenSource == nil || refreshed || !rewindRequest(req) {
+			break
+		}
+		log.Printf("[WARN] duplo-doRequest: %s %s was unauthorized, refreshing the Duplo token", req.Method, req.URL.String())
+		io.Copy(io.Discard, res.Body) // nolint
+		res.Body.Close()
+		c.TokenSource.Invalidate()
 	}
 
 	// Pass through HTTP errors, unexpected redirects, or unexpected status codes.
 	if res.StatusCode > 300 || (expectedStatus > 0 && expectedStatus != res.StatusCode) {
-		return nil, responseHttpError(req, res)
+		httpErr := responseHttpError(req, res)
+		logResponse(req, start, res.StatusCode, nil, httpErr)
+		return nil, httpErr
 	}
 
 	// Othterwise, we have a response that needs reading.
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		log.Printf("[TRACE] duplo-doRequest: %s", err)
+		logResponse(req, start, res.StatusCode, nil, err)
 		return nil, ioHttpError(req, err)
 	}
 
+	logResponse(req, start, res.StatusCode, body, nil)
 	return body, nil
 }
 
+// doWithRetries sends a request, retrying transient failures according to the client's retry policy.
+func (c *Client) doWithRetries(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy(req.Context())
+	for attempt := 1; ; attempt++ {
+		res, err := c.send(req)
+
+		// Decide if the failure is worth retrying.
+		var delay time.Duration
+		var reason string
+		if err != nil {
+			if !retryableConnectionError(req, err) {
+				return nil, err
+			}
+			reason = err.Error()
+		} else if retryableResponse(req, res) {
+			delay = policy.retryAfter(res)
+			reason = fmt.Sprintf("status %d", res.StatusCode)
+		} else {
+			return res, nil
+		}
+
+		// Give up once we run out of attempts, or cannot resend the request.
+		if attempt > policy.MaxRetries || req.Context().Err() != nil || !rewindRequest(req) {
+			return res, err
+		}
+		if res != nil {
+			io.Copy(io.Discard, res.Body) // nolint
+			res.Body.Close()
+		}
+
+		if backoff := policy.backoff(attempt); delay < backoff {
+			delay = backoff
+		}
+		log.Printf("[WARN] duplo-doRequest: %s %s failed with %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.String(), reason, delay, attempt, policy.MaxRetries)
+		if err := sleepWithContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send sends a single request, once the client's rate limiter allows it.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	release, err := c.RateLimiter.acquire(req.Context(), req.Method+" "+req.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// The request stays in flight until its response body is closed.
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
 func (c *Client) doRequest(req *http.Request) ([]byte, ClientError) {
 	return c.doRequestWithStatus(req, 0)
 }
@@ -205,11 +397,10 @@ func (c *Client) doAPI(verb string, apiName string, apiPath string, rp interface
 
 	// Build the request
 	url := fmt.Sprintf("%s/%s", c.HostURL, apiPath)
-	log.Printf("[TRACE] %s: prepared request: %s", apiName, url)
-	req, err := http.NewRequest(verb, url, nil)
+	req, err := http.NewRequestWithContext(withAPIName(c.Context(), apiName), verb, url, nil)
 	if err != nil {
 		log.Printf("[TRACE] %s: cannot build request: %s", apiName, err.Error())
-		return nil
+		return requestHttpError(url, err.Error())
 	}
 
 	// Call the API and get the response.
@@ -219,16 +410,14 @@ func (c *Client) doAPI(verb string, apiName string, apiPath string, rp interface
 		return httpErr
 	}
 	bodyString := string(body)
-	if !strings.Contains(apiName, "K8SecretGetList") && !strings.Contains(apiName, "SsmParameterGet") && !strings.Contains(apiName, "SsmParameterList") {
-		log.Printf("[TRACE] %s: received response: %s", apiName, bodyString)
-	}
+
 	// Check for an expected "null" response.
 	if rp == nil {
 		log.Printf("[TRACE] %s: expected null response", apiName)
 		if bodyString == "null" || bodyString == "" || bodyString == "\"\"" {
 			return nil
 		}
-		message := fmt.Sprintf("%s: received unexpected response: %s", apiName, bodyString)
+		message := fmt.Sprintf("%s: received unexpected response: %s", apiName, redactBody(body))
 		log.Printf("[TRACE] %s", message)
 		return appHttpError(req, message)
 	}
@@ -254,12 +443,10 @@ func (c *Client) doAPIWithRequestBody(verb string, apiName string, apiPath strin
 		log.Printf("[TRACE] %s", message)
 		return requestHttpError(url, message)
 	}
-	//log.Printf("[TRACE] %s: prepared request: %s <= (%s)", apiName, url, rqBody)
-	log.Printf("[TRACE] %s: prepared request: %s", apiName, url)
-	req, err := http.NewRequest(verb, url, strings.NewReader(string(rqBody)))
+	req, err := http.NewRequestWithContext(withAPIName(c.Context(), apiName), verb, url, strings.NewReader(string(rqBody)))
 	if err != nil {
 		log.Printf("[TRACE] %s: cannot build request: %s", apiName, err.Error())
-		return nil
+		return requestHttpError(url, err.Error())
 	}
 
 	// Call the API and get the response
@@ -269,7 +456,6 @@ func (c *Client) doAPIWithRequestBody(verb string, apiName string, apiPath strin
 		return httpErr
 	}
 	bodyString := string(body)
-	log.Printf("[TRACE] %s: received response: %s", apiName, bodyString)
 
 	// Check for an expected "null" response.
 	if rp == nil {
@@ -277,7 +463,7 @@ func (c *Client) doAPIWithRequestBody(verb string, apiName string, apiPath strin
 		if bodyString == "null" || bodyString == "" || bodyString == "\"\"" {
 			return nil
 		}
-		message := fmt.Sprintf("%s: received unexpected response: %s", apiName, bodyString)
+		message := fmt.Sprintf("%s: received unexpected response: %s", apiName, redactBody(body))
 		log.Printf("[TRACE] %s", message)
 		return appHttpError(req, message)
 	}
@@ -0,0 +1,240 @@
+package duplosdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Cassette modes.
+const (
+	CassetteRecord = "record"
+	CassetteReplay = "replay"
+)
+
+// CassetteInteraction is one request sent to Duplo and its response, as stored in a cassette.
+//
+// Each interaction is written to its own NNNN-METHOD-path.json file, in the order it was sent.  This layout
+// can only be replayed by a Cassette: it is not the layout of internal/duplosdktest/fixtures, and the
+// emulator cannot load it.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"Request"`
+	Response CassetteResponse `json:"Response"`
+}
+
+// CassetteRequest is a recorded request.  Its body is kept in Body when it is JSON, and in Text otherwise.
+type CassetteRequest struct {
+	Method string          `json:"Method"`
+	Path   string          `json:"Path"`
+	Query  string          `json:"Query,omitempty"`
+	Body   json.RawMessage `json:"Body,omitempty"`
+	Text   string          `json:"Text,omitempty"`
+}
+
+// CassetteResponse is a recorded response.  Its body is kept in Body when it is JSON, and in Text otherwise.
+type CassetteResponse struct {
+	Status int             `json:"Status"`
+	Body   json.RawMessage `json:"Body,omitempty"`
+	Text   string          `json:"Text,omitempty"`
+}
+
+// Cassette is an http.RoundTripper that records Duplo API traffic to a directory, or replays it from one.
+//
+// Recorded bodies are sanitized: the values of sensitive fields (see RegisterSensitiveFields) are
+// redacted, and no headers are kept, so that tokens never reach the cassette.
+//
+// When replaying, requests are matched on their method, path, query and normalized body.  Interactions
+// that match the same request are replayed in the order they were recorded, and the last one is repeated
+// once they are exhausted, so that polling loops still terminate.
+type Cassette struct {
+	Dir  string
+	Mode string
+
+	// Next sends the requests that are being recorded.  It defaults to http.DefaultTransport.
+	Next http.RoundTripper
+
+	mutex  sync.Mutex
+	count  int
+	tracks map[string][]CassetteInteraction
+}
+
+// NewCassetteRecorder returns a cassette that sends requests through next, and records them to dir.
+func NewCassetteRecorder(dir string, next http.RoundTripper) (*Cassette, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	return &Cassette{Dir: dir, Mode: CassetteRecord, Next: next, count: len(files)}, nil
+}
+
+// NewCassetteReplayer returns a cassette that answers requests with the interactions recorded in dir.
+func NewCassetteReplayer(dir string) (*Cassette, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("cassette: no recorded interactions in %s", dir)
+	}
+	sort.Strings(files)
+
+	c := &Cassette{Dir: dir, Mode: CassetteReplay, tracks: map[string][]CassetteInteraction{}}
+	for _, file := range files {
+		bytes, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %w", err)
+		}
+		interaction := CassetteInteraction{}
+		if err := json.Unmarshal(bytes, &interaction); err != nil {
+			return nil, fmt.Errorf("cassette: %s: %w", file, err)
+		}
+		key := interaction.Request.key()
+		c.tracks[key] = append(c.tracks[key], interaction)
+	}
+	return c, nil
+}
+
+// RoundTrip records or replays a request.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	if c.Mode == CassetteReplay {
+		return c.replay(req)
+	}
+	return c.record(req)
+}
+
+func (c *Cassette) record(req *http.Request) (*http.Response, error) {
+	next := c.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	rq := newCassetteRequest(req)
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the response, and give the caller a copy of it.
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := CassetteInteraction{Request: rq, Response: CassetteResponse{Status: res.StatusCode}}
+	interaction.Response.Body, interaction.Response.Text = cassetteBody(body)
+	if err := c.write(interaction); err != nil {
+		log.Printf("[WARN] duplo-cassette: cannot record %s %s: %s", rq.Method, rq.Path, err)
+	}
+	return res, nil
+}
+
+var cassetteFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+func (c *Cassette) write(interaction CassetteInteraction) error {
+	bytes, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.count++
+	name := fmt.Sprintf("%04d-%s-%s", c.count, interaction.Request.Method, strings.Trim(interaction.Request.Path, "/"))
+	name = strings.Trim(cassetteFileChars.ReplaceAllString(name, "_"), "_")
+	if len(name) > 120 {
+		name = name[:120]
+	}
+	return os.WriteFile(filepath.Join(c.Dir, name+".json"), append(bytes, '\n'), 0o644)
+}
+
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+	rq := newCassetteRequest(req)
+	key := rq.key()
+
+	c.mutex.Lock()
+	track := c.tracks[key]
+	if len(track) == 0 {
+		c.mutex.Unlock()
+		return nil, fmt.Errorf("cassette: no recorded interaction for %s %s", rq.Method, rq.Path)
+	}
+	interaction := track[0]
+	if len(track) > 1 {
+		c.tracks[key] = track[1:]
+	}
+	c.mutex.Unlock()
+
+	log.Printf("[TRACE] duplo-cassette: replaying %s %s", rq.Method, rq.Path)
+	body := []byte(interaction.Response.Body)
+	if len(body) == 0 {
+		body = []byte(interaction.Response.Text)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+		StatusCode:    interaction.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func newCassetteRequest(req *http.Request) CassetteRequest {
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	rq := CassetteRequest{Method: method, Path: req.URL.Path, Query: req.URL.RawQuery}
+	rq.Body, rq.Text = cassetteBody(requestBody(req))
+	return rq
+}
+
+// key identifies the requests that an interaction can answer.
+func (rq CassetteRequest) key() string {
+	return rq.Method + " " + rq.Path + "?" + rq.Query + " " + string(normalizeCassetteBody(rq.Body)) + rq.Text
+}
+
+// cassetteBody sanitizes a body for a cassette, and returns it as JSON if it is JSON, or as text otherwise.
+func cassetteBody(body []byte) (json.RawMessage, string) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, ""
+	}
+	sanitized := redactBody(body)
+	if json.Valid([]byte(sanitized)) {
+		return normalizeCassetteBody(json.RawMessage(sanitized)), ""
+	}
+	return nil, sanitized
+}
+
+// normalizeCassetteBody returns a JSON body in a canonical form, with sorted keys and no whitespace.
+func normalizeCassetteBody(body json.RawMessage) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return normalized
+}
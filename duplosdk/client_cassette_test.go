@@ -0,0 +1,92 @@
+package duplosdk
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	// Record a few calls.
+	calls := 0
+	srv, c, err := SetupClientFlaky(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			calls++
+			w.Write([]byte(`{"Name":"web","Replicas":` + strings.Repeat("1", calls) + `,"Token":"abc123"}`)) // nolint
+		case http.MethodPost:
+			w.Write([]byte(`{"Status":"created"}`)) // nolint
+		}
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.UseCassette(dir, CassetteRecord))
+
+	rp := map[string]interface{}{}
+	require.Nil(t, c.getAPI("get", "subscriptions/tenant/web", &rp))
+	require.Nil(t, c.getAPI("get", "subscriptions/tenant/web", &rp))
+	rq := map[string]interface{}{"Name": "web", "Password": "s3cr3t", "Replicas": 2}
+	require.Nil(t, c.postAPI("post", "subscriptions/tenant/web", &rq, &rp))
+	secret := DuploAwsSecretCreateRequest{Name: "db", SecretString: `{"password":"pl41nt3xt"}`}
+	require.Nil(t, c.postAPI("post", "subscriptions/tenant/secret", &secret, &rp))
+	TeardownClient(srv, c)
+
+	// Sanitized interactions were written.
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	assert.Equal(t, "0001-GET-subscriptions_tenant_web.json", filepath.Base(files[0]))
+	for _, file := range files {
+		bytes, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(bytes), "s3cr3t")
+		assert.NotContains(t, string(bytes), "pl41nt3xt")
+		assert.NotContains(t, string(bytes), "abc123")
+		assert.NotContains(t, string(bytes), "FAKE")
+	}
+
+	// Replay them, without a server.
+	c, err = NewClient("http://127.0.0.1:1", "OTHER")
+	require.NoError(t, err)
+	require.NoError(t, c.UseCassette(dir, CassetteReplay))
+
+	rp = map[string]interface{}{}
+	require.Nil(t, c.getAPI("get", "subscriptions/tenant/web", &rp))
+	assert.EqualValues(t, 1, rp["Replicas"])
+	assert.Equal(t, "***", rp["Token"])
+	require.Nil(t, c.getAPI("get", "subscriptions/tenant/web", &rp))
+	assert.EqualValues(t, 11, rp["Replicas"])
+
+	// Once exhausted, the last interaction is repeated.
+	require.Nil(t, c.getAPI("get", "subscriptions/tenant/web", &rp))
+	assert.EqualValues(t, 11, rp["Replicas"])
+
+	// Bodies are matched regardless of their key order and secrets.
+	body := struct {
+		Replicas int
+		Password string
+		Name     string
+	}{2, "other", "web"}
+	rp = map[string]interface{}{}
+	require.Nil(t, c.postAPI("post", "subscriptions/tenant/web", &body, &rp))
+	assert.Equal(t, "created", rp["Status"])
+
+	// Unknown requests fail.
+	body.Replicas = 3
+	err = c.postAPI("post", "subscriptions/tenant/web", &body, &rp)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no recorded interaction")
+}
+
+func TestCassette_UnknownMode(t *testing.T) {
+	c, err := NewClient("http://127.0.0.1:1", "FAKE")
+	require.NoError(t, err)
+	assert.Error(t, c.UseCassette(t.TempDir(), "rewind"))
+	assert.Error(t, c.UseCassette(t.TempDir(), CassetteReplay))
+}
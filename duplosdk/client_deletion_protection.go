@@ -0,0 +1,49 @@
+package duplosdk
+
+import "path"
+
+// DeletionProtectionRule protects the resources that match it from deletion.
+//
+// ResourceType and Names are globs, with the syntax of path.Match.  An empty ResourceType matches
+// every resource type, and an empty list of Names matches every name.
+type DeletionProtectionRule struct {
+	ResourceType string
+	Names        []string
+}
+
+// DeletionProtectionPolicy lists the resources that the provider refuses to delete.
+// A resource is protected when it matches any of the rules.
+type DeletionProtectionPolicy struct {
+	Rules []DeletionProtectionRule
+}
+
+// Protects returns true if the policy protects the named resource of the given type.
+// A nil policy protects nothing.
+func (p *DeletionProtectionPolicy) Protects(resourceType, name string) bool {
+	if p == nil {
+		return false
+	}
+	for _, rule := range p.Rules {
+		if rule.matches(resourceType, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r DeletionProtectionRule) matches(resourceType, name string) bool {
+	if r.ResourceType != "" {
+		if ok, _ := path.Match(r.ResourceType, resourceType); !ok {
+			return false
+		}
+	}
+	if len(r.Names) == 0 {
+		return true
+	}
+	for _, pattern := range r.Names {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package duplosdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors for the kinds of failures reported by the Duplo API.
+//
+// Every ClientError returned by the SDK wraps at most one of them - except for a 404 that ASP.NET
+// reports as a missing API, which wraps both ErrNotFound and ErrAPIUnsupported - so callers can test
+// for a failure with errors.Is instead of comparing status codes:
+//
+//	if errors.Is(err, duplosdk.ErrNotFound) { ... }
+var (
+	ErrNotFound       = errors.New("duplo: object not found")
+	ErrConflict       = errors.New("duplo: conflicting change")
+	ErrRateLimited    = errors.New("duplo: rate limited")
+	ErrUnauthorized   = errors.New("duplo: unauthorized")
+	ErrAPIUnsupported = errors.New("duplo: API not supported by this portal")
+	ErrValidation     = errors.New("duplo: invalid request")
+)
+
+// ValidationError describes a request that Duplo rejected as invalid.  It wraps ErrValidation.
+type ValidationError struct {
+	// Message is Duplo's description of the failure.
+	Message string
+
+	// Fields maps the name of each invalid field of the request, as reported by Duplo, to its errors.
+	Fields map[string][]string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(e.Message)
+	for _, name := range names {
+		fmt.Fprintf(&sb, "; %s: %s", name, strings.Join(e.Fields[name], ", "))
+	}
+	return sb.String()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// Messages that ASP.NET returns when no API matches a request.
+var unsupportedAPIMessages = []string{
+	"No HTTP resource was found that matches the request URI",
+	"No action was found on the controller",
+	"The requested resource does not support http method",
+}
+
+// Unwrap returns the sentinel errors, or *ValidationError, that match this error.
+func (e clientError) Unwrap() []error {
+	switch e.status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return []error{ErrUnauthorized}
+	case http.StatusConflict:
+		return []error{ErrConflict}
+	case http.StatusTooManyRequests:
+		return []error{ErrRateLimited}
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return []error{ErrAPIUnsupported}
+	}
+	if e.status < 400 {
+		return nil
+	}
+
+	message := e.duploMessage()
+	unsupported := false
+	for _, u := range unsupportedAPIMessages {
+		if strings.Contains(message, u) {
+			unsupported = true
+			break
+		}
+	}
+
+	// A 404 always means that the object is missing, even when ASP.NET reports it as a missing API,
+	// as it does for some routes whose object does not exist.
+	if e.status == http.StatusNotFound {
+		if unsupported {
+			return []error{ErrNotFound, ErrAPIUnsupported}
+		}
+		return []error{ErrNotFound}
+	}
+
+	if strings.Contains(message, RateExceededMsg) {
+		return []error{ErrRateLimited}
+	}
+	if unsupported {
+		return []error{ErrAPIUnsupported}
+	}
+	switch e.status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return []error{&ValidationError{Message: message, Fields: e.modelState()}}
+	}
+	return nil
+}
+
+// duploMessage returns the message from Duplo's error response, falling back to the error's message.
+func (e clientError) duploMessage() string {
+	for _, key := range []string{"ExceptionMessage", "Message"} {
+		if message, ok := e.response[key].(string); ok && message != "" {
+			if detail, ok := e.response["MessageDetail"].(string); ok && detail != "" {
+				message += ": " + detail
+			}
+			return message
+		}
+	}
+	return e.message
+}
+
+// modelState returns the invalid fields listed by an ASP.NET error response, if any.
+func (e clientError) modelState() map[string][]string {
+	modelState, ok := e.response["ModelState"].(map[string]interface{})
+	if !ok || len(modelState) == 0 {
+		return nil
+	}
+
+	fields := make(map[string][]string, len(modelState))
+	for name, value := range modelState {
+		switch v := value.(type) {
+		case []interface{}:
+			for _, message := range v {
+				fields[name] = append(fields[name], fmt.Sprint(message))
+			}
+		default:
+			fields[name] = append(fields[name], fmt.Sprint(v))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,118 @@
+package duplosdk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/internal/duplosdktest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	faultsTenantID    = "302c6a63-ffc4-4276-b52e-48a84108b658"
+	faultsTenantRoute = "/v3/admin/tenant/:tenantId"
+)
+
+func SetupClientEmulator(t *testing.T, faults ...duplosdktest.EmuFault) (*Client, func()) {
+	srv := duplosdktest.NewEmulator(duplosdktest.EmuConfig{})
+	duplosdktest.ResetEmulator()
+	duplosdktest.InjectFaults(faults...)
+
+	c, err := NewClient(srv.URL, "FAKE")
+	require.NoError(t, err)
+	c.RetryPolicy = RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	return c.WithoutCache(), func() { duplosdktest.TeardownHttptest(srv) }
+}
+
+func TestEmulatorFaults_RetriedStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		c, teardown := SetupClientEmulator(t, duplosdktest.EmuFailTimes("GET", faultsTenantRoute, 2, status))
+
+		tenant, err := c.TenantGetV3(faultsTenantID)
+		assert.Nil(t, err, "status %d", status)
+		require.NotNil(t, tenant)
+		assert.Equal(t, "testacc1a", tenant.AccountName)
+		assert.Equal(t, 2, duplosdktest.EmuFaultCount("GET", faultsTenantRoute))
+		teardown()
+	}
+}
+
+func TestEmulatorFaults_InternalServerError(t *testing.T) {
+	c, teardown := SetupClientEmulator(t, duplosdktest.EmuFailTimes("GET", faultsTenantRoute, 1, http.StatusInternalServerError))
+	defer teardown()
+
+	// A 500 is not retried, but the next call succeeds.
+	_, err := c.TenantGetV3(faultsTenantID)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+
+	tenant, err := c.TenantGetV3(faultsTenantID)
+	assert.Nil(t, err)
+	assert.NotNil(t, tenant)
+}
+
+func TestEmulatorFaults_RateExceeded(t *testing.T) {
+	c, teardown := SetupClientEmulator(t, duplosdktest.EmuRateExceeded("GET", faultsTenantRoute, 3))
+	defer teardown()
+
+	tenant := DuploTenant{}
+	err := c.getAPIWithRetry("TenantGetV3", "v3/admin/tenant/"+faultsTenantID, &tenant)
+	assert.Nil(t, err)
+	assert.Equal(t, faultsTenantID, tenant.TenantID)
+	assert.Equal(t, 3, duplosdktest.EmuFaultCount("GET", faultsTenantRoute))
+
+	// Give up once the retries are exhausted, without retrying again on top of the retry policy.
+	duplosdktest.ResetEmulator()
+	duplosdktest.InjectFaults(duplosdktest.EmuRateExceeded("GET", faultsTenantRoute, 0))
+	err = c.getAPIWithRetry("TenantGetV3", "v3/admin/tenant/"+faultsTenantID, &tenant)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), RateExceededMsg)
+	assert.Equal(t, c.RetryPolicy.MaxRetries+1, duplosdktest.EmuFaultCount("GET", faultsTenantRoute))
+}
+
+func TestEmulatorFaults_BusyRetries(t *testing.T) {
+	c, teardown := SetupClientEmulator(t, duplosdktest.EmuFailTimes("GET", faultsTenantRoute, 2, http.StatusBadRequest))
+	defer teardown()
+
+	// A 400 is only retried by the APIs that expect Duplo to report a busy cloud resource this way.
+	_, err := c.TenantGetV3(faultsTenantID)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+
+	tenant := DuploTenant{}
+	err = c.getAPIWithRetry("TenantGetV3", "v3/admin/tenant/"+faultsTenantID, &tenant)
+	assert.Nil(t, err)
+	assert.Equal(t, faultsTenantID, tenant.TenantID)
+	assert.Equal(t, 2, duplosdktest.EmuFaultCount("GET", faultsTenantRoute))
+}
+
+func TestEmulatorFaults_Delay(t *testing.T) {
+	c, teardown := SetupClientEmulator(t, duplosdktest.EmuFault{Method: "GET", Route: faultsTenantRoute, Times: 1, Delay: 200 * time.Millisecond})
+	defer teardown()
+
+	c.RetryPolicy.MaxRetries = 0
+	c.HTTPClient.Timeout = 50 * time.Millisecond
+	_, err := c.TenantGetV3(faultsTenantID)
+	assert.NotNil(t, err)
+
+	tenant, err := c.TenantGetV3(faultsTenantID)
+	assert.Nil(t, err)
+	assert.NotNil(t, tenant)
+}
+
+func TestEmulatorFaults_TruncatedJSON(t *testing.T) {
+	c, teardown := SetupClientEmulator(t, duplosdktest.EmuFault{Method: "GET", Route: faultsTenantRoute, Times: 1, Truncate: true})
+	defer teardown()
+
+	_, err := c.TenantGetV3(faultsTenantID)
+	require.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	tenant, err := c.TenantGetV3(faultsTenantID)
+	assert.Nil(t, err)
+	assert.NotNil(t, tenant)
+}
@@ -0,0 +1,175 @@
+package duplosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem that Duplo API requests and responses are logged to.
+// Its level can be set with the TF_LOG_SDK_DUPLO_API environment variable.
+const LogSubsystem = "duplo_api"
+
+// RequestIDHeader is the header that carries the ID of each request sent to Duplo.
+// It is also logged, so that a log line can be matched with Duplo's own logs.
+const RequestIDHeader = "X-Request-ID"
+
+// redactedValue replaces the value of sensitive fields in logs.
+const redactedValue = "***"
+
+// sensitiveFields holds the normalized names of the JSON fields that are redacted from logs.
+var sensitiveFields = struct {
+	sync.RWMutex
+	names map[string]bool
+}{names: map[string]bool{}}
+
+func init() {
+	RegisterSensitiveFields(
+		"Password", "SecretData", "SecretValue", "Token", "AccessToken", "SessionToken",
+		"SecretAccessKey", "SecretKey", "ClientSecret", "PrivateKey", "ConnectionString",
+		"SecretString", "SecretBinary", "AuthToken", "SASToken", "SASSignature",
+	)
+}
+
+// RegisterSensitiveFields marks JSON fields as sensitive, so that their values are redacted from logs.
+// Names are matched regardless of case and underscores, so that `secret_data` in a Terraform schema
+// matches `SecretData` in a Duplo API payload.  They apply to every payload, so generic names such as
+// `Value` must not be registered.
+func RegisterSensitiveFields(names ...string) {
+	sensitiveFields.Lock()
+	defer sensitiveFields.Unlock()
+	for _, name := range names {
+		sensitiveFields.names[normalizeFieldName(name)] = true
+	}
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+func isSensitiveField(name string) bool {
+	normalized := normalizeFieldName(name)
+	if strings.Contains(normalized, "password") {
+		return true
+	}
+	sensitiveFields.RLock()
+	defer sensitiveFields.RUnlock()
+	return sensitiveFields.names[normalized]
+}
+
+// redactBody returns a JSON body with the values of all sensitive fields redacted.
+// Bodies that are not JSON objects or arrays are returned as-is.
+func redactBody(body []byte) string {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return string(body)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// contextualSensitiveFields returns the fields of an object that are only sensitive because of the object's type,
+// such as the value of an SSM SecureString parameter, or the data of a kubernetes secret.
+func contextualSensitiveFields(v map[string]interface{}) map[string]bool {
+	if v["Type"] == "SecureString" {
+		return map[string]bool{"Value": true}
+	}
+	if v["kind"] == "Secret" {
+		return map[string]bool{"data": true, "stringData": true}
+	}
+	return nil
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		contextual := contextualSensitiveFields(v)
+		for key, child := range v {
+			if child != nil && (contextual[key] || isSensitiveField(key)) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+	}
+	return value
+}
+
+// withAPIName returns a context whose Duplo API logs carry the name of the SDK method being called.
+func withAPIName(ctx context.Context, apiName string) context.Context {
+	return tflog.SubsystemSetField(ctx, LogSubsystem, "duplo_api", apiName)
+}
+
+// requestBody returns a copy of a request's body, without consuming it.
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	bytes, _ := io.ReadAll(body)
+	return bytes
+}
+
+// logRequest assigns an ID to a request, and logs it before it is sent.
+func logRequest(req *http.Request) {
+	requestID := uuid.NewString()
+	req.Header.Set(RequestIDHeader, requestID)
+
+	fields := map[string]interface{}{
+		"request_id":  requestID,
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+	}
+	if body := requestBody(req); len(body) > 0 {
+		fields["request_body"] = redactBody(body)
+	}
+	tflog.SubsystemTrace(req.Context(), LogSubsystem, "Sending Duplo API request", fields)
+}
+
+// logResponse logs the outcome of a request, once its response has been received and read.
+func logResponse(req *http.Request, start time.Time, status int, body []byte, err error) {
+	fields := map[string]interface{}{
+		"request_id":  req.Header.Get(RequestIDHeader),
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+		"latency_ms":  time.Since(start).Milliseconds(),
+	}
+	if status > 0 {
+		fields["http_status"] = status
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(req.Context(), LogSubsystem, "Duplo API request failed", fields)
+		return
+	}
+	if len(body) > 0 {
+		fields["response_body"] = redactBody(body)
+	}
+	tflog.SubsystemTrace(req.Context(), LogSubsystem, "Received Duplo API response", fields)
+}
@@ -0,0 +1,115 @@
+package duplosdk
+
+import (
+	"context"
+	"io"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles the requests a Client sends to the Duplo API.
+//
+// It combines a token bucket, which limits how many requests are started per second, with a
+// semaphore, which limits how many requests are in flight at once.  A single RateLimiter is
+// shared by every copy of a Client returned by WithContext.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64   // tokens added per second, or zero for no rate limit
+	burst  float64   // maximum number of tokens in the bucket
+	tokens float64   // tokens currently available; negative when requests are queued
+	last   time.Time // last time tokens were added to the bucket
+
+	slots chan struct{} // semaphore for in-flight requests, or nil for no concurrency limit
+}
+
+// NewRateLimiter creates a rate limiter allowing requestsPerSecond requests to be started per second,
+// and at most maxConcurrent requests in flight.  A value of zero disables the corresponding limit.
+// It returns nil if both limits are disabled.
+func NewRateLimiter(requestsPerSecond float64, maxConcurrent int) *RateLimiter {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return nil
+	}
+
+	l := &RateLimiter{}
+	if requestsPerSecond > 0 {
+		l.rate = requestsPerSecond
+		l.burst = math.Max(1, math.Ceil(requestsPerSecond))
+		l.tokens = l.burst
+		l.last = time.Now()
+	}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// reserve takes a token from the bucket, returning how long the caller must wait before using it.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token to the bucket.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+// acquire waits until a request may be sent, and returns a function that must be called once it completes.
+func (l *RateLimiter) acquire(ctx context.Context, apiCaller string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+
+	// Wait for a token.
+	if l.rate > 0 {
+		if delay := l.reserve(); delay > 0 {
+			if err := sleepWithContext(ctx, delay); err != nil {
+				l.cancel()
+				return nil, err
+			}
+		}
+	}
+
+	// Wait for an in-flight slot.
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-l.slots }) }
+	}
+
+	if waited := time.Since(start); waited >= time.Millisecond {
+		log.Printf("[TRACE] duplo-rateLimiter: %s waited %s before sending", apiCaller, waited)
+	}
+	return release, nil
+}
+
+// releaseOnClose releases a rate limiter slot once the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	defer r.release()
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,133 @@
+package duplosdk
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReadCacheTTL is how long NewClient's read cache keeps a response.
+const DefaultReadCacheTTL = 5 * time.Minute
+
+// ReadCache memoizes Duplo API reads that do not change during a Terraform run, such as
+// the list of tenants, a tenant's AWS account ID, infrastructure configuration and system features.
+//
+// Entries expire after a TTL, and are explicitly invalidated by the SDK methods that change them.
+// A single ReadCache is shared by every copy of a Client returned by WithContext or WithoutCache.
+type ReadCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]readCacheEntry
+}
+
+type readCacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// NewReadCache creates a read cache whose entries expire after ttl.
+func NewReadCache(ttl time.Duration) *ReadCache {
+	return &ReadCache{ttl: ttl, entries: map[string]readCacheEntry{}}
+}
+
+func (rc *ReadCache) get(key string) ([]byte, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+	return entry.body, true
+}
+
+func (rc *ReadCache) put(key string, body []byte) {
+	if rc == nil || rc.ttl <= 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[key] = readCacheEntry{body: body, expires: time.Now().Add(rc.ttl)}
+}
+
+// Invalidate removes every cached response whose API path starts with one of the given prefixes.
+func (rc *ReadCache) Invalidate(prefixes ...string) {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for key := range rc.entries {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				log.Printf("[TRACE] duplo-readCache: invalidated %s", key)
+				delete(rc.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// WithoutCache returns a shallow copy of the client that always reads from the Duplo API.
+// It is meant for callers that poll for a change, or that must observe the current state of
+// the object they manage.  Writes made through the copy still invalidate the shared cache.
+func (c *Client) WithoutCache() *Client {
+	c2 := *c
+	c2.bypassCache = true
+	return &c2
+}
+
+// getAPICached is like getAPI, but serves repeated reads of the same API path from the read cache.
+// Only responses for which keep returns true are cached, so that missing objects are looked up again.
+func (c *Client) getAPICached(apiName string, apiPath string, rp interface{}, keep func() bool) ClientError {
+	if c.bypassCache {
+		return c.getAPI(apiName, apiPath, rp)
+	}
+
+	if body, ok := c.ReadCache.get(apiPath); ok {
+		if err := json.Unmarshal(body, rp); err == nil {
+			log.Printf("[TRACE] getAPI %s: served from cache", apiName)
+			return nil
+		}
+	}
+
+	err := c.getAPI(apiName, apiPath, rp)
+	if err != nil || !keep() {
+		return err
+	}
+	if body, jsonErr := json.Marshal(rp); jsonErr == nil {
+		c.ReadCache.put(apiPath, body)
+	}
+	return nil
+}
+
+// invalidateTenantCache drops cached reads that describe the given tenant, or list all tenants.
+func (c *Client) invalidateTenantCache(tenantID string) {
+	prefixes := []string{"admin/GetTenantsForUser"}
+	if tenantID != "" {
+		prefixes = append(prefixes,
+			"v2/admin/TenantV2/"+tenantID,
+			"v3/admin/tenant/"+tenantID,
+			"subscriptions/"+tenantID+"/")
+	}
+	c.ReadCache.Invalidate(prefixes...)
+}
+
+// invalidateInfrastructureCache drops cached reads of the given infrastructure's configuration.
+func (c *Client) invalidateInfrastructureCache(infraName string) {
+	c.ReadCache.Invalidate("v3/admin/infrastructure/" + infraName)
+}
+
+// invalidateSystemFeaturesCache drops cached reads of the system features.
+func (c *Client) invalidateSystemFeaturesCache() {
+	c.ReadCache.Invalidate("v3/features/system")
+}
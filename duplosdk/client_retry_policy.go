@@ -0,0 +1,209 @@
+package duplosdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default transport-level retry settings.
+const (
+	DefaultMaxRetries      = 5
+	DefaultRetryMinBackoff = 1 * time.Second
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy controls how Client.doRequestWithStatus retries transient failures from the Duplo API.
+//
+// A request is retried when Duplo answers with 429, 502, 503 or 504, when the response body carries
+// a "Rate exceeded" message, or when the connection fails before a response is received.  Statuses
+// and connection errors that could mean the request was already processed (502, 504, connection resets)
+// are only retried for idempotent methods.  Requests made with withRetryStatuses also retry the statuses
+// that Duplo uses to report a busy cloud resource.
+type RetryPolicy struct {
+	MaxRetries int           // Maximum number of retries after the first attempt.  Zero disables retries.
+	MinBackoff time.Duration // Delay before the first retry.
+	MaxBackoff time.Duration // Upper bound for the exponential backoff between retries.
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		MinBackoff: DefaultRetryMinBackoff,
+		MaxBackoff: DefaultRetryMaxBackoff,
+	}
+}
+
+// backoff calculates the delay before the given retry attempt (starting at 1), using exponential backoff with jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Add up to 20% of jitter, so that parallel resources do not retry in lock-step.
+	if jitter := int64(delay) / 5; jitter > 0 {
+		delay += time.Duration(rand.Int63n(jitter))
+	}
+	return delay
+}
+
+// isIdempotentMethod returns true if a request with the given method can safely be sent more than once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryPolicyKey is the context key for a retry policy that replaces the client's one.
+type retryPolicyKey struct{}
+
+// withRetryPolicy returns a copy of the client whose requests are retried according to the given policy,
+// instead of the client's RetryPolicy.  It is meant for the Duplo APIs that are known to be slow to settle.
+func (c *Client) withRetryPolicy(policy RetryPolicy) *Client {
+	return c.WithContext(context.WithValue(c.Context(), retryPolicyKey{}, policy))
+}
+
+// retryPolicy returns the policy that applies to a request made with the given context.
+func (c *Client) retryPolicy(ctx context.Context) RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return policy
+	}
+	return c.RetryPolicy
+}
+
+// retryStatusesKey is the context key for the statuses that a request retries, on top of the transient ones.
+type retryStatusesKey struct{}
+
+// withRetryStatuses returns a context whose requests are also retried when Duplo answers with one of the given
+// statuses, with an HRESULT timeout message, or when an idempotent request times out.
+//
+// Some Duplo APIs report a cloud resource that is still busy - such as a DynamoDB table being updated - this way.
+func withRetryStatuses(ctx context.Context, statuses ...int) context.Context {
+	return context.WithValue(ctx, retryStatusesKey{}, statuses)
+}
+
+// retryStatuses returns the statuses that were added to a context by withRetryStatuses.
+func retryStatuses(ctx context.Context) []int {
+	statuses, _ := ctx.Value(retryStatusesKey{}).([]int)
+	return statuses
+}
+
+// retryableConnectionError returns true if err is a connection failure that is worth retrying.
+func retryableConnectionError(req *http.Request, err error) bool {
+	method := req.Method
+	msg := err.Error()
+
+	// The request never reached Duplo, so it is always safe to retry.
+	if strings.Contains(msg, "connection refused") {
+		return true
+	}
+
+	// The request may have been processed, so only retry if it can safely be repeated.
+	if isIdempotentMethod(method) {
+		for _, connErr := range []string{"connection reset", "GOAWAY", "broken pipe", "EOF"} {
+			if strings.Contains(msg, connErr) {
+				return true
+			}
+		}
+
+		// The HTTP client timed out, which is only retried when the caller asked for it.
+		var netErr interface{ Timeout() bool }
+		if len(retryStatuses(req.Context())) > 0 && req.Context().Err() == nil && errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+	}
+	return false
+}
+
+// retryableResponse returns true if res is a transient failure that is worth retrying.
+// The response body is buffered so that it can still be read by the caller.
+func retryableResponse(req *http.Request, res *http.Response) bool {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotentMethod(req.Method)
+	}
+
+	statuses := retryStatuses(req.Context())
+	for _, status := range statuses {
+		if res.StatusCode == status {
+			return true
+		}
+	}
+
+	// Duplo relays cloud provider throttling as an error with a "Rate exceeded" message,
+	// and cloud provider timeouts as an error with an HRESULT message.
+	if res.StatusCode >= 400 {
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return false
+		}
+		return bytes.Contains(body, []byte(RateExceededMsg)) || (len(statuses) > 0 && bytes.Contains(body, []byte("HRESULT")))
+	}
+
+	return false
+}
+
+// retryAfter returns the delay requested by a response's Retry-After header, capped by the policy's MaxBackoff
+// so that a misbehaving server cannot stall a run.
+func (p RetryPolicy) retryAfter(res *http.Response) time.Duration {
+	delay := retryAfter(res)
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// retryAfter returns the delay requested by a response's Retry-After header, or zero if there is none.
+func retryAfter(res *http.Response) time.Duration {
+	value := strings.TrimSpace(res.Header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// rewindRequest prepares a request to be sent again, by resetting its body.
+func rewindRequest(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		log.Printf("[TRACE] duplo-doRequest: cannot rewind request body: %s", err)
+		return false
+	}
+	req.Body = body
+	return true
+}
@@ -1,9 +1,15 @@
 package duplosdk
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -322,6 +328,455 @@ func TestDeleteAPI_ResponseElided_InvalidResponseJson(t *testing.T) {
 	assert.True(t, strings.HasPrefix(result.Error(), invalidJsonMsg))
 }
 
+// Should not send a request when the client's context is already cancelled.
+func TestGetAPI_ContextCancelled(t *testing.T) {
+	srv, c, err := SetupClientOneshot(t, "GET", 200, "{\"foo\":\"bar\"}")
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rp := struct {
+		Foo string `json:"foo"`
+	}{}
+	result := c.WithContext(ctx).getAPI("testAPI", "/test", &rp)
+
+	assert.NotNil(t, result)
+	assert.Contains(t, result.Error(), "context canceled")
+	assert.Equal(t, "", rp.Foo)
+}
+
+// Should not wait longer than the maximum backoff, whatever the Retry-After header asks for.
+func TestGetAPI_RetryAfterCapped(t *testing.T) {
+	calls := 0
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls == 1 {
+			res.Header().Set("Retry-After", "3600")
+			res.WriteHeader(429)
+			return
+		}
+		res.WriteHeader(200)
+		res.Write([]byte("null")) // nolint
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	start := time.Now()
+	result := c.getAPI("testAPI", "/test", nil)
+
+	assert.Nil(t, result)
+	assert.Equal(t, 2, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// Should retry transient failures until the request succeeds.
+func TestGetAPI_RetryTransientFailures(t *testing.T) {
+	calls := 0
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			res.WriteHeader(503)
+		case 2:
+			res.WriteHeader(400)
+			res.Write([]byte("Rate exceeded")) // nolint
+		default:
+			res.WriteHeader(200)
+			res.Write([]byte("{\"foo\":\"bar\"}")) // nolint
+		}
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	rp := struct {
+		Foo string `json:"foo"`
+	}{}
+	result := c.getAPI("testAPI", "/test", &rp)
+
+	assert.Nil(t, result)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "bar", rp.Foo)
+}
+
+// Should give up after the maximum number of retries.
+func TestGetAPI_RetryExhausted(t *testing.T) {
+	calls := 0
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		res.Header().Set("Retry-After", "0")
+		res.WriteHeader(429)
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	result := c.getAPI("testAPI", "/test", nil)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, 429, result.Status())
+	assert.Equal(t, c.RetryPolicy.MaxRetries+1, calls)
+}
+
+// Should follow the retry policy of the call, instead of the client's one.
+func TestGetAPI_RetryPolicyOverride(t *testing.T) {
+	calls := 0
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		res.Header().Set("Retry-After", "0")
+		res.WriteHeader(429)
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	policy := RetryPolicy{MaxRetries: 7, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	result := c.withRetryPolicy(policy).getAPI("testAPI", "/test", nil)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, policy.MaxRetries+1, calls)
+
+	// The client itself keeps its own policy.
+	calls = 0
+	c.getAPI("testAPI", "/test", nil) // nolint
+	assert.Equal(t, c.RetryPolicy.MaxRetries+1, calls)
+}
+
+// Should not retry a non-idempotent request that may already have been processed.
+func TestPostAPI_NoRetryOnBadGateway(t *testing.T) {
+	calls := 0
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		res.WriteHeader(502)
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	rq := struct{}{}
+	result := c.postAPI("testAPI", "/test", &rq, nil)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, 502, result.Status())
+	assert.Equal(t, 1, calls)
+}
+
+// Should never have more requests in flight than the rate limiter allows.
+func TestGetAPI_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		res.WriteHeader(200)
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+	c.RateLimiter = NewRateLimiter(0, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, c.getAPI("testAPI", "/test", nil))
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxInFlight, int32(2))
+}
+
+// Should delay requests once the token bucket is empty.
+func TestRateLimiter_RequestsPerSecond(t *testing.T) {
+	l := NewRateLimiter(10, 0)
+
+	start := time.Now()
+	for i := 0; i < 12; i++ {
+		release, err := l.acquire(context.Background(), "testAPI")
+		assert.Nil(t, err)
+		release()
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+// Should serve repeated reads from the cache until they are invalidated.
+func TestListTenantsForUser_ReadCache(t *testing.T) {
+	calls := 0
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		calls++
+		res.WriteHeader(200)
+		res.Write([]byte("[{\"TenantId\":\"1\",\"AccountName\":\"one\"}]")) // nolint
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	for i := 0; i < 3; i++ {
+		tenant, err := c.GetTenantForUser("1")
+		assert.Nil(t, err)
+		assert.Equal(t, "one", tenant.AccountName)
+	}
+	assert.Equal(t, 1, calls)
+
+	_, err = c.WithoutCache().ListTenantsForUser()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+
+	c.invalidateTenantCache("")
+	_, err = c.ListTenantsForUser()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+type testTokenSource struct {
+	tokens []string
+}
+
+func (s *testTokenSource) Token(ctx context.Context) (string, error) {
+	return s.tokens[0], nil
+}
+
+func (s *testTokenSource) Invalidate() {
+	s.tokens = s.tokens[1:]
+}
+
+// Should refresh a token from a token source once it is rejected.
+func TestGetAPI_TokenSourceRefreshOnUnauthorized(t *testing.T) {
+	srv := duplosdktest.SetupHttptest(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer fresh" {
+			res.WriteHeader(401)
+			return
+		}
+		res.WriteHeader(200)
+		res.Write([]byte("{\"foo\":\"bar\"}")) // nolint
+	}))
+	defer duplosdktest.TeardownHttptest(srv)
+
+	ts := &testTokenSource{tokens: []string{"stale", "fresh"}}
+	c, err := NewClientWithTokenSource(srv.URL, ts)
+	assert.Nil(t, err, err)
+
+	rp := struct {
+		Foo string `json:"foo"`
+	}{}
+	result := c.getAPI("testAPI", "/test", &rp)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "bar", rp.Foo)
+	assert.Equal(t, []string{"fresh"}, ts.tokens)
+}
+
+func TestGetAPI_RequestIDHeader(t *testing.T) {
+	var requestIDs []string
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		requestIDs = append(requestIDs, req.Header.Get(RequestIDHeader))
+		res.WriteHeader(200)
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	assert.Nil(t, c.getAPI("testAPI", "/test", nil))
+	assert.Nil(t, c.getAPI("testAPI", "/test", nil))
+
+	assert.Len(t, requestIDs, 2)
+	assert.NotEmpty(t, requestIDs[0])
+	assert.NotEqual(t, requestIDs[0], requestIDs[1])
+}
+
+// Should apply a kubernetes object to the tenant's namespace, with the tenant's JIT credentials.
+func TestK8sManifestApply_ServerSideApply(t *testing.T) {
+	var applied *http.Request
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v3/subscriptions/tenant-id/k8s/jitAccess":
+			res.Write([]byte(`{"ApiServer": "http://` + req.Host + `", "Token": "k8s-token", "DefaultNamespace": "duploservices-app"}`)) // nolint
+		case "/apis/apps/v1":
+			res.Write([]byte(`{"resources": [{"name": "deployments/scale", "kind": "Scale"}, {"name": "deployments", "kind": "Deployment", "namespaced": true}]}`)) // nolint
+		default:
+			applied = req
+			res.Write([]byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "web", "uid": "1234"}}`)) // nolint
+		}
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	rq := DuploK8sManifest{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": map[string]interface{}{"name": "web"}}
+	rp, cerr := c.K8sManifestApply("tenant-id", rq, "terraform", true)
+	assert.Nil(t, cerr)
+	assert.Equal(t, "1234", rp["metadata"].(map[string]interface{})["uid"])
+
+	assert.NotNil(t, applied)
+	assert.Equal(t, "PATCH", applied.Method)
+	assert.Equal(t, "/apis/apps/v1/namespaces/duploservices-app/deployments/web", applied.URL.Path)
+	assert.Equal(t, "fieldManager=terraform&force=true", applied.URL.RawQuery)
+	assert.Equal(t, "application/apply-patch+yaml", applied.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer k8s-token", applied.Header.Get("Authorization"))
+}
+
+// countingTransport records the paths of the requests that go through it.
+type countingTransport struct {
+	base  http.RoundTripper
+	paths []string
+}
+
+func (ct *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ct.paths = append(ct.paths, req.URL.Path)
+	return ct.base.RoundTrip(req)
+}
+
+// Should fetch the tenant's credentials and discover its resources once, and never call the cluster with Duplo's transport.
+func TestK8sManifest_ClientCached(t *testing.T) {
+	requests := map[string]int{}
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		requests[req.Method+" "+req.URL.Path]++
+		switch req.URL.Path {
+		case "/v3/subscriptions/tenant-id/k8s/jitAccess":
+			res.Write([]byte(`{"ApiServer": "http://` + req.Host + `", "Token": "k8s-token", "DefaultNamespace": "duploservices-app"}`)) // nolint
+		case "/apis/apps/v1":
+			res.Write([]byte(`{"resources": [{"name": "deployments", "kind": "Deployment", "namespaced": true}]}`)) // nolint
+		default:
+			res.Write([]byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "web"}}`)) // nolint
+		}
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	duplo := &countingTransport{base: http.DefaultTransport}
+	c.HTTPClient.Transport = duplo
+
+	rq := DuploK8sManifest{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": map[string]interface{}{"name": "web"}}
+	for i := 0; i < 2; i++ {
+		_, cerr := c.K8sManifestApply("tenant-id", rq, "terraform", false)
+		assert.Nil(t, cerr)
+	}
+	rp, cerr := c.WithContext(context.Background()).K8sManifestGet("tenant-id", "apps/v1", "Deployment", "web")
+	assert.Nil(t, cerr)
+	assert.NotNil(t, rp)
+
+	assert.Equal(t, 1, requests["GET /v3/subscriptions/tenant-id/k8s/jitAccess"])
+	assert.Equal(t, 1, requests["GET /apis/apps/v1"])
+	assert.Equal(t, 2, requests["PATCH /apis/apps/v1/namespaces/duploservices-app/deployments/web"])
+	assert.Equal(t, 1, requests["GET /apis/apps/v1/namespaces/duploservices-app/deployments/web"])
+	assert.Equal(t, []string{"/v3/subscriptions/tenant-id/k8s/jitAccess"}, duplo.paths)
+
+	// A kind that is missing is discovered again, such as the kind of a CRD that was installed since.
+	_, cerr = c.K8sManifestGet("tenant-id", "apps/v1", "Widget", "web")
+	assert.NotNil(t, cerr)
+	assert.Equal(t, 2, requests["GET /apis/apps/v1"])
+}
+
+func TestRedactBody(t *testing.T) {
+	RegisterSensitiveFields("subject_token")
+
+	body := `[{"SecretName":"db","SecretData":{"user":"admin"},"Nested":{"AdminPassword":"hunter2","SubjectToken":"jwt"},"Count":1.50}]`
+	assert.Equal(t, `[{"Count":1.50,"Nested":{"AdminPassword":"***","SubjectToken":"***"},"SecretData":"***","SecretName":"db"}]`, redactBody([]byte(body)))
+
+	// Tenant secrets, SSM secure strings and kubernetes secrets.
+	secret, _ := json.Marshal(DuploAwsSecretUpdateRequest{SecretId: "db", SecretValueType: "json", SecretString: `{"password":"hunter2"}`})
+	assert.Equal(t, `{"SecretId":"db","SecretString":"***","SecretValueType":"json"}`, redactBody(secret))
+	assert.Equal(t, `{"Name":"db","Type":"SecureString","Value":"***"}`, redactBody([]byte(`{"Name":"db","Type":"SecureString","Value":"hunter2"}`)))
+	assert.Equal(t, `{"Name":"db","Type":"String","Value":"plain"}`, redactBody([]byte(`{"Name":"db","Type":"String","Value":"plain"}`)))
+	assert.Equal(t, `{"data":"***","kind":"Secret"}`, redactBody([]byte(`{"kind":"Secret","data":{"password":"aHVudGVyMg=="}}`)))
+
+	assert.Equal(t, `not json`, redactBody([]byte(`not json`)))
+	assert.Equal(t, `{"Password":null}`, redactBody([]byte(`{"Password":null}`)))
+}
+
+// Should redact the sensitive fields that Duplo echoes in an error body.
+func TestGetAPI_ErrorBodyRedacted(t *testing.T) {
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(400)
+		res.Write([]byte(`{"Message":"invalid request","SecretString":"hunter2"}`)) // nolint
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	result := c.getAPI("testAPI", "/test", nil)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, 400, result.Status())
+	assert.Contains(t, result.Error(), `"SecretString":"***"`)
+	assert.NotContains(t, result.Error(), "hunter2")
+
+	// And in an unexpected response.
+	srv2, c2, err := SetupClientOneshot(t, "GET", 200, `{"SecretString":"hunter2"}`)
+	defer TeardownClient(srv2, c2)
+	assert.Nil(t, err, err)
+	result = c2.getAPI("testAPI", "/test", nil)
+	assert.NotNil(t, result)
+	assert.NotContains(t, result.Error(), "hunter2")
+}
+
+func TestClientError_Is(t *testing.T) {
+	cases := []struct {
+		status      int
+		contentType string
+		body        string
+		expected    error
+	}{
+		{404, "text/plain", "not found", ErrNotFound},
+		{404, "application/json", `{"Message":"No HTTP resource was found that matches the request URI 'x'."}`, ErrAPIUnsupported},
+		{404, "application/json", `{"Message":"No HTTP resource was found that matches the request URI 'x'."}`, ErrNotFound},
+		{405, "application/json", `{"Message":"The requested resource does not support http method 'PATCH'."}`, ErrAPIUnsupported},
+		{400, "application/json", `{"Message":"No action was found on the controller 'Tenant' that matches the request."}`, ErrAPIUnsupported},
+		{401, "text/plain", "", ErrUnauthorized},
+		{409, "text/plain", "exists", ErrConflict},
+		{400, "text/plain", RateExceededMsg, ErrRateLimited},
+		{400, "application/json", `{"Message":"The request is invalid.","ModelState":{"rq.Name":["Name is required"]}}`, ErrValidation},
+	}
+	for _, tc := range cases {
+		srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-Type", tc.contentType)
+			res.WriteHeader(tc.status)
+			_, _ = res.Write([]byte(tc.body))
+		})
+		assert.Nil(t, err, err)
+		c.RetryPolicy.MaxRetries = 0
+
+		cerr := c.postAPI("testAPI", "v3/test", map[string]string{}, nil)
+		assert.NotNil(t, cerr)
+		assert.ErrorIs(t, cerr, tc.expected, "status %d: %s", tc.status, tc.body)
+		TeardownClient(srv, c)
+	}
+}
+
+func TestClientError_ValidationFields(t *testing.T) {
+	srv, c, err := SetupClientFlaky(t, func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(400)
+		_, _ = res.Write([]byte(`{"Message":"The request is invalid.","ModelState":{"rq.Name":["Name is required"]}}`))
+	})
+	defer TeardownClient(srv, c)
+	assert.Nil(t, err, err)
+
+	cerr := c.postAPI("testAPI", "v3/test", map[string]string{}, nil)
+	var verr *ValidationError
+	if assert.ErrorAs(t, cerr, &verr) {
+		assert.Equal(t, "The request is invalid.", verr.Message)
+		assert.Equal(t, map[string][]string{"rq.Name": {"Name is required"}}, verr.Fields)
+	}
+}
+
+func TestCustomError_Is(t *testing.T) {
+	err := NewCustomError("secret not found", 404)
+	assert.Equal(t, "secret not found", err.Error())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func SetupClientFlaky(t *testing.T, handler http.HandlerFunc) (srv *httptest.Server, c *Client, err error) {
+	srv = duplosdktest.SetupHttptest(handler)
+	c, err = NewClient(srv.URL, "FAKE")
+	if c != nil {
+		c.RetryPolicy = RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	}
+	return
+}
+
 func SetupClientOneshot(t *testing.T, expectedMethod string, status int, body string) (srv *httptest.Server, c *Client, err error) {
 	srv = duplosdktest.SetupHttptestOneshot(t, expectedMethod, status, body)
 	c, err = NewClient(srv.URL, "FAKE")
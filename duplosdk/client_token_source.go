@@ -0,0 +1,242 @@
+package duplosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies the bearer token used to authenticate to the Duplo API.
+//
+// Token is called before each request, and should return a cached token when it is still valid.
+// Invalidate is called when Duplo rejects a token with a 401, so that the next call to Token
+// obtains a fresh one.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+	Invalidate()
+}
+
+// FileTokenSource reads the Duplo token from a file, and re-reads it whenever the file changes.
+type FileTokenSource struct {
+	Path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+// Token returns the token stored in the file.
+func (s *FileTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("duplo_token_file: %w", err)
+	}
+	if s.token != "" && info.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	bytes, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("duplo_token_file: %w", err)
+	}
+	token := strings.TrimSpace(string(bytes))
+	if token == "" {
+		return "", fmt.Errorf("duplo_token_file: %s is empty", s.Path)
+	}
+
+	log.Printf("[TRACE] FileTokenSource: (re)loaded token from %s", s.Path)
+	s.token, s.modTime = token, info.ModTime()
+	return s.token, nil
+}
+
+// Invalidate forces the file to be read again.
+func (s *FileTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+}
+
+// ExecCredential is the output of a credential helper run by ExecTokenSource.
+// A helper may also print the bare token instead.
+type ExecCredential struct {
+	Token      string     `json:"token"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// ExecTokenSource runs a credential helper to obtain the Duplo token, in the style of kubeconfig exec plugins.
+//
+// The helper must print either the bare token, or an ExecCredential JSON object, to stdout.  The token is
+// cached until its expiration (if any), or until Duplo rejects it.
+type ExecTokenSource struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+
+	mu         sync.Mutex
+	token      string
+	expiration *time.Time
+}
+
+// Token returns the cached token, running the credential helper if needed.
+func (s *ExecTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiration == nil || time.Now().Add(tokenExpiryLeeway).Before(*s.expiration)) {
+		return s.token, nil
+	}
+
+	cmd := exec.CommandContext(ctx, s.Command, s.Args...)
+	cmd.Env = os.Environ()
+	for k, v := range s.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	log.Printf("[TRACE] ExecTokenSource: running credential helper %s", s.Command)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("exec credential helper %s: %w: %s", s.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	cred := ExecCredential{Token: output}
+	if strings.HasPrefix(output, "{") {
+		cred = ExecCredential{}
+		if err := json.Unmarshal([]byte(output), &cred); err != nil {
+			return "", fmt.Errorf("exec credential helper %s: cannot parse output: %w", s.Command, err)
+		}
+	}
+	if cred.Token == "" {
+		return "", fmt.Errorf("exec credential helper %s: returned an empty token", s.Command)
+	}
+
+	s.token, s.expiration = cred.Token, cred.Expiration
+	return s.token, nil
+}
+
+// Invalidate forces the credential helper to be run again.
+func (s *ExecTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+}
+
+// Token exchange constants, from RFC 8693.
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// tokenExpiryLeeway is how long before its expiration a cached token is refreshed.
+const tokenExpiryLeeway = 30 * time.Second
+
+// TokenExchangeSource trades a CI OIDC JWT for a Duplo token, using an RFC 8693 token exchange endpoint.
+//
+// The subject token is read from SubjectTokenFile when set (and re-read on every exchange, since CI
+// systems rotate it), or else taken from SubjectToken.
+type TokenExchangeSource struct {
+	Endpoint         string
+	SubjectToken     string
+	SubjectTokenFile string
+	Audience         string
+	HTTPClient       *http.Client
+
+	mu         sync.Mutex
+	token      string
+	expiration time.Time
+}
+
+type tokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in,omitempty"`
+}
+
+// Token returns the cached Duplo token, exchanging the subject token for a new one if needed.
+func (s *TokenExchangeSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiration.IsZero() || time.Now().Add(tokenExpiryLeeway).Before(s.expiration)) {
+		return s.token, nil
+	}
+
+	subjectToken := s.SubjectToken
+	if s.SubjectTokenFile != "" {
+		bytes, err := os.ReadFile(s.SubjectTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("token_exchange: %w", err)
+		}
+		subjectToken = strings.TrimSpace(string(bytes))
+	}
+	if subjectToken == "" {
+		return "", fmt.Errorf("token_exchange: no subject token was provided")
+	}
+
+	form := url.Values{
+		"grant_type":         {tokenExchangeGrantType},
+		"subject_token":      {subjectToken},
+		"subject_token_type": {tokenTypeJWT},
+	}
+	if s.Audience != "" {
+		form.Set("audience", s.Audience)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("token_exchange: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	log.Printf("[TRACE] TokenExchangeSource: exchanging subject token at %s", s.Endpoint)
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token_exchange: %w", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("token_exchange: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token_exchange: %s returned status %d: %s", s.Endpoint, res.StatusCode, string(body))
+	}
+
+	rp := tokenExchangeResponse{}
+	if err := json.Unmarshal(body, &rp); err != nil {
+		return "", fmt.Errorf("token_exchange: cannot parse response: %w", err)
+	}
+	if rp.AccessToken == "" {
+		return "", fmt.Errorf("token_exchange: %s returned an empty access_token", s.Endpoint)
+	}
+
+	s.token, s.expiration = rp.AccessToken, time.Time{}
+	if rp.ExpiresIn > 0 {
+		s.expiration = time.Now().Add(time.Duration(rp.ExpiresIn) * time.Second)
+	}
+	return s.token, nil
+}
+
+// Invalidate forces a new token exchange.
+func (s *TokenExchangeSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+}
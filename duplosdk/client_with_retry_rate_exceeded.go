@@ -1,177 +1,33 @@
 package duplosdk
 
 import (
-	"fmt"
-	"log"
-	"math/rand"
-	"strings"
-	"time"
+	"net/http"
 )
 
-// retry Rate exceeded
-// retry - intial setting for dynamodb, need more insights to make it generic.
-const (
-	RateExceededMaxRetries = 9
-	RateExceededMsg        = "Rate exceeded"
-	MinStartingDelay       = 1
-	MaxStartingDelay       = 7
-	MinDelay               = 3
-	MaxDelay               = 15
-	MinJitterDelay         = 6
-)
-
-type RetryConf struct {
-	RateExceededMaxRetries int
-	MinStartingDelay       int
-	MaxStartingDelay       int
-	MinDelay               int
-	MaxDelay               int
-	MinJitterDelay         int
-}
-
-func NewRetryConf() RetryConf {
-	return RetryConf{
-		RateExceededMaxRetries: RateExceededMaxRetries,
-		MinStartingDelay:       MinStartingDelay,
-		MaxStartingDelay:       MaxStartingDelay,
-		MinDelay:               MinDelay,
-		MaxDelay:               MaxDelay,
-		MinJitterDelay:         MinJitterDelay,
-	}
-}
-func calculateBackoffInterval(apiCaller string, attempt, minStartingDelay, maxStartingDelay, minDelay, maxDelay, minJitterDelay int) time.Duration {
-	var miDelay, mxDelay int
-	switch {
-	case attempt == 1:
-		miDelay, mxDelay = minStartingDelay, maxStartingDelay
-	case attempt <= 3:
-		miDelay, mxDelay = minDelay, maxDelay
-	default:
-		miDelay, mxDelay = minJitterDelay, minJitterDelay+attempt*minStartingDelay
-	}
-	interval := rand.Intn(mxDelay-miDelay+1) + miDelay
-	if attempt > 1 {
-		log.Printf("[TRACE] calculateBackoffInterval minDelay: %d, maxDelay: %d, interval: %d %s", miDelay, mxDelay, interval, apiCaller)
-	}
-	return time.Duration(interval) * time.Second
-}
-
-func retryApiCall(apiCaller string, operationApiCall func() ClientError, conf *RetryConf) ClientError {
-	var err ClientError
-	var attempt int
-	var sleepDuration time.Duration
-	if conf == nil {
-		return newClientError("RetryConf is nil")
-	}
-	for attempt = 1; attempt <= conf.RateExceededMaxRetries; attempt++ {
-		delay := calculateBackoffInterval(apiCaller, attempt, conf.MinStartingDelay, conf.MaxStartingDelay, conf.MinDelay, conf.MaxDelay, conf.MinJitterDelay)
-		sleepDuration += delay
-		log.Printf("[TRACE] retryApiCall START sleep start (loop_sleep, retry_attempts, api) (%d,%d,%s)", int(delay.Seconds()), attempt, apiCaller)
-		if attempt > 1 {
-			time.Sleep(delay)
-			log.Printf("[WARN] retryApiCall sleep done (loop_sleep, retry_attempts, api) (%d,%d,%s)", int(delay.Seconds()), attempt, apiCaller)
-		}
-		err = operationApiCall()
-		if err == nil {
-			return nil
-		}
-		logString := ""
-		if !isRateExceededError(err) && !is400OrTimeoutsError(err) {
-			return err
-		}
-		if isRateExceededError(err) {
-			logString = "FAILED_WITH_RATE_EXCEEDED"
-		} else {
-			logString = "FAILED_WITH_400_OR_TIMEOUT"
-		}
-		log.Printf("[WARN] %s: retryApiCall (total_sleep, retry_attempts, api) (%d,%d,%s)", logString, int(sleepDuration.Seconds()), attempt, apiCaller)
-	}
-	return newClientError(fmt.Sprintf("API_RETRIES: Max retry attempts exceeded. (total_sleep, retry_attempts, api) (%d,%d,%s)", int(sleepDuration.Seconds()), attempt, apiCaller))
-}
+// RateExceededMsg is the message that Duplo uses to relay cloud provider throttling.
+const RateExceededMsg = "Rate exceeded"
 
-func isRateExceededError(err ClientError) bool {
-	if err != nil {
-		if value, exists := err.Response()["Message"]; exists {
-			if strError, ok := value.(string); ok {
-				if RateExceededMsg == strError {
-					log.Printf("[ERROR] FAILED_WITH_RATE_EXCEEDED isRateExceededError: detected? %s", strError)
-					return true
-				}
-			}
-		}
+// withBusyRetries returns a copy of the client whose requests are also retried while Duplo reports the cloud
+// resource as busy, with a 400 or an HRESULT timeout.  The retries follow the client's RetryPolicy.
+func (c *Client) withBusyRetries(statuses ...int) *Client {
+	if len(statuses) == 0 {
+		statuses = []int{http.StatusBadRequest}
 	}
-	return false
+	return c.WithContext(withRetryStatuses(c.Context(), statuses...))
 }
 
-func is400OrTimeoutsError(err ClientError) bool {
-	if err != nil {
-		errMsg := err.Error()
-
-		// Detect connection errors that should be retried
-		connectionErrors := []string{
-			"GOAWAY",
-			"connection reset",
-			"connection refused",
-			"broken pipe",
-			"EOF",
-			"context deadline exceeded",
-		}
-		for _, connErr := range connectionErrors {
-			if strings.Contains(errMsg, connErr) {
-				log.Printf("[ERROR] FAILED_WITH_CONNECTION_ERROR is400OrTimeoutsError: detected? %s", errMsg)
-				return true
-			}
-		}
-
-		if value, exists := err.Response()["Message"]; exists {
-			if strError, ok := value.(string); ok {
-				if strings.Contains(strError, "HRESULT") {
-					log.Printf("[ERROR] FAILED_WITH_TIMEOUT_PERIOD_EXPIRED is400OrTimeoutsError: detected? %s", strError)
-					return true
-				} else if err.Status() == 400 {
-					log.Printf("[ERROR] FAILED_WITH_400 is400OrTimeoutsError: detected? %s", err.Error())
-					return true
-				}
-			} else if err.Status() == 400 {
-				log.Printf("[ERROR] FAILED_WITH_400 is400OrTimeoutsError: detected? %s", err.Error())
-				return true
-			}
-		} else if err.Status() == 400 {
-			log.Printf("[ERROR] FAILED_WITH_400 is400OrTimeoutsError: detected? %s", err.Error())
-			return true
-		}
-	}
-	return false
+func (c *Client) getAPIWithRetry(apiName, apiPath string, rp interface{}) ClientError {
+	return c.withBusyRetries().doAPI("GET", apiName, apiPath, rp)
 }
 
-func (c *Client) getAPIWithRetry(apiName, apiPath string, rp interface{}, conf *RetryConf) ClientError {
-	apiCaller := fmt.Sprintf("GET (%s, %s)", apiName, apiPath)
-	operation := func() ClientError {
-		return c.doAPI("GET", apiName, apiPath, rp)
-	}
-	return retryApiCall(apiCaller, operation, conf)
+func (c *Client) deleteAPIWithRetry(apiName, apiPath string, rp interface{}) ClientError {
+	return c.withBusyRetries().doAPI("DELETE", apiName, apiPath, rp)
 }
 
-func (c *Client) deleteAPIWithRetry(apiName, apiPath string, rp interface{}, conf *RetryConf) ClientError {
-	apiCaller := fmt.Sprintf("DELETE (%s, %s)", apiName, apiPath)
-	operation := func() ClientError {
-		return c.doAPI("DELETE", apiName, apiPath, rp)
-	}
-	return retryApiCall(apiCaller, operation, conf)
+func (c *Client) postAPIWithRetry(apiName, apiPath string, rq, rp interface{}) ClientError {
+	return c.withBusyRetries().doAPIWithRequestBody("POST", apiName, apiPath, rq, rp)
 }
 
-func (c *Client) postAPIWithRetry(apiName, apiPath string, rq, rp interface{}, conf *RetryConf) ClientError {
-	apiCaller := fmt.Sprintf("POST (%s, %s)", apiName, apiPath)
-	operation := func() ClientError {
-		return c.doAPIWithRequestBody("POST", apiName, apiPath, rq, rp)
-	}
-	return retryApiCall(apiCaller, operation, conf)
-}
-
-func (c *Client) putAPIWithRetry(apiName, apiPath string, rq, rp interface{}, conf *RetryConf) ClientError {
-	apiCaller := fmt.Sprintf("PUT (%s, %s)", apiName, apiPath)
-	operation := func() ClientError {
-		return c.doAPIWithRequestBody("PUT", apiName, apiPath, rq, rp)
-	}
-	return retryApiCall(apiCaller, operation, conf)
+func (c *Client) putAPIWithRetry(apiName, apiPath string, rq, rp interface{}) ClientError {
+	return c.withBusyRetries().doAPIWithRequestBody("PUT", apiName, apiPath, rq, rp)
 }
@@ -3,6 +3,7 @@ package duplosdk
 import "fmt"
 
 func (c *Client) SystemSettingCreate(rq *DuploCustomDataEx) ClientError {
+	defer c.invalidateSystemFeaturesCache()
 	rp := DuploCustomDataEx{}
 	return c.postAPI(
 		fmt.Sprintf("SystemSettingCreate(%s)", rq.Key),
@@ -42,6 +43,7 @@ func (c *Client) SystemSettingList() (*[]DuploCustomDataEx, ClientError) {
 }
 
 func (c *Client) SystemSettingDelete(key string) ClientError {
+	defer c.invalidateSystemFeaturesCache()
 	return c.deleteAPI(
 		fmt.Sprintf("SystemSettingDelete(%s)", key),
 		fmt.Sprintf("v3/admin/systemSettings/config/%s", key),
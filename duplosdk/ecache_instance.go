@@ -221,11 +221,10 @@ func (c *Client) EcacheInstanceModify(tenantID string, rq *DuploEcacheModifyRequ
 	// The endpoint returns the full AWS ModifyReplicationGroup response.
 	// Use a map to absorb it — we don't need the response contents.
 	var rp map[string]interface{}
-	conf := NewRetryConf()
 	return c.postAPIWithRetry(
 		fmt.Sprintf("EcacheInstanceModify(%s, %s)", tenantID, rq.ReplicationGroupId),
 		fmt.Sprintf("v3/subscriptions/%s/aws/ecache/modify", tenantID),
-		rq, &rp, &conf)
+		rq, &rp)
 }
 
 type LogDeliveryConfigurationUpdateItem struct {
@@ -252,11 +251,10 @@ func (c *Client) EcacheInstanceGet(tenantID, name string) (*DuploEcacheInstance,
 
 	// Call the API.
 	rp := DuploEcacheInstance{}
-	conf := NewRetryConf()
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("EcacheInstanceGet(%s, duplo-%s)", tenantID, name),
 		fmt.Sprintf("v2/subscriptions/%s/ECacheDBInstance/duplo-%s", tenantID, name),
-		&rp, &conf)
+		&rp)
 	if err != nil || rp.Identifier == "" {
 		return nil, err
 	}
@@ -281,7 +281,7 @@ func (c *Client) InfrastructureGet(name string) (*DuploInfrastructureConfig, Cli
 // InfrastructureGetConfig retrieves extended infrastructure configuration by name via the Duplo API.
 func (c *Client) InfrastructureGetConfig(name string) (*DuploInfrastructureConfig, ClientError) {
 	rp := DuploInfrastructureConfig{}
-	err := c.getAPI(fmt.Sprintf("InfrastructureGetConfig(%s)", name), fmt.Sprintf("v3/admin/infrastructure/%s", name), &rp)
+	err := c.getAPICached(fmt.Sprintf("InfrastructureGetConfig(%s)", name), fmt.Sprintf("v3/admin/infrastructure/%s", name), &rp, func() bool { return rp.Name != "" })
 	if err != nil || rp.Name == "" {
 		return nil, err
 	}
@@ -337,6 +337,7 @@ func (c *Client) InfrastructureChangeSetting(infraName string, oldSetting, newSe
 
 // InfrastructureDeleteSettingKey deletes a specific configuration key for a tenant via the Duplo API.
 func (c *Client) InfrastructureDeleteSettingKey(infraName, key string) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	rq := DuploInfrastructureSettingUpdateRequest{State: "delete", Key: key}
 	return c.postAPI(
 		fmt.Sprintf("InfrastructureDeleteSettingKey(%s, %s)", infraName, key),
@@ -347,6 +348,7 @@ func (c *Client) InfrastructureDeleteSettingKey(infraName, key string) ClientErr
 
 // InfrastructureSetSettingKey set a specific configuration key for a tenant via the Duplo API.
 func (c *Client) InfrastructureSetSettingKey(infraName, key, value string) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	rq := DuploInfrastructureSettingUpdateRequest{Key: key, Value: value}
 	return c.postAPI(
 		fmt.Sprintf("InfrastructureSetSettingKey(%s, %s)", infraName, key),
@@ -391,6 +393,7 @@ func (c *Client) InfrastructureGetSubnet(infraName string, subnetName string, su
 
 // InfrastructureCreateOrUpdateSubnet creates or updates an infrastructure subnet via the Duplo API.
 func (c *Client) InfrastructureCreateOrUpdateSubnet(rq DuploInfrastructureVnetSubnet) ClientError {
+	defer c.invalidateInfrastructureCache(rq.InfrastructureName)
 	return c.postAPI(
 		fmt.Sprintf("InfrastructureCreateOrUpdateSubnet(%s, %s)", rq.InfrastructureName, rq.Name),
 		"adminproxy/UpdateInfrastructureSubnet",
@@ -400,6 +403,7 @@ func (c *Client) InfrastructureCreateOrUpdateSubnet(rq DuploInfrastructureVnetSu
 
 // InfrastructureDeleteSubnet deletes an infrastructure subnet via the Duplo API.
 func (c *Client) InfrastructureDeleteSubnet(infraName, subnetName, subnetCidr string) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	rq := DuploInfrastructureVnetSubnet{
 		State:              "delete",
 		InfrastructureName: infraName,
@@ -415,6 +419,7 @@ func (c *Client) InfrastructureDeleteSubnet(infraName, subnetName, subnetCidr st
 
 // InfrastructureCreate creates an infrastructure by name via the Duplo API.
 func (c *Client) InfrastructureCreate(rq DuploInfrastructureConfig) ClientError {
+	defer c.invalidateInfrastructureCache(rq.Name)
 	return c.postAPI(
 		fmt.Sprintf("InfrastructureCreate(%s)", rq.Name),
 		"adminproxy/CreateInfrastructureConfig",
@@ -424,6 +429,7 @@ func (c *Client) InfrastructureCreate(rq DuploInfrastructureConfig) ClientError
 
 // InfrastructureUpdateECSConfig creates or updates an infrastructure's ECS cluster via the Duplo API.
 func (c *Client) InfrastructureUpdateECSConfig(infraName string, rq DuploInfrastructureECSConfigUpdate) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	return c.postAPI(
 		fmt.Sprintf("InfrastructureUpdateECSConfig(%s)", infraName),
 		fmt.Sprintf("adminproxy/UpdateInfrastructureECS/%s", infraName),
@@ -433,6 +439,7 @@ func (c *Client) InfrastructureUpdateECSConfig(infraName string, rq DuploInfrast
 
 // InfrastructureDelete deletes an infrastructure by name via the Duplo API.
 func (c *Client) InfrastructureDelete(infraName string) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	return c.postAPI(
 		fmt.Sprintf("InfrastructureDelete(%s)", infraName),
 		fmt.Sprintf("adminproxy/DeleteInfrastructureConfig/%s", infraName),
@@ -452,6 +459,7 @@ func (c *Client) GetK8sCredentials(planID string) (*DuploEksCredentials, ClientE
 }
 
 func (c *Client) AzureLogAnalyticsWorkspaceCreate(infraName string, rq DuploAzureLogAnalyticsWorkspaceRq) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	return c.postAPI(
 		fmt.Sprintf("AzureLogAnalyticsWorkspaceCreate(%s,%s)", infraName, rq.Name),
 		fmt.Sprintf("adminproxy/SetInfrastructureLogAnalyticsConfig/%s", infraName),
@@ -472,6 +480,7 @@ func (c *Client) AzureLogAnalyticsWorkspaceGet(infraName string) (*DuploAzureLog
 }
 
 func (c *Client) AzureRecoveryServicesVaultCreate(infraName string, rq DuploAzureRecoveryServicesVaultRq) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	return c.postAPI(
 		fmt.Sprintf("AzureRecoveryServicesVaultCreate(%s,%s)", infraName, rq.Name),
 		fmt.Sprintf("adminproxy/SetInfrastructureRecoveryServicesVaultConfig/%s", infraName),
@@ -492,6 +501,7 @@ func (c *Client) AzureRecoveryServicesVaultGet(infraName string) (*DuploAzureRec
 }
 
 func (c *Client) NetworkSgRuleCreateOrDelete(rq *InfrastructureSgUpdate) ClientError {
+	defer c.invalidateInfrastructureCache(rq.Name)
 	return c.postAPI(
 		fmt.Sprintf("NetworkSgRuleCreate(%s)", rq.Name),
 		"adminproxy/UpdateInfrastructureSg",
@@ -524,6 +534,7 @@ func (c *Client) NetworkSgRuleGet(infraName, sgName, ruleName string) (*DuploInf
 }
 
 func (c *Client) AzureK8sClusterCreate(infraName string, rq *DuploAksConfig) ClientError {
+	defer c.invalidateInfrastructureCache(infraName)
 	return c.postAPI(
 		fmt.Sprintf("AzureK8sClusterCreate(%s,%s)", infraName, rq.Name),
 		fmt.Sprintf("adminproxy/UpdateInfrastructureAksConfig/%s", infraName),
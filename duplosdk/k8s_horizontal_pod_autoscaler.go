@@ -0,0 +1,83 @@
+package duplosdk
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DuploK8sHorizontalPodAutoscaler represents a kubernetes horizontal pod autoscaler in a Duplo tenant.
+type DuploK8sHorizontalPodAutoscaler struct {
+	// NOTE: The TenantId field does not come from the backend - we synthesize it
+	TenantId string                                      `json:"-"` //nolint:govet
+	Metadata metav1.ObjectMeta                           `json:"metadata"`
+	Spec     autoscalingv2.HorizontalPodAutoscalerSpec   `json:"spec"`
+	Status   autoscalingv2.HorizontalPodAutoscalerStatus `json:"status,omitempty"`
+}
+
+// K8sHorizontalPodAutoscalerGetList retrieves a list of k8s horizontal pod autoscalers via the Duplo API.
+func (c *Client) K8sHorizontalPodAutoscalerGetList(tenantId string) (*[]DuploK8sHorizontalPodAutoscaler, ClientError) {
+	var rp []DuploK8sHorizontalPodAutoscaler
+	err := c.getAPI(
+		fmt.Sprintf("k8sHorizontalPodAutoscalerGetList(%s)", tenantId),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/horizontalPodAutoscaler", tenantId),
+		&rp)
+
+	if err == nil {
+		for i := range rp {
+			rp[i].TenantId = tenantId
+		}
+	}
+
+	return &rp, err
+}
+
+// K8sHorizontalPodAutoscalerGet retrieves a k8s horizontal pod autoscaler via the Duplo API.
+func (c *Client) K8sHorizontalPodAutoscalerGet(tenantId, name string) (*DuploK8sHorizontalPodAutoscaler, ClientError) {
+	var rp DuploK8sHorizontalPodAutoscaler
+	err := c.getAPI(
+		fmt.Sprintf("k8sHorizontalPodAutoscalerGet(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/horizontalPodAutoscaler/%s", tenantId, name),
+		&rp)
+
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rp.TenantId = tenantId
+
+	return &rp, nil
+}
+
+// K8sHorizontalPodAutoscalerCreate creates a k8s horizontal pod autoscaler via the Duplo API.
+func (c *Client) K8sHorizontalPodAutoscalerCreate(rq *DuploK8sHorizontalPodAutoscaler) ClientError {
+	rp := DuploK8sHorizontalPodAutoscaler{}
+	return c.postAPI(
+		fmt.Sprintf("k8sHorizontalPodAutoscalerCreate(%s, %s)", rq.TenantId, rq.Metadata.Name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/horizontalPodAutoscaler", rq.TenantId),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sHorizontalPodAutoscalerUpdate updates a k8s horizontal pod autoscaler via the Duplo API.
+func (c *Client) K8sHorizontalPodAutoscalerUpdate(tenantId, name string, rq *DuploK8sHorizontalPodAutoscaler) ClientError {
+	rp := DuploK8sHorizontalPodAutoscaler{}
+	return c.putAPI(
+		fmt.Sprintf("k8sHorizontalPodAutoscalerUpdate(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/horizontalPodAutoscaler/%s", tenantId, name),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sHorizontalPodAutoscalerDelete deletes a k8s horizontal pod autoscaler via the Duplo API.
+func (c *Client) K8sHorizontalPodAutoscalerDelete(tenantId, name string) ClientError {
+	return c.deleteAPI(
+		fmt.Sprintf("K8sHorizontalPodAutoscalerDelete(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/horizontalPodAutoscaler/%s", tenantId, name),
+		nil)
+}
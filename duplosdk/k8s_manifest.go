@@ -0,0 +1,292 @@
+package duplosdk
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DuploK8sManifest represents an arbitrary kubernetes object in a Duplo tenant, such as an instance of a CRD.
+type DuploK8sManifest map[string]interface{}
+
+// ApiVersion returns the apiVersion of the object.
+func (m DuploK8sManifest) ApiVersion() string {
+	v, _ := m["apiVersion"].(string)
+	return v
+}
+
+// Kind returns the kind of the object.
+func (m DuploK8sManifest) Kind() string {
+	v, _ := m["kind"].(string)
+	return v
+}
+
+// Name returns the metadata.name of the object.
+func (m DuploK8sManifest) Name() string {
+	if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+		v, _ := metadata["name"].(string)
+		return v
+	}
+	return ""
+}
+
+// duploK8sAPIResourceList is the part of a Kubernetes discovery document that is needed to find the resource of a kind.
+type duploK8sAPIResourceList struct {
+	Resources []struct {
+		Name       string `json:"name"`
+		Kind       string `json:"kind"`
+		Namespaced bool   `json:"namespaced"`
+	} `json:"resources"`
+}
+
+// k8sClientCacheTTL is how long the client of a tenant's Kubernetes API server is kept, which must be
+// shorter than the lifetime of the tenant's just-in-time Kubernetes token.
+const k8sClientCacheTTL = 5 * time.Minute
+
+// k8sClientCache keeps, for the duration of a run, the clients of the tenants' Kubernetes API servers
+// and the resources they discovered.  A single cache is shared by every copy of a Client.
+type k8sClientCache struct {
+	mu      sync.Mutex
+	tenants map[string]*k8sTenantClient
+}
+
+// k8sTenantClient is a client for the Kubernetes API server of a tenant.
+type k8sTenantClient struct {
+	client    *Client // not bound to any context
+	namespace string
+	expires   time.Time
+
+	mu        sync.Mutex
+	resources map[string]*duploK8sAPIResourceList // by apiVersion
+}
+
+func newK8sClientCache() *k8sClientCache {
+	return &k8sClientCache{tenants: map[string]*k8sTenantClient{}}
+}
+
+func (kc *k8sClientCache) get(tenantId string) *k8sTenantClient {
+	if kc == nil {
+		return nil
+	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	t, ok := kc.tenants[tenantId]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(t.expires) {
+		delete(kc.tenants, tenantId)
+		return nil
+	}
+	return t
+}
+
+func (kc *k8sClientCache) put(tenantId string, t *k8sTenantClient) {
+	if kc == nil {
+		return
+	}
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	kc.tenants[tenantId] = t
+}
+
+// k8sManifestTenant returns the client for the Kubernetes API server of a tenant, authenticated with the
+// tenant's just-in-time credentials.
+//
+// Duplo has no API for arbitrary kubernetes objects, so they are managed through the Kubernetes API directly.
+// The cluster is called with its own transport, so that the Duplo client certificate and proxy settings are
+// never sent to it.
+func (c *Client) k8sManifestTenant(tenantId string) (*k8sTenantClient, ClientError) {
+	if t := c.k8sClients.get(tenantId); t != nil {
+		return t, nil
+	}
+
+	creds, err := c.GetTenantK8sJitAccess(tenantId)
+	if err != nil {
+		return nil, err
+	}
+	if creds.APIServer == "" || creds.DefaultNamespace == "" {
+		return nil, newClientError(fmt.Sprintf("the Kubernetes credentials of tenant %s have no API server or namespace", tenantId))
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if creds.CertificateAuthorityDataBase64 != "" {
+		pem, derr := base64.StdEncoding.DecodeString(creds.CertificateAuthorityDataBase64)
+		pool := x509.NewCertPool()
+		if derr != nil || !pool.AppendCertsFromPEM(pem) {
+			return nil, newClientError(fmt.Sprintf("the Kubernetes credentials of tenant %s have an invalid certificate authority", tenantId))
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	t := &k8sTenantClient{
+		client: &Client{
+			HTTPClient:  &http.Client{Timeout: c.HTTPClient.Timeout, Transport: transport},
+			HostURL:     strings.TrimSuffix(creds.APIServer, "/"),
+			Token:       "Bearer " + creds.Token,
+			RetryPolicy: c.RetryPolicy,
+		},
+		namespace: creds.DefaultNamespace,
+		expires:   time.Now().Add(k8sClientCacheTTL),
+		resources: map[string]*duploK8sAPIResourceList{},
+	}
+	c.k8sClients.put(tenantId, t)
+	return t, nil
+}
+
+// objectUrl returns the path of an object in the Kubernetes API, after looking up the resource of its kind.
+//
+// The resources of an API version are discovered once, and again only when a kind is missing, such as
+// the kind of a CRD that was installed since.
+func (t *k8sTenantClient) objectUrl(k *Client, apiVersion, kind, name string) (string, ClientError) {
+	base := "api/" + apiVersion
+	if strings.Contains(apiVersion, "/") {
+		base = "apis/" + apiVersion
+	}
+
+	find := func(list *duploK8sAPIResourceList) (string, bool) {
+		for _, r := range list.Resources {
+			// Subresources, such as deployments/scale, share the kind of their parent.
+			if r.Kind != kind || strings.Contains(r.Name, "/") {
+				continue
+			}
+			if r.Namespaced {
+				return fmt.Sprintf("%s/namespaces/%s/%s/%s", base, url.PathEscape(t.namespace), r.Name, url.PathEscape(name)), true
+			}
+			return fmt.Sprintf("%s/%s/%s", base, r.Name, url.PathEscape(name)), true
+		}
+		return "", false
+	}
+
+	t.mu.Lock()
+	list := t.resources[apiVersion]
+	t.mu.Unlock()
+	if list != nil {
+		if path, ok := find(list); ok {
+			return path, nil
+		}
+	}
+
+	list = &duploK8sAPIResourceList{}
+	err := k.k8sAPI("GET", fmt.Sprintf("K8sDiscovery(%s)", apiVersion), base, "", nil, list)
+	if err != nil {
+		return "", err
+	}
+	t.mu.Lock()
+	t.resources[apiVersion] = list
+	t.mu.Unlock()
+	if path, ok := find(list); ok {
+		return path, nil
+	}
+	return "", newClientError(fmt.Sprintf("the Kubernetes API server has no resource of kind %s in %s", kind, apiVersion))
+}
+
+// k8sManifestObject returns a client for the Kubernetes API server of a tenant, bound to the context of c,
+// and the path of an object in it.
+func (c *Client) k8sManifestObject(tenantId, apiVersion, kind, name string) (*Client, string, ClientError) {
+	t, err := c.k8sManifestTenant(tenantId)
+	if err != nil {
+		return nil, "", err
+	}
+	k := t.client.WithContext(c.Context())
+	path, err := t.objectUrl(k, apiVersion, kind, name)
+	if err != nil {
+		return nil, "", err
+	}
+	return k, path, nil
+}
+
+// k8sAPI calls the Kubernetes API server.  Unlike Duplo APIs, the response body of a write is optional.
+func (c *Client) k8sAPI(verb, apiName, apiPath, contentType string, rq []byte, rp interface{}) ClientError {
+	apiName = fmt.Sprintf("%sAPI %s", strings.ToLower(verb), apiName)
+
+	// Build the request
+	url := fmt.Sprintf("%s/%s", c.HostURL, apiPath)
+	req, err := http.NewRequestWithContext(withAPIName(c.Context(), apiName), verb, url, bytes.NewReader(rq))
+	if err != nil {
+		log.Printf("[TRACE] %s: cannot build request: %s", apiName, err.Error())
+		return requestHttpError(url, err.Error())
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	// Call the API and get the response
+	rpBody, httpErr := c.doRequest(req)
+	if httpErr != nil {
+		log.Printf("[TRACE] %s: failed: %s", apiName, httpErr.Error())
+		return httpErr
+	}
+	if rp == nil || len(rpBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(rpBody, rp); err != nil {
+		message := fmt.Sprintf("%s: cannot unmarshal response from JSON: %s", apiName, err.Error())
+		log.Printf("[TRACE] %s", message)
+		return appHttpError(req, message)
+	}
+	return nil
+}
+
+// K8sManifestGet retrieves a kubernetes object from the tenant's namespace.
+func (c *Client) K8sManifestGet(tenantId, apiVersion, kind, name string) (DuploK8sManifest, ClientError) {
+	k, path, err := c.k8sManifestObject(tenantId, apiVersion, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	rp := DuploK8sManifest{}
+	err = k.k8sAPI("GET", fmt.Sprintf("K8sManifestGet(%s, %s, %s, %s)", tenantId, apiVersion, kind, name), path, "", nil, &rp)
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rp, nil
+}
+
+// K8sManifestApply creates or updates a kubernetes object in the tenant's namespace, using server-side apply.
+// The fields of the object become owned by the given field manager.
+// When force is true, the ownership of fields that conflict with other field managers is taken over.
+func (c *Client) K8sManifestApply(tenantId string, rq DuploK8sManifest, fieldManager string, force bool) (DuploK8sManifest, ClientError) {
+	k, path, err := c.k8sManifestObject(tenantId, rq.ApiVersion(), rq.Kind(), rq.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so the object can be sent as an apply patch as-is.
+	apiName := fmt.Sprintf("K8sManifestApply(%s, %s, %s, %s)", tenantId, rq.ApiVersion(), rq.Kind(), rq.Name())
+	body, jerr := json.Marshal(rq)
+	if jerr != nil {
+		return nil, newClientError(fmt.Sprintf("%s: cannot marshal request to JSON: %s", apiName, jerr))
+	}
+	rp := DuploK8sManifest{}
+	err = k.k8sAPI("PATCH", apiName,
+		fmt.Sprintf("%s?fieldManager=%s&force=%t", path, url.QueryEscape(fieldManager), force),
+		"application/apply-patch+yaml", body, &rp)
+	return rp, err
+}
+
+// K8sManifestDelete deletes a kubernetes object from the tenant's namespace.
+func (c *Client) K8sManifestDelete(tenantId, apiVersion, kind, name string) ClientError {
+	k, path, err := c.k8sManifestObject(tenantId, apiVersion, kind, name)
+	if err != nil {
+		return err
+	}
+
+	return k.k8sAPI("DELETE", fmt.Sprintf("K8sManifestDelete(%s, %s, %s, %s)", tenantId, apiVersion, kind, name), path, "", nil, nil)
+}
@@ -0,0 +1,82 @@
+package duplosdk
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DuploK8sNetworkPolicy represents a kubernetes network policy in a Duplo tenant.
+type DuploK8sNetworkPolicy struct {
+	// NOTE: The TenantId field does not come from the backend - we synthesize it
+	TenantId string                         `json:"-"` //nolint:govet
+	Metadata metav1.ObjectMeta              `json:"metadata"`
+	Spec     networkingv1.NetworkPolicySpec `json:"spec"`
+}
+
+// K8sNetworkPolicyGetList retrieves a list of k8s network policies via the Duplo API.
+func (c *Client) K8sNetworkPolicyGetList(tenantId string) (*[]DuploK8sNetworkPolicy, ClientError) {
+	var rp []DuploK8sNetworkPolicy
+	err := c.getAPI(
+		fmt.Sprintf("k8sNetworkPolicyGetList(%s)", tenantId),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/networkPolicy", tenantId),
+		&rp)
+
+	if err == nil {
+		for i := range rp {
+			rp[i].TenantId = tenantId
+		}
+	}
+
+	return &rp, err
+}
+
+// K8sNetworkPolicyGet retrieves a k8s network policy via the Duplo API.
+func (c *Client) K8sNetworkPolicyGet(tenantId, name string) (*DuploK8sNetworkPolicy, ClientError) {
+	var rp DuploK8sNetworkPolicy
+	err := c.getAPI(
+		fmt.Sprintf("k8sNetworkPolicyGet(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/networkPolicy/%s", tenantId, name),
+		&rp)
+
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rp.TenantId = tenantId
+
+	return &rp, nil
+}
+
+// K8sNetworkPolicyCreate creates a k8s network policy via the Duplo API.
+func (c *Client) K8sNetworkPolicyCreate(rq *DuploK8sNetworkPolicy) ClientError {
+	rp := DuploK8sNetworkPolicy{}
+	return c.postAPI(
+		fmt.Sprintf("k8sNetworkPolicyCreate(%s, %s)", rq.TenantId, rq.Metadata.Name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/networkPolicy", rq.TenantId),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sNetworkPolicyUpdate updates a k8s network policy via the Duplo API.
+func (c *Client) K8sNetworkPolicyUpdate(tenantId, name string, rq *DuploK8sNetworkPolicy) ClientError {
+	rp := DuploK8sNetworkPolicy{}
+	return c.putAPI(
+		fmt.Sprintf("k8sNetworkPolicyUpdate(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/networkPolicy/%s", tenantId, name),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sNetworkPolicyDelete deletes a k8s network policy via the Duplo API.
+func (c *Client) K8sNetworkPolicyDelete(tenantId, name string) ClientError {
+	return c.deleteAPI(
+		fmt.Sprintf("K8sNetworkPolicyDelete(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/networkPolicy/%s", tenantId, name),
+		nil)
+}
@@ -0,0 +1,83 @@
+package duplosdk
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DuploK8sPodDisruptionBudget represents a kubernetes pod disruption budget in a Duplo tenant.
+type DuploK8sPodDisruptionBudget struct {
+	// NOTE: The TenantId field does not come from the backend - we synthesize it
+	TenantId string                             `json:"-"` //nolint:govet
+	Metadata metav1.ObjectMeta                  `json:"metadata"`
+	Spec     policyv1.PodDisruptionBudgetSpec   `json:"spec"`
+	Status   policyv1.PodDisruptionBudgetStatus `json:"status,omitempty"`
+}
+
+// K8sPodDisruptionBudgetGetList retrieves a list of k8s pod disruption budgets via the Duplo API.
+func (c *Client) K8sPodDisruptionBudgetGetList(tenantId string) (*[]DuploK8sPodDisruptionBudget, ClientError) {
+	var rp []DuploK8sPodDisruptionBudget
+	err := c.getAPI(
+		fmt.Sprintf("k8sPodDisruptionBudgetGetList(%s)", tenantId),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/podDisruptionBudget", tenantId),
+		&rp)
+
+	if err == nil {
+		for i := range rp {
+			rp[i].TenantId = tenantId
+		}
+	}
+
+	return &rp, err
+}
+
+// K8sPodDisruptionBudgetGet retrieves a k8s pod disruption budget via the Duplo API.
+func (c *Client) K8sPodDisruptionBudgetGet(tenantId, name string) (*DuploK8sPodDisruptionBudget, ClientError) {
+	var rp DuploK8sPodDisruptionBudget
+	err := c.getAPI(
+		fmt.Sprintf("k8sPodDisruptionBudgetGet(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/podDisruptionBudget/%s", tenantId, name),
+		&rp)
+
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rp.TenantId = tenantId
+
+	return &rp, nil
+}
+
+// K8sPodDisruptionBudgetCreate creates a k8s pod disruption budget via the Duplo API.
+func (c *Client) K8sPodDisruptionBudgetCreate(rq *DuploK8sPodDisruptionBudget) ClientError {
+	rp := DuploK8sPodDisruptionBudget{}
+	return c.postAPI(
+		fmt.Sprintf("k8sPodDisruptionBudgetCreate(%s, %s)", rq.TenantId, rq.Metadata.Name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/podDisruptionBudget", rq.TenantId),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sPodDisruptionBudgetUpdate updates a k8s pod disruption budget via the Duplo API.
+func (c *Client) K8sPodDisruptionBudgetUpdate(tenantId, name string, rq *DuploK8sPodDisruptionBudget) ClientError {
+	rp := DuploK8sPodDisruptionBudget{}
+	return c.putAPI(
+		fmt.Sprintf("k8sPodDisruptionBudgetUpdate(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/podDisruptionBudget/%s", tenantId, name),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sPodDisruptionBudgetDelete deletes a k8s pod disruption budget via the Duplo API.
+func (c *Client) K8sPodDisruptionBudgetDelete(tenantId, name string) ClientError {
+	return c.deleteAPI(
+		fmt.Sprintf("K8sPodDisruptionBudgetDelete(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/podDisruptionBudget/%s", tenantId, name),
+		nil)
+}
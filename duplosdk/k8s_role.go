@@ -0,0 +1,82 @@
+package duplosdk
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DuploK8sRole represents a kubernetes role in a Duplo tenant.
+type DuploK8sRole struct {
+	// NOTE: The TenantId field does not come from the backend - we synthesize it
+	TenantId string              `json:"-"` //nolint:govet
+	Metadata metav1.ObjectMeta   `json:"metadata"`
+	Rules    []rbacv1.PolicyRule `json:"rules"`
+}
+
+// K8sRoleGetList retrieves a list of k8s roles via the Duplo API.
+func (c *Client) K8sRoleGetList(tenantId string) (*[]DuploK8sRole, ClientError) {
+	var rp []DuploK8sRole
+	err := c.getAPI(
+		fmt.Sprintf("k8sRoleGetList(%s)", tenantId),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/role", tenantId),
+		&rp)
+
+	if err == nil {
+		for i := range rp {
+			rp[i].TenantId = tenantId
+		}
+	}
+
+	return &rp, err
+}
+
+// K8sRoleGet retrieves a k8s role via the Duplo API.
+func (c *Client) K8sRoleGet(tenantId, name string) (*DuploK8sRole, ClientError) {
+	var rp DuploK8sRole
+	err := c.getAPI(
+		fmt.Sprintf("k8sRoleGet(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/role/%s", tenantId, name),
+		&rp)
+
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rp.TenantId = tenantId
+
+	return &rp, nil
+}
+
+// K8sRoleCreate creates a k8s role via the Duplo API.
+func (c *Client) K8sRoleCreate(rq *DuploK8sRole) ClientError {
+	rp := DuploK8sRole{}
+	return c.postAPI(
+		fmt.Sprintf("k8sRoleCreate(%s, %s)", rq.TenantId, rq.Metadata.Name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/role", rq.TenantId),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sRoleUpdate updates a k8s role via the Duplo API.
+func (c *Client) K8sRoleUpdate(tenantId, name string, rq *DuploK8sRole) ClientError {
+	rp := DuploK8sRole{}
+	return c.putAPI(
+		fmt.Sprintf("k8sRoleUpdate(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/role/%s", tenantId, name),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sRoleDelete deletes a k8s role via the Duplo API.
+func (c *Client) K8sRoleDelete(tenantId, name string) ClientError {
+	return c.deleteAPI(
+		fmt.Sprintf("K8sRoleDelete(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/role/%s", tenantId, name),
+		nil)
+}
@@ -0,0 +1,83 @@
+package duplosdk
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DuploK8sRoleBinding represents a kubernetes role binding in a Duplo tenant.
+type DuploK8sRoleBinding struct {
+	// NOTE: The TenantId field does not come from the backend - we synthesize it
+	TenantId string            `json:"-"` //nolint:govet
+	Metadata metav1.ObjectMeta `json:"metadata"`
+	Subjects []rbacv1.Subject  `json:"subjects,omitempty"`
+	RoleRef  rbacv1.RoleRef    `json:"roleRef"`
+}
+
+// K8sRoleBindingGetList retrieves a list of k8s role bindings via the Duplo API.
+func (c *Client) K8sRoleBindingGetList(tenantId string) (*[]DuploK8sRoleBinding, ClientError) {
+	var rp []DuploK8sRoleBinding
+	err := c.getAPI(
+		fmt.Sprintf("k8sRoleBindingGetList(%s)", tenantId),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/roleBinding", tenantId),
+		&rp)
+
+	if err == nil {
+		for i := range rp {
+			rp[i].TenantId = tenantId
+		}
+	}
+
+	return &rp, err
+}
+
+// K8sRoleBindingGet retrieves a k8s role binding via the Duplo API.
+func (c *Client) K8sRoleBindingGet(tenantId, name string) (*DuploK8sRoleBinding, ClientError) {
+	var rp DuploK8sRoleBinding
+	err := c.getAPI(
+		fmt.Sprintf("k8sRoleBindingGet(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/roleBinding/%s", tenantId, name),
+		&rp)
+
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rp.TenantId = tenantId
+
+	return &rp, nil
+}
+
+// K8sRoleBindingCreate creates a k8s role binding via the Duplo API.
+func (c *Client) K8sRoleBindingCreate(rq *DuploK8sRoleBinding) ClientError {
+	rp := DuploK8sRoleBinding{}
+	return c.postAPI(
+		fmt.Sprintf("k8sRoleBindingCreate(%s, %s)", rq.TenantId, rq.Metadata.Name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/roleBinding", rq.TenantId),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sRoleBindingUpdate updates a k8s role binding via the Duplo API.
+func (c *Client) K8sRoleBindingUpdate(tenantId, name string, rq *DuploK8sRoleBinding) ClientError {
+	rp := DuploK8sRoleBinding{}
+	return c.putAPI(
+		fmt.Sprintf("k8sRoleBindingUpdate(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/roleBinding/%s", tenantId, name),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sRoleBindingDelete deletes a k8s role binding via the Duplo API.
+func (c *Client) K8sRoleBindingDelete(tenantId, name string) ClientError {
+	return c.deleteAPI(
+		fmt.Sprintf("K8sRoleBindingDelete(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/roleBinding/%s", tenantId, name),
+		nil)
+}
@@ -0,0 +1,83 @@
+package duplosdk
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DuploK8sService represents a kubernetes service in a Duplo tenant.
+type DuploK8sService struct {
+	// NOTE: The TenantId field does not come from the backend - we synthesize it
+	TenantId string               `json:"-"` //nolint:govet
+	Metadata metav1.ObjectMeta    `json:"metadata"`
+	Spec     corev1.ServiceSpec   `json:"spec"`
+	Status   corev1.ServiceStatus `json:"status,omitempty"`
+}
+
+// K8sServiceGetList retrieves a list of k8s services via the Duplo API.
+func (c *Client) K8sServiceGetList(tenantId string) (*[]DuploK8sService, ClientError) {
+	var rp []DuploK8sService
+	err := c.getAPI(
+		fmt.Sprintf("k8sServiceGetList(%s)", tenantId),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/service", tenantId),
+		&rp)
+
+	if err == nil {
+		for i := range rp {
+			rp[i].TenantId = tenantId
+		}
+	}
+
+	return &rp, err
+}
+
+// K8sServiceGet retrieves a k8s service via the Duplo API.
+func (c *Client) K8sServiceGet(tenantId, name string) (*DuploK8sService, ClientError) {
+	var rp DuploK8sService
+	err := c.getAPI(
+		fmt.Sprintf("k8sServiceGet(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/service/%s", tenantId, name),
+		&rp)
+
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rp.TenantId = tenantId
+
+	return &rp, nil
+}
+
+// K8sServiceCreate creates a k8s service via the Duplo API.
+func (c *Client) K8sServiceCreate(rq *DuploK8sService) ClientError {
+	rp := DuploK8sService{}
+	return c.postAPI(
+		fmt.Sprintf("k8sServiceCreate(%s, %s)", rq.TenantId, rq.Metadata.Name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/service", rq.TenantId),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sServiceUpdate updates a k8s service via the Duplo API.
+func (c *Client) K8sServiceUpdate(tenantId, name string, rq *DuploK8sService) ClientError {
+	rp := DuploK8sService{}
+	return c.putAPI(
+		fmt.Sprintf("k8sServiceUpdate(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/service/%s", tenantId, name),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sServiceDelete deletes a k8s service via the Duplo API.
+func (c *Client) K8sServiceDelete(tenantId, name string) ClientError {
+	return c.deleteAPI(
+		fmt.Sprintf("K8sServiceDelete(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/service/%s", tenantId, name),
+		nil)
+}
@@ -0,0 +1,84 @@
+package duplosdk
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DuploK8sServiceAccount represents a kubernetes service account in a Duplo tenant.
+type DuploK8sServiceAccount struct {
+	// NOTE: The TenantId field does not come from the backend - we synthesize it
+	TenantId                     string                        `json:"-"` //nolint:govet
+	Metadata                     metav1.ObjectMeta             `json:"metadata"`
+	Secrets                      []corev1.ObjectReference      `json:"secrets,omitempty"`
+	ImagePullSecrets             []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	AutomountServiceAccountToken *bool                         `json:"automountServiceAccountToken,omitempty"`
+}
+
+// K8sServiceAccountGetList retrieves a list of k8s service accounts via the Duplo API.
+func (c *Client) K8sServiceAccountGetList(tenantId string) (*[]DuploK8sServiceAccount, ClientError) {
+	var rp []DuploK8sServiceAccount
+	err := c.getAPI(
+		fmt.Sprintf("k8sServiceAccountGetList(%s)", tenantId),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/serviceAccount", tenantId),
+		&rp)
+
+	if err == nil {
+		for i := range rp {
+			rp[i].TenantId = tenantId
+		}
+	}
+
+	return &rp, err
+}
+
+// K8sServiceAccountGet retrieves a k8s service account via the Duplo API.
+func (c *Client) K8sServiceAccountGet(tenantId, name string) (*DuploK8sServiceAccount, ClientError) {
+	var rp DuploK8sServiceAccount
+	err := c.getAPI(
+		fmt.Sprintf("k8sServiceAccountGet(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/serviceAccount/%s", tenantId, name),
+		&rp)
+
+	if err != nil {
+		if err.Status() == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rp.TenantId = tenantId
+
+	return &rp, nil
+}
+
+// K8sServiceAccountCreate creates a k8s service account via the Duplo API.
+func (c *Client) K8sServiceAccountCreate(rq *DuploK8sServiceAccount) ClientError {
+	rp := DuploK8sServiceAccount{}
+	return c.postAPI(
+		fmt.Sprintf("k8sServiceAccountCreate(%s, %s)", rq.TenantId, rq.Metadata.Name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/serviceAccount", rq.TenantId),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sServiceAccountUpdate updates a k8s service account via the Duplo API.
+func (c *Client) K8sServiceAccountUpdate(tenantId, name string, rq *DuploK8sServiceAccount) ClientError {
+	rp := DuploK8sServiceAccount{}
+	return c.putAPI(
+		fmt.Sprintf("k8sServiceAccountUpdate(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/serviceAccount/%s", tenantId, name),
+		&rq,
+		&rp,
+	)
+}
+
+// K8sServiceAccountDelete deletes a k8s service account via the Duplo API.
+func (c *Client) K8sServiceAccountDelete(tenantId, name string) ClientError {
+	return c.deleteAPI(
+		fmt.Sprintf("K8sServiceAccountDelete(%s, %s)", tenantId, name),
+		fmt.Sprintf("v3/subscriptions/%s/k8s/serviceAccount/%s", tenantId, name),
+		nil)
+}
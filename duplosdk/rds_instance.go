@@ -243,6 +243,24 @@ func (c *Client) RdsInstanceDelete(id string) (*DuploRdsInstance, ClientError) {
 	return &DuploRdsInstance{TenantID: tenantID, Name: name}, nil
 }
 
+// RdsInstanceList retrieves the RDS instances in a tenant via the Duplo API.
+func (c *Client) RdsInstanceList(tenantID string) (*[]DuploRdsInstance, ClientError) {
+	rp := []DuploRdsInstance{}
+	err := c.getAPI(
+		fmt.Sprintf("RdsInstanceList(%s)", tenantID),
+		fmt.Sprintf("v3/subscriptions/%s/aws/rds/instance", tenantID),
+		&rp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill in the tenant ID and return the list.
+	for i := range rp {
+		rp[i].TenantID = tenantID
+	}
+	return &rp, nil
+}
+
 // RdsInstanceGet retrieves an RDS instance via the Duplo API.
 func (c *Client) RdsInstanceGet(id string) (*DuploRdsInstance, ClientError) {
 	idParts := strings.SplitN(id, "/", 5)
@@ -251,11 +269,10 @@ func (c *Client) RdsInstanceGet(id string) (*DuploRdsInstance, ClientError) {
 	identifier := EnsureDuploPrefixInRdsIdentifier(name)
 	// Call the API.
 	duploObject := DuploRdsInstance{}
-	conf := NewRetryConf()
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("RdsInstanceGet(%s, %s)", tenantID, identifier),
 		fmt.Sprintf("v3/subscriptions/%s/aws/rds/instance/%s", tenantID, identifier),
-		&duploObject, &conf)
+		&duploObject)
 	if err != nil || duploObject.Identifier == "" {
 		return nil, err
 	}
@@ -269,11 +286,10 @@ func (c *Client) RdsInstanceGetByName(tenantID, name string) (*DuploRdsInstance,
 	identifier := EnsureDuploPrefixInRdsIdentifier(name)
 	// Call the API.
 	duploObject := DuploRdsInstance{}
-	conf := NewRetryConf()
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("RdsInstanceGet(%s, %s)", tenantID, identifier),
 		fmt.Sprintf("v3/subscriptions/%s/aws/rds/instance/%s", tenantID, identifier),
-		&duploObject, &conf)
+		&duploObject)
 	if err != nil || duploObject.Identifier == "" {
 		return nil, err
 	}
@@ -450,22 +466,20 @@ func (c *Client) RdsTagUpdateV3(tenantID string, tag DuploRDSTag) ClientError {
 func (c *Client) RdsTagListV3(tenantID, resourceType, resourceId string) (*[]DuploKeyStringValue, ClientError) {
 	tags := []DuploKeyStringValue{}
 
-	conf := NewRetryConf()
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("RdsTagListV3(%s, %s)", tenantID, resourceId),
 		fmt.Sprintf("v3/subscriptions/%s/aws/rds/%s/%s/tag", tenantID, resourceType, resourceId),
-		&tags, &conf,
+		&tags,
 	)
 	return &tags, err
 }
 
 func (c *Client) RdsTagGetV3(tenantID string, tag DuploRDSTag) (*DuploKeyStringValue, ClientError) {
 	tags := DuploKeyStringValue{}
-	conf := NewRetryConf()
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("RdsTagGetV3(%s, %s)", tenantID, tag.ResourceId),
 		fmt.Sprintf("v3/subscriptions/%s/aws/rds/%s/%s/tag/%s", tenantID, tag.ResourceType, tag.ResourceId, urlSafeBase64Encode(tag.Key)),
-		&tags, &conf,
+		&tags,
 	)
 	return &tags, err
 }
@@ -548,11 +562,10 @@ func (c *Client) DescribeRdsCluster(id string) (*DuploRDSClusterCompareField, Cl
 	identifier := EnsureDuploPrefixInRdsIdentifier(name)
 	// Call the API.
 	duploObject := DuploRDSClusterCompareField{}
-	conf := NewRetryConf()
 	err := c.getAPIWithRetry(
 		fmt.Sprintf("RdsInstanceGet(%s, %s)", tenantID, identifier),
 		fmt.Sprintf("v3/subscriptions/%s/aws/rds/cluster/%s", tenantID, identifier+"-cluster"),
-		&duploObject, &conf)
+		&duploObject)
 	return &duploObject, err
 }
 
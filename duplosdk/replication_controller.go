@@ -2,8 +2,7 @@ package duplosdk
 
 import (
 	"fmt"
-	"strings"
-	"time"
+	"net/http"
 )
 
 // DuploReplicationController represents a service in the Duplo SDK
@@ -149,7 +148,7 @@ type DuploReplicationControllerCreateRequest struct {
 	NetworkId                         string                 `json:"NetworkId"`
 	Cloud                             int                    `json:"Cloud"`
 	AgentPlatform                     int                    `json:"AgentPlatform"`
-	Replicas                          int                    `json:"Replicas,omitempty"`
+	Replicas                          int                    `json:"Replicas"`
 	ReplicasMatchingAsgName           string                 `json:"ReplicasMatchingAsgName,omitempty"`
 	ForceStatefulSet                  bool                   `json:"ForceStatefulSet,omitempty"`
 	IsDaemonset                       bool                   `json:"IsDaemonset"`
@@ -178,7 +177,7 @@ type DuploReplicationControllerUpdateRequest struct {
 	Name                              string                 `json:"Name"`
 	Image                             string                 `json:"DockerImage"`
 	AgentPlatform                     int                    `json:"AgentPlatform"`
-	Replicas                          int                    `json:"Replicas,omitempty"`
+	Replicas                          int                    `json:"Replicas"`
 	ReplicasMatchingAsgName           string                 `json:"ReplicasMatchingAsgName,omitempty"`
 	ForceStatefulSet                  bool                   `json:"ForceStatefulSet,omitempty"`
 	IsDaemonset                       bool                   `json:"IsDaemonset"`
@@ -206,6 +205,16 @@ type DuploReplicationControllerDeleteRequest struct {
 	State         string `json:"State"`
 }
 
+// DuploReplicationControllerVolume represents an entry of the Volumes JSON of a replication controller.
+// For a stateful set, the entries with an access mode and a size become volume claim templates.
+type DuploReplicationControllerVolume struct {
+	Name             string `json:"Name"`
+	Path             string `json:"Path,omitempty"`
+	AccessMode       string `json:"AccessMode,omitempty"`
+	Size             string `json:"Size,omitempty"`
+	StorageClassName string `json:"StorageClassName,omitempty"`
+}
+
 type DuploLbDnsRequest struct {
 	ReplicationControllerName string `json:"ReplicationControllerName"`
 	DnsPrfx                   string `json:"Name"`
@@ -430,23 +439,11 @@ func (c *Client) ReplicationControllerLbConfigurationDeleteAll(tenantID, name st
 func (c *Client) ReplicationControllerLbWafGet(tenantID, name string) (string, ClientError) {
 	wafAclId := ""
 
-	_, err := RetryWithExponentialBackoff(func() (interface{}, ClientError) {
-		err := c.getAPI(
-			fmt.Sprintf("ReplicationControllerLbGetWaf(%s, %s)", tenantID, name),
-			fmt.Sprintf("subscriptions/%s/GetWafInLb/%s", tenantID, name),
-			&wafAclId,
-		)
-		return &wafAclId, err
-	},
-		RetryConfig{
-			MinDelay:  1 * time.Second,
-			MaxDelay:  5 * time.Second,
-			MaxJitter: 2000,
-			Timeout:   60 * time.Second,
-			IsRetryable: func(error ClientError) bool {
-				return error.Status() == 400 || error.Status() == 500 || strings.Contains(error.Error(), "context deadline exceeded")
-			},
-		})
+	err := c.withBusyRetries(http.StatusBadRequest, http.StatusInternalServerError).getAPI(
+		fmt.Sprintf("ReplicationControllerLbGetWaf(%s, %s)", tenantID, name),
+		fmt.Sprintf("subscriptions/%s/GetWafInLb/%s", tenantID, name),
+		&wafAclId,
+	)
 	return wafAclId, err
 }
 
@@ -123,7 +123,7 @@ func (c *Client) TenantGetV2(tenantID string) (*DuploTenant, ClientError) {
 	rp := DuploTenant{}
 
 	// Get the tenant from Duplo
-	err := c.getAPI(apiName, fmt.Sprintf("v2/admin/TenantV2/%s", tenantID), &rp)
+	err := c.getAPICached(apiName, fmt.Sprintf("v2/admin/TenantV2/%s", tenantID), &rp, func() bool { return rp.TenantID != "" })
 	if err != nil || rp.TenantID == "" {
 		return nil, err
 	}
@@ -136,7 +136,7 @@ func (c *Client) TenantGetV3(tenantID string) (*DuploTenant, ClientError) {
 	rp := DuploTenant{}
 
 	// Get the tenant from Duplo
-	err := c.getAPI(apiName, fmt.Sprintf("v3/admin/tenant/%s", tenantID), &rp)
+	err := c.getAPICached(apiName, fmt.Sprintf("v3/admin/tenant/%s", tenantID), &rp, func() bool { return rp.TenantID != "" })
 	if err != nil || rp.TenantID == "" {
 		return nil, err
 	}
@@ -145,10 +145,12 @@ func (c *Client) TenantGetV3(tenantID string) (*DuploTenant, ClientError) {
 
 // TenantCreate creates a tenant via the Duplo API.
 func (c *Client) TenantCreate(rq DuploTenant) ClientError {
+	defer c.invalidateTenantCache("")
 	return c.postAPI(fmt.Sprintf("TenantCreate(%s, %s)", rq.AccountName, rq.PlanID), "admin/AddTenant", &rq, nil)
 }
 
 func (c *Client) TenantCreateAzure(rq DuploTenant) (string, ClientError) {
+	defer c.invalidateTenantCache("")
 	rp := ""
 	err := c.postAPI(fmt.Sprintf("TenantCreateAzure(%s, %s)", rq.AccountName, rq.PlanID), "admin/AddTenant", &rq, &rp)
 	if err != nil {
@@ -159,6 +161,7 @@ func (c *Client) TenantCreateAzure(rq DuploTenant) (string, ClientError) {
 
 // TenantDelete deletes an AWS host via the Duplo API.
 func (c *Client) TenantDelete(tenantID string) ClientError {
+	defer c.invalidateTenantCache(tenantID)
 	return c.postAPI(fmt.Sprintf("TenantDelete(%s)", tenantID), fmt.Sprintf("admin/DeleteTenant/%s", tenantID), "", nil)
 }
 
@@ -166,7 +169,7 @@ func (c *Client) TenantDelete(tenantID string) ClientError {
 func (c *Client) ListTenantsForUser() ([]DuploTenant, ClientError) {
 	list := []DuploTenant{}
 
-	err := c.getAPI("ListTenantsForUser()", "admin/GetTenantsForUser", &list)
+	err := c.getAPICached("ListTenantsForUser()", "admin/GetTenantsForUser", &list, func() bool { return len(list) > 0 })
 	if err != nil {
 		return nil, err
 	}
@@ -338,10 +341,36 @@ func (c *Client) TenantGetExternalSubnets(tenantID string) ([]string, ClientErro
 // TenantGetAwsAccountID retrieves the AWS account ID via the Duplo API.
 func (c *Client) TenantGetAwsAccountID(tenantID string) (string, ClientError) {
 	awsAccountID := ""
-	err := c.getAPI(fmt.Sprintf("TenantGetAwsAccountID(%s)", tenantID), fmt.Sprintf("subscriptions/%s/GetTenantAwsAccountId", tenantID), &awsAccountID)
+	err := c.getAPICached(fmt.Sprintf("TenantGetAwsAccountID(%s)", tenantID), fmt.Sprintf("subscriptions/%s/GetTenantAwsAccountId", tenantID), &awsAccountID, func() bool { return awsAccountID != "" })
 	return awsAccountID, err
 }
 
+// TenantGetAwsIamRoleArn builds the ARN of the IAM role that Duplo creates for a tenant.
+func (c *Client) TenantGetAwsIamRoleArn(tenantID string) (string, ClientError) {
+	features, err := c.AdminGetSystemFeatures()
+	if err != nil {
+		return "", err
+	}
+	prefix := "duploservices"
+	if features.ResourceNamePrefix != "" {
+		prefix = features.ResourceNamePrefix
+	}
+	partition := "aws"
+	if features.DefaultAwsPartition != "" {
+		partition = features.DefaultAwsPartition
+	}
+
+	roleName, err := c.GetResourcePrefix(prefix, tenantID)
+	if err != nil {
+		return "", err
+	}
+	accountID, err := c.TenantGetAwsAccountID(tenantID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, roleName), nil
+}
+
 // TenantGetGcpProjectID retrieves the GCP project ID via the Duplo API.
 func (c *Client) TenantGetGcpProjectID(tenantID string) (string, ClientError) {
 	plan, err := c.GetTenantPlan(tenantID)
@@ -522,6 +551,7 @@ func (c *Client) TenantTagsGetByKey(tenantId, key string) (*DuploKeyStringValue,
 }
 
 func (c *Client) TenantTagCreate(req DuploTenantConfigUpdateRequest) ClientError {
+	defer c.invalidateTenantCache(req.TenantID)
 	return c.postAPI(fmt.Sprintf("TenantTagCreate(%s)", req.TenantID),
 		"adminproxy/TenantTagUpdate",
 		req,
@@ -529,6 +559,7 @@ func (c *Client) TenantTagCreate(req DuploTenantConfigUpdateRequest) ClientError
 }
 
 func (c *Client) TenantTagDelete(req DuploTenantConfigUpdateRequest) ClientError {
+	defer c.invalidateTenantCache(req.TenantID)
 	return c.postAPI(fmt.Sprintf("TenantTagDelete(%s)", req.TenantID),
 		"adminproxy/TenantTagUpdate",
 		req,
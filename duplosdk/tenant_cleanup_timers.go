@@ -33,5 +33,6 @@ func (c *Client) GetTenantCleanUpTimers(tenantId string) (*DuploTenantCleanUpTim
 // UpdateTenantCleanUpTimers updates the clean-up timers of a tenant.
 func (c *Client) UpdateTenantCleanUpTimers(expiry *DuploTenantCleanUpTimersUpdateRequest) ClientError {
 	apiName := fmt.Sprintf("UpdateTenantCleanUpTimers(%s)", expiry.TenantId)
+	defer c.invalidateTenantCache(expiry.TenantId)
 	return c.postAPI(apiName, "adminproxy/UpdateTenantCleanupTimers", expiry, nil)
 }
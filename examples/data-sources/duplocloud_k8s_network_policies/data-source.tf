@@ -0,0 +1,7 @@
+data "duplocloud_k8s_network_policies" "all" {
+  tenant_id = var.tenant_id
+}
+
+output "network_policy_names" {
+  value = data.duplocloud_k8s_network_policies.all.network_policies[*].name
+}
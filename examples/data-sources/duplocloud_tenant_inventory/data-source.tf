@@ -0,0 +1,16 @@
+data "duplocloud_tenant_inventory" "all" {
+  tenant_id = "tenant_id"
+}
+
+# Only list S3 buckets and services.
+data "duplocloud_tenant_inventory" "some" {
+  tenant_id = "tenant_id"
+  types     = ["s3_bucket", "duplo_service"]
+}
+
+output "out" {
+  value = {
+    resources = data.duplocloud_tenant_inventory.all.resources
+    buckets   = [for r in data.duplocloud_tenant_inventory.some.resources : r.cloud_id if r.type == "s3_bucket"]
+  }
+}
@@ -0,0 +1,8 @@
+ephemeral "duplocloud_admin_aws_credentials" "admin" {}
+
+provider "aws" {
+  region     = ephemeral.duplocloud_admin_aws_credentials.admin.region
+  access_key = ephemeral.duplocloud_admin_aws_credentials.admin.access_key_id
+  secret_key = ephemeral.duplocloud_admin_aws_credentials.admin.secret_access_key
+  token      = ephemeral.duplocloud_admin_aws_credentials.admin.session_token
+}
@@ -0,0 +1,9 @@
+ephemeral "duplocloud_eks_credentials" "cluster" {
+  plan_id = "nonprod"
+}
+
+provider "kubernetes" {
+  host                   = ephemeral.duplocloud_eks_credentials.cluster.endpoint
+  cluster_ca_certificate = ephemeral.duplocloud_eks_credentials.cluster.ca_certificate_data
+  token                  = ephemeral.duplocloud_eks_credentials.cluster.token
+}
@@ -0,0 +1,12 @@
+data "duplocloud_tenant" "myapp" {
+  name = "myapp"
+}
+
+ephemeral "duplocloud_k8_secret" "registry" {
+  tenant_id   = data.duplocloud_tenant.myapp.id
+  secret_name = "registry-credentials"
+}
+
+locals {
+  registry = jsondecode(ephemeral.duplocloud_k8_secret.registry.secret_data)
+}
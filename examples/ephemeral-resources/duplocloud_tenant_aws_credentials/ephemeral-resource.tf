@@ -0,0 +1,14 @@
+data "duplocloud_tenant" "myapp" {
+  name = "myapp"
+}
+
+ephemeral "duplocloud_tenant_aws_credentials" "myapp" {
+  tenant_id = data.duplocloud_tenant.myapp.id
+}
+
+provider "aws" {
+  region     = ephemeral.duplocloud_tenant_aws_credentials.myapp.region
+  access_key = ephemeral.duplocloud_tenant_aws_credentials.myapp.access_key_id
+  secret_key = ephemeral.duplocloud_tenant_aws_credentials.myapp.secret_access_key
+  token      = ephemeral.duplocloud_tenant_aws_credentials.myapp.session_token
+}
@@ -0,0 +1,15 @@
+data "duplocloud_tenant" "myapp" {
+  name = "myapp"
+}
+
+ephemeral "duplocloud_tenant_eks_credentials" "myapp" {
+  tenant_id = data.duplocloud_tenant.myapp.id
+}
+
+provider "helm" {
+  kubernetes {
+    host                   = ephemeral.duplocloud_tenant_eks_credentials.myapp.endpoint
+    cluster_ca_certificate = ephemeral.duplocloud_tenant_eks_credentials.myapp.ca_certificate_data
+    token                  = ephemeral.duplocloud_tenant_eks_credentials.myapp.token
+  }
+}
@@ -0,0 +1,12 @@
+locals {
+  secret = provider::duplocloud::parse_id(duplocloud_k8_secret.myapp.id)
+}
+
+# The tenant and name of the secret.
+output "secret_tenant_id" {
+  value = local.secret.tenant_id
+}
+
+output "secret_name" {
+  value = local.secret.name
+}
@@ -0,0 +1,14 @@
+# The full name of an SQS queue:  duploservices-myapp-jobs
+output "queue_name" {
+  value = provider::duplocloud::service_name("myapp", "jobs")
+}
+
+# The full name of an S3 bucket, which is suffixed with the AWS account ID:  duploservices-myapp-logs-123456789012
+output "bucket_name" {
+  value = provider::duplocloud::service_name("myapp", "logs", { account_id = "123456789012" })
+}
+
+# The full name of an SQS queue, on a portal that uses another resource name prefix:  acme-myapp-jobs
+output "queue_name_custom_prefix" {
+  value = provider::duplocloud::service_name("myapp", "jobs", { prefix = "acme" })
+}
@@ -0,0 +1,14 @@
+# The short name of an SQS queue:  jobs
+output "queue_short_name" {
+  value = provider::duplocloud::unwrap_name("myapp", "duploservices-myapp-jobs")
+}
+
+# The short name of an S3 bucket, from its ARN:  logs
+output "bucket_short_name" {
+  value = provider::duplocloud::unwrap_name("myapp", "arn:aws:s3:::duploservices-myapp-logs-123456789012", { account_id = "123456789012" })
+}
+
+# The short name of an SQS queue, on a portal that uses another resource name prefix:  jobs
+output "queue_short_name_custom_prefix" {
+  value = provider::duplocloud::unwrap_name("myapp", "acme-myapp-jobs", { prefix = "acme" })
+}
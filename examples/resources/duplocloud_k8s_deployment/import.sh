@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes deployment
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the Duplo service that runs the deployment
+#
+terraform import duplocloud_k8s_deployment.web v3/subscriptions/*TENANT_ID*/k8s/deployment/*NAME*
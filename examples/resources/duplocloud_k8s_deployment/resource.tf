@@ -0,0 +1,47 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+resource "duplocloud_k8s_deployment" "web" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "web"
+  }
+  spec {
+    replicas = 2
+    strategy {
+      type = "RollingUpdate"
+      rolling_update {
+        max_surge       = "1"
+        max_unavailable = "0"
+      }
+    }
+    template {
+      metadata {
+        labels = {
+          tier = "frontend"
+        }
+      }
+      spec {
+        container {
+          name  = "web"
+          image = "nginx:1.27"
+          port {
+            container_port = 80
+          }
+          env {
+            name  = "MODE"
+            value = "production"
+          }
+          readiness_probe {
+            http_get {
+              path = "/"
+              port = 80
+            }
+          }
+        }
+      }
+    }
+  }
+}
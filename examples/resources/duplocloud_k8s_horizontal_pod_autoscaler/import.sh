@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes horizontal pod autoscaler
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the horizontal pod autoscaler
+#
+terraform import duplocloud_k8s_horizontal_pod_autoscaler.web v3/subscriptions/*TENANT_ID*/k8s/horizontalPodAutoscaler/*NAME*
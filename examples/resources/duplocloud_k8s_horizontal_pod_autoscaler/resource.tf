@@ -0,0 +1,57 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+# Scale a deployment on CPU utilization, and on the depth of an external queue.
+resource "duplocloud_k8s_horizontal_pod_autoscaler" "web" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "web"
+  }
+  spec {
+    min_replicas = 2
+    max_replicas = 10
+    scale_target_ref {
+      kind = "Deployment"
+      name = "web"
+    }
+    metric {
+      type = "Resource"
+      resource {
+        name = "cpu"
+        target {
+          type                = "Utilization"
+          average_utilization = 70
+        }
+      }
+    }
+    metric {
+      type = "External"
+      external {
+        metric {
+          name = "sqs_messages_visible"
+          selector {
+            match_labels = {
+              queue = "jobs"
+            }
+          }
+        }
+        target {
+          type          = "AverageValue"
+          average_value = "30"
+        }
+      }
+    }
+    behavior {
+      scale_down {
+        stabilization_window_seconds = 600
+        policy {
+          type           = "Pods"
+          value          = 1
+          period_seconds = 60
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,7 @@
+# Example: Importing an existing kubernetes object
+#  - *TENANT_ID* is the tenant GUID
+#  - *API_VERSION* is the apiVersion of the object, such as v1 or cert-manager.io/v1
+#  - *KIND* is the kind of the object
+#  - *NAME* is the name of the object
+#
+terraform import duplocloud_k8s_manifest.certificate *TENANT_ID*/*API_VERSION*/*KIND*/*NAME*
@@ -0,0 +1,57 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+# A CRD instance from a YAML manifest.
+resource "duplocloud_k8s_manifest" "secret_provider_class" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  manifest  = <<-EOT
+    apiVersion: secrets-store.csi.x-k8s.io/v1
+    kind: SecretProviderClass
+    metadata:
+      name: app-secrets
+    spec:
+      provider: aws
+      parameters:
+        objects: |
+          - objectName: "app-secrets"
+            objectType: "secretsmanager"
+  EOT
+}
+
+# An object built with jsonencode, waiting until it is ready.
+resource "duplocloud_k8s_manifest" "certificate" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  manifest = jsonencode({
+    apiVersion = "cert-manager.io/v1"
+    kind       = "Certificate"
+    metadata = {
+      name = "web"
+    }
+    spec = {
+      secretName = "web-tls"
+      dnsNames   = ["web.example.com"]
+      issuerRef = {
+        kind = "ClusterIssuer"
+        name = "letsencrypt"
+      }
+    }
+  })
+
+  wait_for {
+    condition {
+      type   = "Ready"
+      status = "True"
+    }
+  }
+
+  timeouts {
+    create = "10m"
+  }
+}
+
+# The live object, including its status.  The object is sensitive, as it may be a Secret.
+output "certificate_not_after" {
+  value = nonsensitive(jsondecode(duplocloud_k8s_manifest.certificate.object).status.notAfter)
+}
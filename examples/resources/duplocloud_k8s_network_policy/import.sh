@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes network policy
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the network policy
+#
+terraform import duplocloud_k8s_network_policy.api v3/subscriptions/*TENANT_ID*/k8s/networkPolicy/*NAME*
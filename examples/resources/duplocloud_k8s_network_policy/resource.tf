@@ -0,0 +1,61 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+# Only allow the web pods to reach the API pods, and only allow the API pods to resolve DNS.
+resource "duplocloud_k8s_network_policy" "api" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "api"
+  }
+  spec {
+    pod_selector {
+      match_labels = {
+        app = "api"
+      }
+    }
+    policy_types = ["Ingress", "Egress"]
+
+    ingress {
+      from {
+        pod_selector {
+          match_labels = {
+            app = "web"
+          }
+        }
+      }
+      port {
+        port = "8080"
+      }
+    }
+
+    egress {
+      port {
+        port     = "53"
+        protocol = "UDP"
+      }
+    }
+  }
+}
+
+# Allow traffic from a CIDR range, except for one subnet.
+resource "duplocloud_k8s_network_policy" "vpn" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "allow-vpn"
+  }
+  spec {
+    pod_selector {}
+    policy_types = ["Ingress"]
+
+    ingress {
+      from {
+        ip_block {
+          cidr   = "10.100.0.0/16"
+          except = ["10.100.1.0/24"]
+        }
+      }
+    }
+  }
+}
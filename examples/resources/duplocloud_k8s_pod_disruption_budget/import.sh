@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes pod disruption budget
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the pod disruption budget
+#
+terraform import duplocloud_k8s_pod_disruption_budget.web v3/subscriptions/*TENANT_ID*/k8s/podDisruptionBudget/*NAME*
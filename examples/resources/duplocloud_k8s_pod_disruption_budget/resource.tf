@@ -0,0 +1,19 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+resource "duplocloud_k8s_pod_disruption_budget" "web" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "web"
+  }
+  spec {
+    max_unavailable = "25%"
+    selector {
+      match_labels = {
+        app = "web"
+      }
+    }
+  }
+}
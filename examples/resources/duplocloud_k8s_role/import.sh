@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes role
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the role
+#
+terraform import duplocloud_k8s_role.pod_reader v3/subscriptions/*TENANT_ID*/k8s/role/*NAME*
@@ -0,0 +1,16 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+resource "duplocloud_k8s_role" "pod_reader" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "pod-reader"
+  }
+  rule {
+    api_groups = [""]
+    resources  = ["pods", "pods/log"]
+    verbs      = ["get", "list", "watch"]
+  }
+}
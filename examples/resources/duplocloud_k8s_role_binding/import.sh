@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes role binding
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the role binding
+#
+terraform import duplocloud_k8s_role_binding.worker_pod_reader v3/subscriptions/*TENANT_ID*/k8s/roleBinding/*NAME*
@@ -0,0 +1,39 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+resource "duplocloud_k8s_service_account" "worker" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "worker"
+  }
+}
+
+resource "duplocloud_k8s_role" "pod_reader" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "pod-reader"
+  }
+  rule {
+    api_groups = [""]
+    resources  = ["pods"]
+    verbs      = ["get", "list", "watch"]
+  }
+}
+
+# Binds the role to the service account, which is in the tenant's namespace by default.
+resource "duplocloud_k8s_role_binding" "worker_pod_reader" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "worker-pod-reader"
+  }
+  role_ref {
+    kind = "Role"
+    name = duplocloud_k8s_role.pod_reader.metadata[0].name
+  }
+  subject {
+    kind = "ServiceAccount"
+    name = duplocloud_k8s_service_account.worker.metadata[0].name
+  }
+}
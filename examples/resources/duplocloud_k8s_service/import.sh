@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes service
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the service
+#
+terraform import duplocloud_k8s_service.web v3/subscriptions/*TENANT_ID*/k8s/service/*NAME*
@@ -0,0 +1,25 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+resource "duplocloud_k8s_service" "web" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "web"
+    labels = {
+      app = "web"
+    }
+  }
+  spec {
+    type = "ClusterIP"
+    selector = {
+      app = "web"
+    }
+    port {
+      name        = "http"
+      port        = 80
+      target_port = "8080"
+    }
+  }
+}
@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes service account
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the service account
+#
+terraform import duplocloud_k8s_service_account.worker v3/subscriptions/*TENANT_ID*/k8s/serviceAccount/*NAME*
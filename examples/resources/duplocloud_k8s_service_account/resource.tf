@@ -0,0 +1,13 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+# A service account whose pods can use the tenant's IAM role, through IRSA.
+resource "duplocloud_k8s_service_account" "worker" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "worker"
+  }
+  attach_tenant_iam_role = true
+}
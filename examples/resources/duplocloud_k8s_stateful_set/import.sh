@@ -0,0 +1,5 @@
+# Example: Importing an existing kubernetes stateful set
+#  - *TENANT_ID* is the tenant GUID
+#  - *NAME* is the name of the Duplo service that runs the stateful set
+#
+terraform import duplocloud_k8s_stateful_set.db v3/subscriptions/*TENANT_ID*/k8s/statefulSet/*NAME*
@@ -0,0 +1,46 @@
+resource "duplocloud_tenant" "myapp" {
+  account_name = "myapp"
+  plan_id      = "default"
+}
+
+resource "duplocloud_k8s_stateful_set" "db" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+  metadata {
+    name = "db"
+  }
+  spec {
+    replicas = 1
+    template {
+      spec {
+        container {
+          name  = "db"
+          image = "postgres:16"
+          env {
+            name  = "PGDATA"
+            value = "/var/lib/postgresql/data/pgdata"
+          }
+
+          # Mounts the volume claim template below.
+          volume_mount {
+            name       = "data"
+            mount_path = "/var/lib/postgresql/data"
+          }
+        }
+      }
+    }
+    volume_claim_template {
+      metadata {
+        name = "data"
+      }
+      spec {
+        access_modes = ["ReadWriteOnce"]
+        resources {
+          requests = {
+            storage = "10Gi"
+          }
+        }
+        storage_class_name = "gp3"
+      }
+    }
+  }
+}
@@ -22,3 +22,19 @@ resource "duplocloud_tenant_secret" "mysecret2" {
 
   data = jsonencode({ foo = "bar" })
 }
+
+# Example with write-only data, which is never stored in the state.
+ephemeral "random_password" "mypassword" {
+  length = 24
+}
+
+resource "duplocloud_tenant_secret" "mysecret3" {
+  tenant_id = duplocloud_tenant.myapp.tenant_id
+
+  # The full name will be:  duploservices-myapp-mypassword
+  name_suffix = "mypassword"
+
+  # Increment the version to send a new password.
+  data_wo         = ephemeral.random_password.mypassword.result
+  data_wo_version = 1
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/duplocloud/terraform-provider-duplocloud/duplocloud"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// generate runs the "generate" subcommand, which writes Terraform import blocks and resource bodies
+// for the objects that already exist in a tenant.
+//
+// The Duplo host and token are taken from the same environment variables and profiles as the provider.
+func generate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: terraform-provider-duplocloud generate --tenant <name> [--out <file>]\n\n")
+		flags.PrintDefaults()
+	}
+	tenant := flags.String("tenant", "", "name of the tenant whose objects are imported")
+	out := flags.String("out", "", "file to write the configuration to, instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *tenant == "" {
+		flags.Usage()
+		return 2
+	}
+
+	ctx := context.Background()
+	p := duplocloud.Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); printDiagnostics(diags) {
+		return 1
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if printDiagnostics(duplocloud.GenerateTenantConfig(ctx, p, *tenant, w)) {
+		return 1
+	}
+	return 0
+}
+
+// printDiagnostics prints diagnostics to stderr, and returns true if any of them is an error.
+func printDiagnostics(diags diag.Diagnostics) bool {
+	for _, d := range diags {
+		severity := "Warning"
+		if d.Severity == diag.Error {
+			severity = "Error"
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s\n", severity, d.Summary)
+		if d.Detail != "" {
+			fmt.Fprintf(os.Stderr, "  %s\n", d.Detail)
+		}
+	}
+	return diags.HasError()
+}
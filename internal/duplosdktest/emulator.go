@@ -11,6 +11,8 @@ import (
 	"github.com/julienschmidt/httprouter"
 )
 
+// EmuResponder turns the body of a write request into the object stored by the emulator, and returns its ID.
+// Returning a nil object deletes the object with that ID instead, as older Duplo APIs do for `"State": "delete"`.
 type EmuResponder func(verb string, in interface{}) (id string, out interface{})
 type EmuFactory func() interface{}
 type EmuType struct {
@@ -29,6 +31,7 @@ var (
 
 func ResetEmulator() {
 	ResetFixtures()
+	resetFaults()
 	emuCreated = []interface{}{}
 	emuDeleted = []string{}
 }
@@ -55,7 +58,12 @@ func emuList(location string) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		l := emuLocation(location, ps)
 		log.Printf("[TRACE] emuList(%s)", l)
-		buff := ListFixtures(l)
+		var buff []byte
+		if hidden := emuHiddenChildren(l); len(hidden) > 0 {
+			buff = listFixtures(l, hidden)
+		} else {
+			buff = ListFixtures(l)
+		}
 		w.WriteHeader(200)
 		w.Write(buff) // nolint
 	}
@@ -66,21 +74,55 @@ func emuGet(location, idKey string) httprouter.Handle {
 		id := ps.ByName(idKey)
 		l := emuLocation(location, ps) + "/" + id
 		log.Printf("[TRACE] emuGet(%s)", l)
+		if !FixtureExists(l) || emuIsHidden(l) {
+			emuMissing(w, l)
+			return
+		}
+		buff := GetFixture(l)
+		w.WriteHeader(200)
+		w.Write(buff) // nolint
+	}
+}
+
+func emuGetOne(location string) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		l := emuLocation(location, ps)
+		log.Printf("[TRACE] emuGetOne(%s)", l)
+		if !FixtureExists(l) {
+			emuMissing(w, l)
+			return
+		}
 		buff := GetFixture(l)
 		w.WriteHeader(200)
 		w.Write(buff) // nolint
 	}
 }
 
-func emuWrite(logPrefix, location string, config EmuConfig, hasId, empty bool) httprouter.Handle {
+func emuWrite(logPrefix, typeName, location string, config EmuConfig, hasId, empty bool) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-		in := config.Types[location].Factory()
 		l := emuLocation(location, ps)
 		log.Printf("[TRACE] emu%s(%s)", logPrefix, l)
+		emuType, ok := config.Types[typeName]
+		if !ok {
+			emuNoType(w, r, typeName)
+			return
+		}
+		in := emuType.Factory()
 		unmarshallRequestBody(r, in)
-		id, out := config.Types[location].Responder(r.Method, in)
+		id, out := emuType.Responder(r.Method, in)
 		l += "/" + id
+
+		// The object is being deleted by a write.
+		if out == nil {
+			DeleteFixture(l)
+			emuUnhide(l)
+			emuDeleted = append(emuDeleted, l)
+			w.WriteHeader(200)
+			return
+		}
+
 		PostFixture(l, out)
+		emuHide(r, l)
 		emuCreated = append(emuCreated, out)
 		w.WriteHeader(200)
 		if !empty {
@@ -89,12 +131,36 @@ func emuWrite(logPrefix, location string, config EmuConfig, hasId, empty bool) h
 	}
 }
 
+func emuPutOne(location string, config EmuConfig) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		l := emuLocation(location, ps)
+		log.Printf("[TRACE] emuPutOne(%s)", l)
+		emuType, ok := config.Types[location]
+		if !ok {
+			emuNoType(w, r, location)
+			return
+		}
+		in := emuType.Factory()
+		unmarshallRequestBody(r, in)
+		_, out := emuType.Responder(r.Method, in)
+		PostFixture(l, out)
+		w.WriteHeader(200)
+		w.Write(fc[l]) // nolint
+	}
+}
+
 func emuPut(location string, config EmuConfig, empty bool) httprouter.Handle {
-	return emuWrite("Put", location, config, true, empty)
+	return emuWrite("Put", location, location, config, true, empty)
 }
 
 func emuPost(location string, config EmuConfig, empty bool) httprouter.Handle {
-	return emuWrite("Post", location, config, false, empty)
+	return emuWrite("Post", location, location, config, false, empty)
+}
+
+// emuPostAs is like emuPost, but for APIs whose objects are listed by another route, such as the
+// generic AWS cloud resources.  The type is looked up by typeName instead of by location.
+func emuPostAs(typeName, location string, config EmuConfig, empty bool) httprouter.Handle {
+	return emuWrite("Post", typeName, location, config, false, empty)
 }
 
 func emuDelete(location, idKey string) httprouter.Handle {
@@ -102,6 +168,7 @@ func emuDelete(location, idKey string) httprouter.Handle {
 		id := ps.ByName(idKey)
 		l := emuLocation(location, ps) + "/" + id
 		log.Printf("[TRACE] emuDelete(%s)", l)
+		emuUnhide(l)
 		if DeleteFixture(l) {
 			w.WriteHeader(204)
 		} else {
@@ -111,6 +178,18 @@ func emuDelete(location, idKey string) httprouter.Handle {
 	}
 }
 
+func emuMissing(w http.ResponseWriter, location string) {
+	log.Printf("[TRACE] emuMissing(%s)", location)
+	w.WriteHeader(404)
+	w.Write([]byte("No such object: " + location)) // nolint
+}
+
+func emuNoType(w http.ResponseWriter, r *http.Request, typeName string) {
+	log.Printf("[TRACE] emuNoType(%s %s): %s", r.Method, r.URL.Path, typeName)
+	w.WriteHeader(599)
+	w.Write([]byte("No test-case type for " + typeName)) // nolint
+}
+
 func emuNotFound(res http.ResponseWriter, req *http.Request) {
 	m := req.Method
 	path := req.URL.Path
@@ -160,7 +239,128 @@ func NewEmulator(config EmuConfig) *httptest.Server {
 	router.POST("/v2/subscriptions/:tenantId/NativeHostV2", emuPost("tenant/:tenantId/aws_host", config, false))
 	router.DELETE("/v2/subscriptions/:tenantId/NativeHostV2/:id", emuDelete("tenant/:tenantId/aws_host", "id"))
 
-	return SetupHttptest(router)
+	// system APIs
+	router.GET("/v3/features/system", emuGetOne("features/system"))
+	router.GET("/v3/admin/systemSettings/config", emuList("system_setting"))
+
+	// user APIs
+	router.GET("/admin/GetUserRoleInfo", emuGetOne("user/current"))
+	router.GET("/v3/admin/user/:username/tenantAccess", emuList("user/:username/tenant_access"))
+
+	// credential APIs
+	router.GET("/adminproxy/GetJITAwsConsoleAccessUrl", emuGetOne("admin/aws_credentials"))
+	router.GET("/subscriptions/:tenantId/GetAwsConsoleTokenUrl", emuGetOne("tenant/:tenantId/aws_credentials"))
+	router.GET("/subscriptions/:tenantId/GetTenantAwsAccountId", emuGetOne("tenant/:tenantId/aws_account_id"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/jitAccess", emuGetOne("tenant/:tenantId/k8s_credentials"))
+
+	// plan APIs
+	router.GET("/v3/admin/plans/:planId/settings", emuGetOne("plan/:planId/settings"))
+	router.PUT("/v3/admin/plans/:planId/settings", emuPutOne("plan/:planId/settings", config))
+	router.GET("/v3/admin/plans/:planId/k8sConfig", emuGetOne("plan/:planId/k8s_credentials"))
+
+	// service APIs
+	router.GET("/subscriptions/:tenantId/GetReplicationControllers", emuList("tenant/:tenantId/replication_controller"))
+	router.GET("/v3/subscriptions/:tenantId/replicationcontroller/:name", emuGet("tenant/:tenantId/replication_controller", "name"))
+	router.POST("/subscriptions/:tenantId/ReplicationControllerUpdate", emuPost("tenant/:tenantId/replication_controller", config, true))
+	router.PUT("/v3/subscriptions/:tenantId/replicationcontroller", emuPut("tenant/:tenantId/replication_controller", config, true))
+	router.POST("/subscriptions/:tenantId/ReplicationControllerChangeAll", emuPut("tenant/:tenantId/replication_controller", config, true))
+	router.DELETE("/v3/subscriptions/:tenantId/replicationcontroller/:name", emuDelete("tenant/:tenantId/replication_controller", "name"))
+	router.GET("/subscriptions/:tenantId/GetLBConfigurations", emuList("tenant/:tenantId/lb_configuration"))
+	router.POST("/subscriptions/:tenantId/LBConfigurationUpdate", emuPost("tenant/:tenantId/lb_configuration", config, true))
+
+	// K8s secret and config map APIs
+	router.GET("/subscriptions/:tenantId/GetAllK8Secrets", emuList("tenant/:tenantId/k8s_secret"))
+	router.POST("/subscriptions/:tenantId/CreateOrUpdateK8Secret", emuPost("tenant/:tenantId/k8s_secret", config, true))
+	router.DELETE("/v2/subscriptions/:tenantId/K8SecretApiV2/:name", emuDelete("tenant/:tenantId/k8s_secret", "name"))
+	router.GET("/v2/subscriptions/:tenantId/K8ConfigMapApiV2", emuList("tenant/:tenantId/k8s_config_map"))
+	router.GET("/v2/subscriptions/:tenantId/K8ConfigMapApiV2/:name", emuGet("tenant/:tenantId/k8s_config_map", "name"))
+	router.POST("/v2/subscriptions/:tenantId/K8ConfigMapApiV2", emuPost("tenant/:tenantId/k8s_config_map", config, false))
+	router.PUT("/v2/subscriptions/:tenantId/K8ConfigMapApiV2", emuPut("tenant/:tenantId/k8s_config_map", config, false))
+	router.DELETE("/v2/subscriptions/:tenantId/K8ConfigMapApiV2/:name", emuDelete("tenant/:tenantId/k8s_config_map", "name"))
+
+	// K8s service, autoscaler and disruption budget APIs
+	router.GET("/v3/subscriptions/:tenantId/k8s/service", emuList("tenant/:tenantId/k8s_service"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/service/:name", emuGet("tenant/:tenantId/k8s_service", "name"))
+	router.POST("/v3/subscriptions/:tenantId/k8s/service", emuPost("tenant/:tenantId/k8s_service", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/k8s/service/:name", emuPut("tenant/:tenantId/k8s_service", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/k8s/service/:name", emuDelete("tenant/:tenantId/k8s_service", "name"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/horizontalPodAutoscaler", emuList("tenant/:tenantId/k8s_horizontal_pod_autoscaler"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/horizontalPodAutoscaler/:name", emuGet("tenant/:tenantId/k8s_horizontal_pod_autoscaler", "name"))
+	router.POST("/v3/subscriptions/:tenantId/k8s/horizontalPodAutoscaler", emuPost("tenant/:tenantId/k8s_horizontal_pod_autoscaler", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/k8s/horizontalPodAutoscaler/:name", emuPut("tenant/:tenantId/k8s_horizontal_pod_autoscaler", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/k8s/horizontalPodAutoscaler/:name", emuDelete("tenant/:tenantId/k8s_horizontal_pod_autoscaler", "name"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/podDisruptionBudget", emuList("tenant/:tenantId/k8s_pod_disruption_budget"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/podDisruptionBudget/:name", emuGet("tenant/:tenantId/k8s_pod_disruption_budget", "name"))
+	router.POST("/v3/subscriptions/:tenantId/k8s/podDisruptionBudget", emuPost("tenant/:tenantId/k8s_pod_disruption_budget", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/k8s/podDisruptionBudget/:name", emuPut("tenant/:tenantId/k8s_pod_disruption_budget", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/k8s/podDisruptionBudget/:name", emuDelete("tenant/:tenantId/k8s_pod_disruption_budget", "name"))
+
+	// K8s service account and RBAC APIs
+	router.GET("/v3/subscriptions/:tenantId/k8s/serviceAccount", emuList("tenant/:tenantId/k8s_service_account"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/serviceAccount/:name", emuGet("tenant/:tenantId/k8s_service_account", "name"))
+	router.POST("/v3/subscriptions/:tenantId/k8s/serviceAccount", emuPost("tenant/:tenantId/k8s_service_account", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/k8s/serviceAccount/:name", emuPut("tenant/:tenantId/k8s_service_account", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/k8s/serviceAccount/:name", emuDelete("tenant/:tenantId/k8s_service_account", "name"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/role", emuList("tenant/:tenantId/k8s_role"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/role/:name", emuGet("tenant/:tenantId/k8s_role", "name"))
+	router.POST("/v3/subscriptions/:tenantId/k8s/role", emuPost("tenant/:tenantId/k8s_role", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/k8s/role/:name", emuPut("tenant/:tenantId/k8s_role", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/k8s/role/:name", emuDelete("tenant/:tenantId/k8s_role", "name"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/roleBinding", emuList("tenant/:tenantId/k8s_role_binding"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/roleBinding/:name", emuGet("tenant/:tenantId/k8s_role_binding", "name"))
+	router.POST("/v3/subscriptions/:tenantId/k8s/roleBinding", emuPost("tenant/:tenantId/k8s_role_binding", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/k8s/roleBinding/:name", emuPut("tenant/:tenantId/k8s_role_binding", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/k8s/roleBinding/:name", emuDelete("tenant/:tenantId/k8s_role_binding", "name"))
+
+	// K8s network policy APIs
+	router.GET("/v3/subscriptions/:tenantId/k8s/networkPolicy", emuList("tenant/:tenantId/k8s_network_policy"))
+	router.GET("/v3/subscriptions/:tenantId/k8s/networkPolicy/:name", emuGet("tenant/:tenantId/k8s_network_policy", "name"))
+	router.POST("/v3/subscriptions/:tenantId/k8s/networkPolicy", emuPost("tenant/:tenantId/k8s_network_policy", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/k8s/networkPolicy/:name", emuPut("tenant/:tenantId/k8s_network_policy", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/k8s/networkPolicy/:name", emuDelete("tenant/:tenantId/k8s_network_policy", "name"))
+
+	// Kubernetes API server, for the tests that point the tenant's JIT credentials at the emulator
+	router.GET("/api/v1", emuGetOne("k8s/api/v1"))
+	router.GET("/api/v1/namespaces/:namespace/:resource/:name", emuGet("k8s/namespaces/:namespace/:resource", "name"))
+	router.PATCH("/api/v1/namespaces/:namespace/:resource/:name", emuPut("k8s/namespaces/:namespace/:resource", config, false))
+	router.DELETE("/api/v1/namespaces/:namespace/:resource/:name", emuDelete("k8s/namespaces/:namespace/:resource", "name"))
+	router.GET("/apis/:group/:version", emuGetOne("k8s/apis/:group/:version"))
+	router.GET("/apis/:group/:version/namespaces/:namespace/:resource/:name", emuGet("k8s/namespaces/:namespace/:resource", "name"))
+	router.PATCH("/apis/:group/:version/namespaces/:namespace/:resource/:name", emuPut("k8s/namespaces/:namespace/:resource", config, false))
+	router.DELETE("/apis/:group/:version/namespaces/:namespace/:resource/:name", emuDelete("k8s/namespaces/:namespace/:resource", "name"))
+
+	// tenant secret APIs
+	router.GET("/v3/subscriptions/:tenantId/aws/secret", emuList("tenant/:tenantId/aws_secret"))
+	router.GET("/v3/subscriptions/:tenantId/aws/secret/:name", emuGet("tenant/:tenantId/aws_secret", "name"))
+	router.POST("/v3/subscriptions/:tenantId/aws/secret", emuPost("tenant/:tenantId/aws_secret", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/aws/secret/:name", emuPut("tenant/:tenantId/aws_secret", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/aws/secret/:name", emuDelete("tenant/:tenantId/aws_secret", "name"))
+
+	// RDS APIs
+	router.GET("/v3/subscriptions/:tenantId/aws/rds/instance", emuList("tenant/:tenantId/rds_instance"))
+	router.GET("/v3/subscriptions/:tenantId/aws/rds/instance/:name", emuGet("tenant/:tenantId/rds_instance", "name"))
+	router.POST("/v3/subscriptions/:tenantId/aws/rds/instance", emuPost("tenant/:tenantId/rds_instance", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/aws/rds/instance/:name", emuPut("tenant/:tenantId/rds_instance", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/aws/rds/instance/:name", emuDelete("tenant/:tenantId/rds_instance", "name"))
+
+	// S3 and load balancer APIs
+	router.GET("/subscriptions/:tenantId/GetCloudResources", emuList("tenant/:tenantId/aws_cloud_resource"))
+	router.POST("/subscriptions/:tenantId/S3BucketUpdate", emuPostAs("tenant/:tenantId/aws_cloud_resource/s3_bucket", "tenant/:tenantId/aws_cloud_resource", config, true))
+	router.POST("/subscriptions/:tenantId/ApplicationLbUpdate", emuPostAs("tenant/:tenantId/aws_cloud_resource/application_lb", "tenant/:tenantId/aws_cloud_resource", config, true))
+	router.GET("/v3/subscriptions/:tenantId/aws/s3Bucket/:name", emuGet("tenant/:tenantId/s3_bucket", "name"))
+	router.POST("/v3/subscriptions/:tenantId/aws/s3Bucket", emuPost("tenant/:tenantId/s3_bucket", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/aws/s3Bucket/:name", emuPut("tenant/:tenantId/s3_bucket", config, false))
+	router.DELETE("/v3/subscriptions/:tenantId/aws/s3Bucket/:name", emuDelete("tenant/:tenantId/s3_bucket", "name"))
+
+	// ECS APIs
+	router.GET("/subscriptions/:tenantId/GetEcsServices", emuList("tenant/:tenantId/ecs_service"))
+	router.GET("/v2/subscriptions/:tenantId/EcsServiceApiV2/:name", emuGet("tenant/:tenantId/ecs_service", "name"))
+	router.POST("/v2/subscriptions/:tenantId/EcsServiceApiV2", emuPost("tenant/:tenantId/ecs_service", config, false))
+	router.PUT("/v2/subscriptions/:tenantId/EcsServiceApiV2", emuPut("tenant/:tenantId/ecs_service", config, false))
+	router.PUT("/v3/subscriptions/:tenantId/aws/ecsService", emuPut("tenant/:tenantId/ecs_service", config, false))
+	router.DELETE("/v2/subscriptions/:tenantId/EcsServiceApiV2/:name", emuDelete("tenant/:tenantId/ecs_service", "name"))
+
+	return SetupHttptest(emuWithFaults(router))
 }
 
 func unmarshallRequestBody(req *http.Request, target interface{}) {
@@ -0,0 +1,208 @@
+package duplosdktest
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmuFault scripts how the emulator misbehaves for the requests that match it.
+type EmuFault struct {
+	// Method and Route select the requests, using the route syntax of NewEmulator, such as
+	// "/v3/subscriptions/:tenantId/aws/rds/instance/:name".  An empty Method matches any method.
+	Method string
+	Route  string
+
+	// Times is how many matching requests are affected, or 0 for all of them.
+	Times int
+
+	// Delay is how long to wait before responding.
+	Delay time.Duration
+
+	// Status and Body replace the response.  When Status is 0, the request is handled normally.
+	Status int
+	Body   string
+
+	// Truncate cuts the response body in half, as a dropped connection would.
+	Truncate bool
+
+	// HiddenReads, on a write route, makes the written object missing from the next HiddenReads
+	// reads of it or of its list, like an eventually consistent API.
+	HiddenReads int
+
+	route *regexp.Regexp
+	count int
+}
+
+// EmuFailTimes returns a fault that fails the first `times` matching requests with the given status.
+func EmuFailTimes(method, route string, times, status int) EmuFault {
+	return EmuFault{Method: method, Route: route, Times: times, Status: status, Body: http.StatusText(status)}
+}
+
+// EmuRateExceeded returns a fault that throttles the first `times` matching requests, like Duplo
+// does when it relays cloud provider throttling.
+func EmuRateExceeded(method, route string, times int) EmuFault {
+	return EmuFault{Method: method, Route: route, Times: times, Status: 400, Body: `{"Message":"Rate exceeded"}`}
+}
+
+// EmuEventuallyConsistent returns a fault that hides the objects written by a route from their
+// next `reads` reads.
+func EmuEventuallyConsistent(method, route string, reads int) EmuFault {
+	return EmuFault{Method: method, Route: route, HiddenReads: reads}
+}
+
+type emuFaultKey struct{}
+
+var (
+	emuMutex  sync.Mutex
+	emuFaults = []*EmuFault{}
+	emuHidden = map[string]int{}
+)
+
+// InjectFaults adds faults to the emulator, until the next call to ResetEmulator.
+// When several faults match a request, the first one that was injected applies.
+func InjectFaults(faults ...EmuFault) {
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	for i := range faults {
+		fault := faults[i]
+		fault.route = emuRouteRegexp(fault.Route)
+		emuFaults = append(emuFaults, &fault)
+	}
+}
+
+// EmuFaultCount returns how many requests were affected by the injected faults for a route.
+func EmuFaultCount(method, route string) int {
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	count := 0
+	for _, fault := range emuFaults {
+		if fault.Method == method && fault.Route == route {
+			count += fault.count
+		}
+	}
+	return count
+}
+
+func resetFaults() {
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	emuFaults = []*EmuFault{}
+	emuHidden = map[string]int{}
+}
+
+func emuRouteRegexp(route string) *regexp.Regexp {
+	pattern := emuParams.ReplaceAllLiteralString(regexp.QuoteMeta(route), "[^/]+")
+	return regexp.MustCompile("^" + pattern + "$")
+}
+
+// emuMatchFault returns the fault that applies to a request, if any, and counts it.
+func emuMatchFault(r *http.Request) *EmuFault {
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	for _, fault := range emuFaults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if fault.Times > 0 && fault.count >= fault.Times {
+			continue
+		}
+		if !fault.route.MatchString(r.URL.Path) {
+			continue
+		}
+		fault.count++
+		matched := *fault
+		return &matched
+	}
+	return nil
+}
+
+// emuHide hides a written object from its next reads, if the request's fault asks for it.
+func emuHide(r *http.Request, location string) {
+	fault, ok := r.Context().Value(emuFaultKey{}).(*EmuFault)
+	if !ok || fault.HiddenReads <= 0 {
+		return
+	}
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	log.Printf("[TRACE] emuHide(%s): hidden from the next %d reads", location, fault.HiddenReads)
+	emuHidden[location] = fault.HiddenReads
+}
+
+func emuUnhide(location string) {
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	delete(emuHidden, location)
+}
+
+// emuIsHidden returns true if an object is still hidden, and counts a read of it.
+func emuIsHidden(location string) bool {
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	if emuHidden[location] <= 0 {
+		return false
+	}
+	emuHidden[location]--
+	return true
+}
+
+// emuHiddenChildren returns the hidden objects under a location, and counts a read of each of them.
+func emuHiddenChildren(location string) map[string]bool {
+	emuMutex.Lock()
+	defer emuMutex.Unlock()
+	prefix := location + "/"
+	hidden := map[string]bool{}
+	for key, reads := range emuHidden {
+		if reads > 0 && strings.HasPrefix(key, prefix) && !strings.Contains(key[len(prefix):], "/") {
+			hidden[key] = true
+			emuHidden[key]--
+		}
+	}
+	return hidden
+}
+
+// emuWithFaults wraps the emulator's router, to apply injected faults.
+func emuWithFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault := emuMatchFault(r)
+		if fault == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		log.Printf("[TRACE] emuFault(%s %s): %+v", r.Method, r.URL.Path, *fault)
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if fault.Status != 0 {
+			w.WriteHeader(fault.Status)
+			w.Write([]byte(fault.Body)) // nolint
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), emuFaultKey{}, fault))
+		if !fault.Truncate {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		body := rec.Body.Bytes()
+		w.WriteHeader(rec.Code)
+		w.Write(body[:len(body)/2]) // nolint
+	})
+}
@@ -71,8 +71,14 @@ func DeleteFixture(location string) bool {
 }
 
 func ListFixtures(location string) []byte {
+	return listFixtures(location, nil)
+}
+
+// listFixtures lists the objects at a location, leaving out the skipped ones.
+// Lists are only cached when nothing is skipped.
+func listFixtures(location string, skip map[string]bool) []byte {
 	// Return the data if it is cached
-	if buff, ok := fc[location]; ok {
+	if buff, ok := fc[location]; ok && len(skip) == 0 {
 		return buff
 	}
 
@@ -109,6 +115,10 @@ func ListFixtures(location string) []byte {
 			continue
 		}
 
+		if skip[key] {
+			continue
+		}
+
 		if size != 2 {
 			size += 1 // leading comma
 		}
@@ -129,7 +139,9 @@ func ListFixtures(location string) []byte {
 	buff = append(buff, []byte("]")...)
 
 	// cache the result and return it
-	fc[location] = buff
+	if len(skip) == 0 {
+		fc[location] = buff
+	}
 	return buff
 }
 
@@ -150,6 +162,15 @@ func GetFixture(location string) []byte {
 	return buff
 }
 
+// FixtureExists reports whether an object exists at the location, either cached or as a permanent fixture.
+func FixtureExists(location string) bool {
+	if _, ok := fc[location]; ok {
+		return true
+	}
+	_, err := os.Stat(path.Join(fdir, location) + ".json")
+	return err == nil
+}
+
 func ResourceExists(location string) bool {
 	_, ok := fc[location]
 	return ok
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
+	"os"
+
 	"github.com/duplocloud/terraform-provider-duplocloud/duplocloud"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -19,18 +22,28 @@ import (
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate(os.Args[2:]))
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		Debug:        debug,
-		ProviderAddr: "registry.terraform.io/duplocloud/duplocloud",
-		ProviderFunc: func() *schema.Provider {
-			return duplocloud.Provider()
-		},
+	// The SDKv2 and plugin-framework providers are served as one provider.
+	factory, err := duplocloud.ProviderServerFactory(context.Background())
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	plugin.Serve(opts)
+	var opts []tf5server.ServeOpt
+	if debug {
+		opts = append(opts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/duplocloud/duplocloud", factory, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }